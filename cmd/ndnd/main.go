@@ -7,6 +7,7 @@ import (
 	dv "github.com/named-data/ndnd/dv/executor"
 	fw "github.com/named-data/ndnd/fw/executor"
 	tools "github.com/named-data/ndnd/tools"
+	"github.com/named-data/ndnd/tools/nfdc"
 )
 
 func main() {
@@ -32,6 +33,10 @@ func main() {
 			}},
 		}, {
 			// tools separator
+		}, {
+			Name: "nfdc",
+			Help: "Manage the local NDN forwarder",
+			Sub:  nfdc.Commands(),
 		}, {
 			Name: "ping",
 			Help: "Send Interests to an NDN ping server",
@@ -16,7 +16,11 @@ func (dv *Router) advertDataFetch(nodeId enc.Name, seqNo uint64) {
 	// debounce; wait before fetching, then check if this is still the latest
 	// sequence number known for this neighbor
 	time.Sleep(10 * time.Millisecond)
-	if ns := dv.neighbors.Get(nodeId); ns == nil || ns.AdvertSeq != seqNo {
+	dv.mutex.Lock()
+	ns := dv.neighbors.Get(nodeId)
+	latest := ns != nil && ns.AdvertSeq == seqNo
+	dv.mutex.Unlock()
+	if !latest {
 		return
 	}
 
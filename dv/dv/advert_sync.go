@@ -29,7 +29,8 @@ func (dv *Router) advertSyncSendInterest() (err error) {
 
 func (dv *Router) advertSyncSendInterestImpl(prefix enc.Name) (err error) {
 	// SVS v2 Sync Interest
-	syncName := append(prefix, enc.NewVersionComponent(2))
+	// The prefix is shared by all the Sync Interests, and must not be appended to
+	syncName := append(prefix.Clone(), enc.NewVersionComponent(2))
 
 	// Sync Interest parameters for SVS
 	cfg := &ndn.InterestConfig{
@@ -41,11 +42,14 @@ func (dv *Router) advertSyncSendInterestImpl(prefix enc.Name) (err error) {
 
 	// State Vector for our group
 	// TODO: switch to new TLV types
+	dv.mutex.Lock()
+	seqNo := dv.advertSyncSeq
+	dv.mutex.Unlock()
 	sv := &svs_2024.StateVectorAppParam{
 		StateVector: &svs_2024.StateVector{
 			Entries: []*svs_2024.StateVectorEntry{{
 				NodeId: dv.config.RouterName(),
-				SeqNo:  dv.advertSyncSeq,
+				SeqNo:  seqNo,
 			}},
 		},
 	}
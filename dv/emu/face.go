@@ -0,0 +1,102 @@
+package emu
+
+import (
+	"errors"
+	"sync/atomic"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/engine"
+	"github.com/named-data/ndnd/std/ndn"
+)
+
+// Size of the queue of packets sent by a forwarder to an application
+const appFaceQueueSize = 1024
+
+// NewEngine creates an engine for an application, connected to a forwarder.
+func NewEngine(fw *Forwarder) ndn.Engine {
+	return engine.NewBasicEngine(&appFace{fw: fw})
+}
+
+// appFace connects an application engine to the forwarder of a node.
+// The forwarder face is created when the face is opened, and removed when it is closed.
+type appFace struct {
+	fw      *Forwarder
+	faceID  atomic.Uint64
+	running atomic.Bool
+	toApp   chan []byte
+	closed  chan struct{}
+	onPkt   func(r enc.ParseReader) error
+	onError func(err error) error
+}
+
+func (f *appFace) Open() error {
+	if f.onError == nil || f.onPkt == nil {
+		return errors.New("face callbacks are not set")
+	}
+	if f.running.Load() {
+		return errors.New("face is already running")
+	}
+
+	toApp := make(chan []byte, appFaceQueueSize)
+	closed := make(chan struct{})
+	faceID := f.fw.addFace(true, func(frame []byte) {
+		// Drop packets when the application does not keep up
+		select {
+		case toApp <- frame:
+		default:
+		}
+	})
+	if faceID == 0 {
+		return errors.New("forwarder is stopped")
+	}
+
+	f.toApp, f.closed = toApp, closed
+	f.faceID.Store(faceID)
+	f.running.Store(true)
+	go f.run(toApp, closed)
+	return nil
+}
+
+func (f *appFace) run(toApp chan []byte, closed chan struct{}) {
+	for {
+		select {
+		case frame := <-toApp:
+			if err := f.onPkt(enc.NewBufferReader(frame)); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+func (f *appFace) Close() error {
+	if !f.running.Swap(false) {
+		return errors.New("face is not running")
+	}
+	close(f.closed)
+	f.fw.removeFace(f.faceID.Load())
+	return nil
+}
+
+func (f *appFace) Send(pkt enc.Wire) error {
+	if !f.running.Load() {
+		return errors.New("face is not running")
+	}
+	f.fw.receive(f.faceID.Load(), pkt.Join())
+	return nil
+}
+
+func (f *appFace) IsRunning() bool {
+	return f.running.Load()
+}
+
+func (f *appFace) IsLocal() bool {
+	return true
+}
+
+func (f *appFace) SetCallback(onPkt func(r enc.ParseReader) error,
+	onError func(err error) error) {
+	f.onPkt = onPkt
+	f.onError = onError
+}
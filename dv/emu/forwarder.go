@@ -0,0 +1,428 @@
+package emu
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/log"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/utils"
+)
+
+// Lifetime of Interests without an InterestLifetime
+const defaultInterestLifetime = 4 * time.Second
+
+// Time during which an Interest with the same name and nonce is a loop
+const deadNonceLifetime = 6 * time.Second
+
+// Size of the queue of packets received by a forwarder
+const forwarderQueueSize = 1024
+
+var localhost = enc.Name{enc.NewStringComponent(enc.TypeGenericNameComponent, "localhost")}
+var localhop = enc.Name{enc.NewStringComponent(enc.TypeGenericNameComponent, "localhop")}
+var mgmtPrefix = append(localhost, enc.NewStringComponent(enc.TypeGenericNameComponent, "nfd"))
+
+// Forwarder is a minimal NDN forwarder, one of which runs on each node of an emulated
+// network. YaNFD keeps its tables in package variables and cannot run several times in a
+// program, so the emulation implements the subset of NFD that routers and applications rely on:
+//   - a RIB with the ChildInherit and Capture flags, registered with management commands,
+//   - the best-route and multicast strategies, chosen per prefix,
+//   - a PIT aggregating Interests, and a dead nonce list detecting loops,
+//   - the /localhost and /localhop scopes,
+//   - the readvertisement of client routes to the router (/localhost/nlsr).
+//
+// There is no content store and Nacks are not sent.
+// All the tables are only accessed by the goroutine of the forwarder.
+type Forwarder struct {
+	// name of the node, for logging
+	name string
+	// packets received by the faces
+	queue chan fwFrame
+	// functions run by the forwarder goroutine
+	tasks chan func()
+	// stop the forwarder
+	stop chan struct{}
+
+	// faces by ID
+	faces map[uint64]*fwFace
+	// ID of the next face
+	nextFaceID uint64
+	// RIB entries by name hash
+	rib map[uint64]*ribEntry
+	// strategy choice entries by name hash (true for multicast)
+	strategies map[uint64]bool
+	// pending Interests by name hash and CanBePrefix
+	pit map[pitKey]*pitEntry
+	// expiration of recent Interests by name hash and nonce
+	deadNonces map[deadNonce]time.Time
+}
+
+// fwFrame is a frame received on a face.
+type fwFrame struct {
+	faceID uint64
+	frame  []byte
+}
+
+// fwFace is a face of a forwarder.
+type fwFace struct {
+	id uint64
+	// local faces connect applications
+	local bool
+	// local fields (IncomingFaceId) are sent to the application
+	localFields bool
+	// send a frame over the face, which must not block
+	send func(frame []byte)
+}
+
+type ribEntry struct {
+	name   enc.Name
+	routes []route
+}
+
+type route struct {
+	faceID uint64
+	origin uint64
+	cost   uint64
+	flags  uint64
+}
+
+// NextHop is a face over which Interests are forwarded.
+type NextHop struct {
+	FaceID uint64
+	Cost   uint64
+}
+
+type pitKey struct {
+	nameHash    uint64
+	canBePrefix bool
+}
+
+type pitEntry struct {
+	name enc.Name
+	// expiration of the Interests received on each face
+	inRecords map[uint64]time.Time
+}
+
+type deadNonce struct {
+	nameHash uint64
+	nonce    uint32
+}
+
+// NewForwarder creates a forwarder, which is started with Run.
+func NewForwarder(name string) *Forwarder {
+	return &Forwarder{
+		name:       name,
+		queue:      make(chan fwFrame, forwarderQueueSize),
+		tasks:      make(chan func()),
+		stop:       make(chan struct{}),
+		faces:      make(map[uint64]*fwFace),
+		nextFaceID: 1,
+		rib:        make(map[uint64]*ribEntry),
+		strategies: map[uint64]bool{enc.Name{}.Hash(): false},
+		pit:        make(map[pitKey]*pitEntry),
+		deadNonces: make(map[deadNonce]time.Time),
+	}
+}
+
+func (f *Forwarder) String() string {
+	return f.name
+}
+
+// Run processes packets until Stop is called.
+func (f *Forwarder) Run() {
+	cleanup := time.NewTicker(time.Second)
+	defer cleanup.Stop()
+
+	for {
+		select {
+		case frame := <-f.queue:
+			f.onFrame(frame.faceID, frame.frame)
+		case task := <-f.tasks:
+			task()
+		case <-cleanup.C:
+			f.cleanup()
+		case <-f.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the forwarder.
+func (f *Forwarder) Stop() {
+	close(f.stop)
+}
+
+// exec runs a function on the forwarder goroutine, and waits for it to finish.
+// It returns false if the forwarder is stopped.
+func (f *Forwarder) exec(task func()) bool {
+	done := make(chan struct{})
+	select {
+	case f.tasks <- func() { task(); close(done) }:
+		<-done
+		return true
+	case <-f.stop:
+		return false
+	}
+}
+
+// receive passes a frame received on a face to the forwarder.
+func (f *Forwarder) receive(faceID uint64, frame []byte) {
+	select {
+	case f.queue <- fwFrame{faceID: faceID, frame: frame}:
+	case <-f.stop:
+	}
+}
+
+// addFace adds a face to the forwarder, and returns its ID.
+func (f *Forwarder) addFace(local bool, send func(frame []byte)) (id uint64) {
+	f.exec(func() {
+		id = f.nextFaceID
+		f.nextFaceID++
+		f.faces[id] = &fwFace{id: id, local: local, send: send}
+	})
+	return id
+}
+
+// removeFace removes a face from the forwarder, with its routes.
+func (f *Forwarder) removeFace(id uint64) {
+	f.exec(func() {
+		delete(f.faces, id)
+		for _, entry := range f.rib {
+			for _, route := range slices.Clone(entry.routes) {
+				if route.faceID == id {
+					f.removeRoute(entry.name, route.faceID, route.origin)
+				}
+			}
+		}
+	})
+}
+
+// NextHops returns the faces over which Interests for a name are forwarded, by increasing cost.
+func (f *Forwarder) NextHops(name enc.Name) (nexthops []NextHop) {
+	f.exec(func() {
+		nexthops = f.findNextHops(name)
+	})
+	return nexthops
+}
+
+// onFrame processes a frame received on a face.
+func (f *Forwarder) onFrame(faceID uint64, frame []byte) {
+	inFace := f.faces[faceID]
+	if inFace == nil {
+		return
+	}
+
+	pkt, _, err := spec.ReadPacket(enc.NewBufferReader(frame))
+	if err != nil {
+		log.Warnf("emu %s: failed to parse packet: %+v", f, err)
+		return
+	}
+
+	// Only the network layer packet is forwarded
+	if pkt.LpPacket != nil {
+		if pkt.LpPacket.Nack != nil || pkt.LpPacket.FragCount != nil || pkt.LpPacket.Fragment == nil {
+			return
+		}
+		frame = pkt.LpPacket.Fragment.Join()
+		pkt, _, err = spec.ReadPacket(enc.NewBufferReader(frame))
+		if err != nil {
+			log.Warnf("emu %s: failed to parse packet in LpPacket: %+v", f, err)
+			return
+		}
+	}
+
+	switch {
+	case pkt.Interest != nil:
+		f.onInterest(inFace, pkt.Interest, frame)
+	case pkt.Data != nil:
+		f.onData(inFace, pkt.Data, frame)
+	}
+}
+
+// onInterest forwards an Interest, or processes it if it is a management command.
+func (f *Forwarder) onInterest(inFace *fwFace, interest *spec.Interest, frame []byte) {
+	name := interest.NameV
+	if localhost.IsPrefix(name) && !inFace.local {
+		return // /localhost never goes over links
+	}
+	if interest.NonceV == nil {
+		return
+	}
+
+	now := time.Now()
+	nameHash := name.Hash()
+
+	// Drop looping Interests
+	nonce := deadNonce{nameHash: nameHash, nonce: *interest.NonceV}
+	if expiry, ok := f.deadNonces[nonce]; ok && expiry.After(now) {
+		return
+	}
+	f.deadNonces[nonce] = now.Add(deadNonceLifetime)
+
+	if mgmtPrefix.IsPrefix(name) {
+		f.onCommand(inFace, interest)
+		return
+	}
+
+	lifetime := defaultInterestLifetime
+	if interest.InterestLifetimeV != nil {
+		lifetime = *interest.InterestLifetimeV
+	}
+
+	// Aggregate Interests from other faces, but forward retransmissions
+	key := pitKey{nameHash: nameHash, canBePrefix: interest.CanBePrefixV}
+	entry := f.pit[key]
+	if entry == nil || !entry.pending(now) {
+		entry = &pitEntry{name: name.Clone(), inRecords: make(map[uint64]time.Time)}
+		f.pit[key] = entry
+	} else if expiry, ok := entry.inRecords[inFace.id]; !ok || !expiry.After(now) {
+		entry.inRecords[inFace.id] = now.Add(lifetime)
+		return
+	}
+	entry.inRecords[inFace.id] = now.Add(lifetime)
+
+	// /localhop Interests from other nodes are only delivered to applications
+	scoped := localhop.IsPrefix(name) && !inFace.local
+
+	nexthops := f.findNextHops(name)
+	multicast := f.findStrategy(name)
+	for _, nexthop := range nexthops {
+		outFace := f.faces[nexthop.FaceID]
+		if outFace == nil || outFace == inFace || (scoped && !outFace.local) {
+			continue
+		}
+		f.sendInterest(outFace, inFace, frame)
+		if !multicast {
+			break // best route
+		}
+	}
+}
+
+// sendInterest sends an Interest, with the incoming face ID if the face expects it.
+func (f *Forwarder) sendInterest(outFace *fwFace, inFace *fwFace, frame []byte) {
+	if !outFace.localFields {
+		outFace.send(frame)
+		return
+	}
+
+	lpPkt := &spec.Packet{
+		LpPacket: &spec.LpPacket{
+			IncomingFaceId: utils.IdPtr(inFace.id),
+			Fragment:       enc.Wire{frame},
+		},
+	}
+	encoder := spec.PacketEncoder{}
+	encoder.Init(lpPkt)
+	outFace.send(encoder.Encode(lpPkt).Join())
+}
+
+// onData sends a Data to the faces of the Interests it satisfies.
+func (f *Forwarder) onData(inFace *fwFace, data *spec.Data, frame []byte) {
+	now := time.Now()
+	name := data.NameV
+	hashes := name.PrefixHash()
+
+	// The Data satisfies Interests for its name, and Interests that can be prefixes
+	downstreams := make(map[uint64]bool)
+	satisfy := func(key pitKey) {
+		entry := f.pit[key]
+		if entry == nil {
+			return
+		}
+		if len(entry.name) == len(name) || entry.name.IsPrefix(name) {
+			for faceID, expiry := range entry.inRecords {
+				if expiry.After(now) {
+					downstreams[faceID] = true
+				}
+			}
+			delete(f.pit, key)
+		}
+	}
+	satisfy(pitKey{nameHash: hashes[len(name)], canBePrefix: false})
+	for i := len(name); i >= 0; i-- {
+		satisfy(pitKey{nameHash: hashes[i], canBePrefix: true})
+	}
+
+	for faceID := range downstreams {
+		if outFace := f.faces[faceID]; outFace != nil && outFace != inFace {
+			outFace.send(frame)
+		}
+	}
+}
+
+// pending returns whether an Interest of the entry has not expired yet.
+func (e *pitEntry) pending(now time.Time) bool {
+	for _, expiry := range e.inRecords {
+		if expiry.After(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanup removes the expired PIT entries and dead nonces.
+func (f *Forwarder) cleanup() {
+	now := time.Now()
+	for key, entry := range f.pit {
+		if !entry.pending(now) {
+			delete(f.pit, key)
+		}
+	}
+	for nonce, expiry := range f.deadNonces {
+		if !expiry.After(now) {
+			delete(f.deadNonces, nonce)
+		}
+	}
+}
+
+// findNextHops returns the nexthops of the longest prefix of a name with routes,
+// including the routes inherited from shorter prefixes, by increasing cost.
+func (f *Forwarder) findNextHops(name enc.Name) []NextHop {
+	hashes := name.PrefixHash()
+	costs := make(map[uint64]uint64)
+	found := false
+	for i := len(name); i >= 0; i-- {
+		entry := f.rib[hashes[i]]
+		if entry == nil || len(entry.routes) == 0 {
+			continue
+		}
+
+		capture := false
+		for _, route := range entry.routes {
+			if found && route.flags&mgmt.RouteFlagChildInherit == 0 {
+				continue
+			}
+			if cost, ok := costs[route.faceID]; !ok || route.cost < cost {
+				costs[route.faceID] = route.cost
+			}
+			capture = capture || route.flags&mgmt.RouteFlagCapture != 0
+		}
+		found = true
+		if capture {
+			break
+		}
+	}
+
+	nexthops := make([]NextHop, 0, len(costs))
+	for faceID, cost := range costs {
+		nexthops = append(nexthops, NextHop{FaceID: faceID, Cost: cost})
+	}
+	slices.SortFunc(nexthops, func(a, b NextHop) int {
+		return cmp.Or(cmp.Compare(a.Cost, b.Cost), cmp.Compare(a.FaceID, b.FaceID))
+	})
+	return nexthops
+}
+
+// findStrategy returns whether the strategy of a name is multicast.
+func (f *Forwarder) findStrategy(name enc.Name) bool {
+	hashes := name.PrefixHash()
+	for i := len(name); i >= 0; i-- {
+		if multicast, ok := f.strategies[hashes[i]]; ok {
+			return multicast
+		}
+	}
+	return false
+}
@@ -0,0 +1,277 @@
+package emu
+
+import (
+	"testing"
+	"time"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captured is a frame sent over a test face, and the time it was sent.
+type captured struct {
+	frame []byte
+	time  time.Time
+}
+
+// startForwarder runs a forwarder for the duration of a test.
+func startForwarder(t *testing.T, name string) *Forwarder {
+	fw := NewForwarder(name)
+	go fw.Run()
+	t.Cleanup(fw.Stop)
+	return fw
+}
+
+// addTestFace adds a face to a forwarder, which captures the frames sent over it.
+func addTestFace(fw *Forwarder, local bool) (uint64, chan captured) {
+	frames := make(chan captured, 4096)
+	id := fw.addFace(local, func(frame []byte) {
+		frames <- captured{frame: frame, time: time.Now()}
+	})
+	return id, frames
+}
+
+// addTestRoute adds a route to a forwarder.
+func addTestRoute(fw *Forwarder, name string, faceID uint64, cost uint64, flags uint64) {
+	prefix, _ := enc.NameFromStr(name)
+	fw.exec(func() {
+		fw.addRoute(prefix, route{faceID: faceID, cost: cost, flags: flags})
+	})
+}
+
+// makeInterest encodes an Interest, with Application Parameters of a given size.
+func makeInterest(t *testing.T, name string, nonce uint64, canBePrefix bool, paramsSize int) []byte {
+	interestName, err := enc.NameFromStr(name)
+	require.NoError(t, err)
+	var params enc.Wire
+	if paramsSize > 0 {
+		params = enc.Wire{make([]byte, paramsSize)}
+	}
+	interest, err := spec.Spec{}.MakeInterest(interestName, &ndn.InterestConfig{
+		CanBePrefix: canBePrefix,
+		Nonce:       utils.IdPtr(nonce),
+		Lifetime:    utils.IdPtr(time.Second),
+	}, params, nil)
+	require.NoError(t, err)
+	return interest.Wire.Join()
+}
+
+// makeData encodes a Data.
+func makeData(t *testing.T, name string) []byte {
+	dataName, err := enc.NameFromStr(name)
+	require.NoError(t, err)
+	data, err := spec.Spec{}.MakeData(dataName, &ndn.DataConfig{}, enc.Wire{[]byte("content")}, nil)
+	require.NoError(t, err)
+	return data.Wire.Join()
+}
+
+// expectFrame returns the next frame sent over a test face.
+func expectFrame(t *testing.T, frames chan captured) captured {
+	t.Helper()
+	select {
+	case frame := <-frames:
+		return frame
+	case <-time.After(2 * time.Second):
+		require.FailNow(t, "no frame was sent")
+		return captured{}
+	}
+}
+
+// expectNoFrame checks that no frame is sent over a test face for a while.
+func expectNoFrame(t *testing.T, frames chan captured) {
+	t.Helper()
+	select {
+	case frame := <-frames:
+		pkt, _, _ := spec.ReadPacket(enc.NewBufferReader(frame.frame))
+		assert.Fail(t, "unexpected frame", "%+v", pkt)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// packetName returns the name of the Interest or Data in a frame.
+func packetName(t *testing.T, frame []byte) string {
+	pkt, _, err := spec.ReadPacket(enc.NewBufferReader(frame))
+	require.NoError(t, err)
+	if pkt.LpPacket != nil {
+		pkt, _, err = spec.ReadPacket(enc.NewWireReader(pkt.LpPacket.Fragment))
+		require.NoError(t, err)
+	}
+	if pkt.Interest != nil {
+		return pkt.Interest.NameV.String()
+	}
+	return pkt.Data.NameV.String()
+}
+
+func TestForwarderNextHops(t *testing.T) {
+	fw := startForwarder(t, "test")
+	addTestRoute(fw, "/", 1, 10, mgmt.RouteFlagChildInherit)
+	addTestRoute(fw, "/a", 2, 5, mgmt.RouteFlagNoFlag)
+	addTestRoute(fw, "/a/b", 3, 1, mgmt.RouteFlagChildInherit)
+	addTestRoute(fw, "/a/b", 1, 20, mgmt.RouteFlagChildInherit)
+	addTestRoute(fw, "/c", 4, 0, mgmt.RouteFlagCapture)
+
+	nexthops := func(name string) []NextHop {
+		prefix, _ := enc.NameFromStr(name)
+		return fw.NextHops(prefix)
+	}
+
+	// Routes are inherited if they have the ChildInherit flag, with the lowest cost per face
+	assert.Equal(t, []NextHop{{FaceID: 3, Cost: 1}, {FaceID: 1, Cost: 10}}, nexthops("/a/b/x"))
+	assert.Equal(t, []NextHop{{FaceID: 2, Cost: 5}, {FaceID: 1, Cost: 10}}, nexthops("/a/x"))
+	assert.Equal(t, []NextHop{{FaceID: 1, Cost: 10}}, nexthops("/z"))
+
+	// Capture stops the inheritance of shorter prefixes
+	assert.Equal(t, []NextHop{{FaceID: 4, Cost: 0}}, nexthops("/c/x"))
+
+	// Routes are removed with their face
+	fw.removeFace(3)
+	assert.Equal(t, []NextHop{{FaceID: 1, Cost: 10}}, nexthops("/a/b/x"))
+}
+
+func TestForwarderPit(t *testing.T) {
+	fw := startForwarder(t, "test")
+	consumer1, frames1 := addTestFace(fw, true)
+	consumer2, frames2 := addTestFace(fw, true)
+	producer, framesP := addTestFace(fw, true)
+	addTestRoute(fw, "/p", producer, 0, mgmt.RouteFlagChildInherit)
+
+	// Interests from other faces are aggregated, and retransmissions are forwarded
+	fw.receive(consumer1, makeInterest(t, "/p/1", 1, false, 0))
+	assert.Equal(t, "/p/1", packetName(t, expectFrame(t, framesP).frame))
+	fw.receive(consumer2, makeInterest(t, "/p/1", 2, false, 0))
+	expectNoFrame(t, framesP)
+	fw.receive(consumer1, makeInterest(t, "/p/1", 3, false, 0))
+	assert.Equal(t, "/p/1", packetName(t, expectFrame(t, framesP).frame))
+
+	// Data is sent to all the downstreams, once
+	fw.receive(producer, makeData(t, "/p/1"))
+	assert.Equal(t, "/p/1", packetName(t, expectFrame(t, frames1).frame))
+	assert.Equal(t, "/p/1", packetName(t, expectFrame(t, frames2).frame))
+	fw.receive(producer, makeData(t, "/p/1"))
+	expectNoFrame(t, frames1)
+
+	// Interests with a known nonce are loops
+	fw.receive(consumer2, makeInterest(t, "/p/2", 7, false, 0))
+	expectFrame(t, framesP)
+	fw.receive(consumer1, makeInterest(t, "/p/2", 7, false, 0))
+	fw.receive(producer, makeData(t, "/p/2"))
+	expectFrame(t, frames2)
+	expectNoFrame(t, frames1)
+
+	// Data satisfies Interests for a prefix of its name with CanBePrefix
+	fw.receive(consumer1, makeInterest(t, "/p", 8, true, 0))
+	expectFrame(t, framesP)
+	fw.receive(producer, makeData(t, "/p/3/v"))
+	assert.Equal(t, "/p/3/v", packetName(t, expectFrame(t, frames1).frame))
+
+	// Multicast forwards Interests to all nexthops but the incoming face
+	fw.exec(func() { fw.strategies[enc.Name{}.Hash()] = true })
+	addTestRoute(fw, "/p", consumer2, 1, mgmt.RouteFlagChildInherit)
+	fw.receive(consumer1, makeInterest(t, "/p/4", 9, false, 0))
+	expectFrame(t, framesP)
+	expectFrame(t, frames2)
+	fw.receive(consumer2, makeInterest(t, "/p/5", 10, false, 0))
+	expectFrame(t, framesP)
+	expectNoFrame(t, frames2)
+}
+
+func TestForwarderScopes(t *testing.T) {
+	fw := startForwarder(t, "test")
+	link1, frames1 := addTestFace(fw, false)
+	link2, frames2 := addTestFace(fw, false)
+	app, framesApp := addTestFace(fw, true)
+	addTestRoute(fw, "/", link2, 0, mgmt.RouteFlagChildInherit)
+	addTestRoute(fw, "/localhop", app, 0, mgmt.RouteFlagChildInherit)
+
+	// /localhop Interests from other nodes are only delivered to applications
+	fw.receive(link1, makeInterest(t, "/localhop/x", 1, false, 0))
+	assert.Equal(t, "/localhop/x", packetName(t, expectFrame(t, framesApp).frame))
+	expectNoFrame(t, frames2)
+
+	// but they are sent to other nodes by applications
+	fw.receive(app, makeInterest(t, "/localhop/y", 2, false, 0))
+	assert.Equal(t, "/localhop/y", packetName(t, expectFrame(t, frames2).frame))
+
+	// /localhost Interests are never received from other nodes
+	addTestRoute(fw, "/localhost", app, 0, mgmt.RouteFlagChildInherit)
+	fw.receive(link1, makeInterest(t, "/localhost/z", 3, false, 0))
+	expectNoFrame(t, framesApp)
+	expectNoFrame(t, frames1)
+}
+
+func TestForwarderCommands(t *testing.T) {
+	fw := startForwarder(t, "test")
+	consumer, _ := addTestFace(fw, true)
+
+	router := NewEngine(fw)
+	require.NoError(t, router.Start())
+	defer router.Stop()
+	app := NewEngine(fw)
+	require.NoError(t, app.Start())
+	defer app.Stop()
+
+	// Routes are registered for the face of the command, and strategies are checked
+	name, _ := enc.NameFromStr("/x")
+	require.NoError(t, router.ExecMgmtCmd("rib", "register", &mgmt.ControlArgs{
+		Name: name,
+		Cost: utils.IdPtr(uint64(3)),
+	}))
+	nexthops := fw.NextHops(name)
+	require.Len(t, nexthops, 1)
+	assert.Equal(t, uint64(3), nexthops[0].Cost)
+	routerFace := nexthops[0].FaceID
+
+	assert.NoError(t, router.ExecMgmtCmd("strategy-choice", "set", &mgmt.ControlArgs{
+		Name:     name,
+		Strategy: &mgmt.Strategy{Name: multicastStrategy},
+	}))
+	unknown, _ := enc.NameFromStr("/localhost/nfd/strategy/unknown")
+	assert.Error(t, router.ExecMgmtCmd("strategy-choice", "set", &mgmt.ControlArgs{
+		Name:     name,
+		Strategy: &mgmt.Strategy{Name: unknown},
+	}))
+	assert.Error(t, router.ExecMgmtCmd("faces", "create", &mgmt.ControlArgs{}))
+
+	// Interests carry their incoming face once local fields are enabled
+	incoming := make(chan *uint64, 1)
+	require.NoError(t, router.AttachHandler(name, func(args ndn.InterestHandlerArgs) {
+		incoming <- args.IncomingFaceId
+	}))
+	require.NoError(t, router.ExecMgmtCmd("faces", "update", &mgmt.ControlArgs{
+		Mask:  utils.IdPtr(uint64(0x01)),
+		Flags: utils.IdPtr(uint64(0x01)),
+	}))
+	fw.receive(consumer, makeInterest(t, "/x/1", 1, false, 0))
+	select {
+	case faceID := <-incoming:
+		require.NotNil(t, faceID)
+		assert.Equal(t, consumer, *faceID)
+	case <-time.After(2 * time.Second):
+		require.FailNow(t, "Interest was not received")
+	}
+
+	// Client routes are readvertised to the router, and withdrawn with their face
+	readvertised := make(chan string, 2)
+	nlsr, _ := enc.NameFromStr("/localhost/nlsr")
+	require.NoError(t, router.AttachHandler(nlsr, func(args ndn.InterestHandlerArgs) {
+		params, err := mgmt.ParseControlParameters(enc.NewBufferReader(args.Interest.Name()[4].Val), false)
+		require.NoError(t, err)
+		readvertised <- args.Interest.Name()[3].String() + " " + params.Val.Name.String()
+	}))
+	require.NoError(t, router.ExecMgmtCmd("rib", "register", &mgmt.ControlArgs{Name: nlsr}))
+	prefix, _ := enc.NameFromStr("/app")
+	require.NoError(t, app.ExecMgmtCmd("rib", "register", &mgmt.ControlArgs{
+		Name:   prefix,
+		Origin: utils.IdPtr(clientOrigin),
+	}))
+	assert.Equal(t, "register /app", <-readvertised)
+	app.Stop()
+	assert.Equal(t, "unregister /app", <-readvertised)
+	assert.Empty(t, fw.NextHops(prefix))
+	assert.Equal(t, routerFace, fw.NextHops(name)[0].FaceID)
+}
@@ -0,0 +1,151 @@
+package emu
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+)
+
+// Number of frames queued for transmission in each direction of a link
+const linkQueueSize = 1024
+
+// Link is a virtual link between the forwarders of two nodes, with a face on each.
+// Frames are delivered in order after the propagation delay, once the frames before
+// them are transmitted at the bandwidth of the link. Frames are lost at random, or
+// when the transmission queue is full.
+type Link struct {
+	config LinkConfig
+	ends   [2]*linkFace
+	up     atomic.Bool
+	stop   chan struct{}
+}
+
+// linkFace is the face of a link on one node, which sends frames to the other end.
+type linkFace struct {
+	link *Link
+	fw   *Forwarder
+	id   uint64
+	peer *linkFace
+	// random losses of the frames sent on this face
+	rand *rand.Rand
+	// frames in transmission to the peer
+	queue chan linkFrame
+	// time at which the frames already queued are transmitted
+	busy time.Time
+	// number of frames sent and lost
+	nSent atomic.Uint64
+	nLost atomic.Uint64
+}
+
+// linkFrame is a frame in transmission, and the time it arrives at the peer.
+type linkFrame struct {
+	frame   []byte
+	arrival time.Time
+}
+
+// NewLink connects two forwarders, which must be running. The losses in each direction
+// are drawn from a random source seeded by seed, so that the same frames are lost when
+// the same frames are sent.
+func NewLink(config LinkConfig, a *Forwarder, b *Forwarder, seed uint64) *Link {
+	l := &Link{config: config, stop: make(chan struct{})}
+	for i, fw := range []*Forwarder{a, b} {
+		end := &linkFace{
+			link:  l,
+			fw:    fw,
+			rand:  rand.New(rand.NewPCG(seed, uint64(i))),
+			queue: make(chan linkFrame, linkQueueSize),
+		}
+		end.id = fw.addFace(false, end.send)
+		l.ends[i] = end
+	}
+	l.ends[0].peer, l.ends[1].peer = l.ends[1], l.ends[0]
+	l.up.Store(true)
+
+	for _, end := range l.ends {
+		go end.run()
+	}
+	return l
+}
+
+// FaceID returns the ID of the face of the link on a forwarder, or zero.
+func (l *Link) FaceID(fw *Forwarder) uint64 {
+	for _, end := range l.ends {
+		if end.fw == fw {
+			return end.id
+		}
+	}
+	return 0
+}
+
+// SetUp brings the link up or down. Frames in transmission when the link goes down are lost.
+func (l *Link) SetUp(up bool) {
+	l.up.Store(up)
+}
+
+// Counters returns the number of frames sent and lost from the forwarder at one end.
+func (l *Link) Counters(fw *Forwarder) (nSent uint64, nLost uint64) {
+	for _, end := range l.ends {
+		if end.fw == fw {
+			return end.nSent.Load(), end.nLost.Load()
+		}
+	}
+	return 0, 0
+}
+
+// Close stops the link, dropping the frames in transmission.
+func (l *Link) Close() {
+	l.up.Store(false)
+	close(l.stop)
+}
+
+// send schedules the transmission of a frame, and is only called by the forwarder goroutine.
+func (f *linkFace) send(frame []byte) {
+	f.nSent.Add(1)
+	if !f.link.up.Load() || f.rand.Float64() < f.link.config.Loss {
+		f.nLost.Add(1)
+		return
+	}
+
+	now := time.Now()
+	if f.busy.Before(now) {
+		f.busy = now
+	}
+	if f.link.config.Bandwidth > 0 {
+		f.busy = f.busy.Add(time.Duration(uint64(len(frame)) * 8 * uint64(time.Second) / f.link.config.Bandwidth))
+	}
+	arrival := f.busy.Add(time.Duration(f.link.config.Delay_ms) * time.Millisecond)
+
+	select {
+	case f.queue <- linkFrame{frame: frame, arrival: arrival}:
+	default:
+		f.nLost.Add(1) // queue is full
+	}
+}
+
+// run delivers the frames to the peer when they arrive.
+func (f *linkFace) run() {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		var frame linkFrame
+		select {
+		case frame = <-f.queue:
+		case <-f.link.stop:
+			return
+		}
+
+		timer.Reset(time.Until(frame.arrival))
+		select {
+		case <-timer.C:
+		case <-f.link.stop:
+			return
+		}
+
+		if f.link.up.Load() {
+			f.peer.fw.receive(f.peer.id, frame.frame)
+		} else {
+			f.nLost.Add(1)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package emu
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startLink connects two forwarders by a link for the duration of a test. Interests for
+// /test sent by the application face of the first one are delivered to the second one.
+func startLink(t *testing.T, config LinkConfig, seed uint64) (*Link, func(frame []byte), chan captured) {
+	a, b := startForwarder(t, "a"), startForwarder(t, "b")
+	link := NewLink(config, a, b, seed)
+	t.Cleanup(link.Close)
+
+	app, _ := addTestFace(a, true)
+	addTestRoute(a, "/test", link.FaceID(a), 0, mgmt.RouteFlagChildInherit)
+	producer, frames := addTestFace(b, true)
+	addTestRoute(b, "/test", producer, 0, mgmt.RouteFlagChildInherit)
+
+	return link, func(frame []byte) { a.receive(app, frame) }, frames
+}
+
+func TestLinkDelay(t *testing.T) {
+	_, send, frames := startLink(t, LinkConfig{Delay_ms: 50}, 0)
+
+	frame := makeInterest(t, "/test/1", 1, false, 0)
+	start := time.Now()
+	send(frame)
+	received := expectFrame(t, frames)
+	assert.Equal(t, frame, received.frame)
+	assert.GreaterOrEqual(t, received.time.Sub(start), 50*time.Millisecond)
+}
+
+func TestLinkBandwidth(t *testing.T) {
+	const nFrames = 20
+	const bandwidth = 1_000_000
+
+	_, send, frames := startLink(t, LinkConfig{Delay_ms: 10, Bandwidth: bandwidth}, 0)
+
+	// Frames are transmitted one after the other, and arrive in order
+	sent := make([][]byte, nFrames)
+	size := 0
+	for i := range sent {
+		sent[i] = makeInterest(t, "/test/"+strconv.Itoa(i), uint64(i), false, 1000)
+		size += len(sent[i])
+	}
+	start := time.Now()
+	for _, frame := range sent {
+		send(frame)
+	}
+	var last captured
+	for _, frame := range sent {
+		last = expectFrame(t, frames)
+		assert.Equal(t, frame, last.frame)
+	}
+
+	transmission := time.Duration(size*8) * time.Second / bandwidth
+	assert.GreaterOrEqual(t, last.time.Sub(start), transmission+10*time.Millisecond)
+}
+
+func TestLinkLoss(t *testing.T) {
+	const nFrames = 500
+
+	// The same frames are lost with the same seed
+	lost := func(seed uint64) []int {
+		link, send, frames := startLink(t, LinkConfig{Loss: 0.3}, seed)
+		for i := 0; i < nFrames; i++ {
+			send(makeInterest(t, "/test/"+strconv.Itoa(i), uint64(i), false, 0))
+		}
+		require.Eventually(t, func() bool {
+			nSent, _ := link.Counters(link.ends[0].fw)
+			return nSent == nFrames
+		}, 2*time.Second, 10*time.Millisecond)
+
+		_, nLost := link.Counters(link.ends[0].fw)
+		received := make(map[string]bool)
+		for i := uint64(0); i < nFrames-nLost; i++ {
+			received[packetName(t, expectFrame(t, frames).frame)] = true
+		}
+		expectNoFrame(t, frames)
+
+		lost := []int{}
+		for i := 0; i < nFrames; i++ {
+			if !received["/test/"+strconv.Itoa(i)] {
+				lost = append(lost, i)
+			}
+		}
+		return lost
+	}
+
+	lost1 := lost(1)
+	assert.InDelta(t, 0.3*nFrames, len(lost1), 0.1*nFrames)
+	assert.Equal(t, lost1, lost(1))
+	assert.NotEqual(t, lost1, lost(2))
+}
+
+func TestLinkDown(t *testing.T) {
+	link, send, frames := startLink(t, LinkConfig{}, 0)
+
+	link.SetUp(false)
+	send(makeInterest(t, "/test/1", 1, false, 0))
+	expectNoFrame(t, frames)
+	_, nLost := link.Counters(link.ends[0].fw)
+	assert.Equal(t, uint64(1), nLost)
+
+	link.SetUp(true)
+	send(makeInterest(t, "/test/2", 2, false, 0))
+	assert.Equal(t, "/test/2", packetName(t, expectFrame(t, frames).frame))
+}
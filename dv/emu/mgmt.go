@@ -0,0 +1,224 @@
+package emu
+
+import (
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/engine/basic"
+	"github.com/named-data/ndnd/std/log"
+	"github.com/named-data/ndnd/std/ndn"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/security"
+	"github.com/named-data/ndnd/std/utils"
+)
+
+// Origin of the routes readvertised to the router, as in YaNFD
+const clientOrigin = uint64(65)
+
+var multicastStrategy, _ = enc.NameFromStr("/localhost/nfd/strategy/multicast")
+var bestRouteStrategy, _ = enc.NameFromStr("/localhost/nfd/strategy/best-route")
+
+// onCommand executes a management command, and replies with a ControlResponse.
+func (f *Forwarder) onCommand(inFace *fwFace, interest *spec.Interest) {
+	name := interest.NameV
+	reply := func(statusCode uint64, statusText string, params *mgmt.ControlArgs) {
+		res := &mgmt.ControlResponse{
+			Val: &mgmt.ControlResponseVal{
+				StatusCode: statusCode,
+				StatusText: statusText,
+				Params:     params,
+			},
+		}
+		data, err := spec.Spec{}.MakeData(name,
+			&ndn.DataConfig{ContentType: utils.IdPtr(ndn.ContentTypeBlob)},
+			res.Encode(),
+			security.NewSha256Signer())
+		if err != nil {
+			log.Warnf("emu %s: failed to make ControlResponse: %+v", f, err)
+			return
+		}
+		inFace.send(data.Wire.Join())
+	}
+
+	if len(name) < len(mgmtPrefix)+3 {
+		reply(400, "ControlParameters is incorrect", nil)
+		return
+	}
+	module, verb := name[len(mgmtPrefix)].String(), name[len(mgmtPrefix)+1].String()
+	params, err := mgmt.ParseControlParameters(enc.NewBufferReader(name[len(mgmtPrefix)+2].Val), true)
+	if err != nil || params.Val == nil {
+		reply(400, "ControlParameters is incorrect", nil)
+		return
+	}
+	args := params.Val
+
+	faceID := inFace.id
+	if args.FaceId != nil && *args.FaceId != 0 {
+		faceID = *args.FaceId
+	}
+
+	switch module + "/" + verb {
+	case "rib/register":
+		if args.Name == nil {
+			reply(400, "ControlParameters is incorrect", nil)
+			return
+		}
+		if f.faces[faceID] == nil {
+			reply(410, "Face does not exist", nil)
+			return
+		}
+		newRoute := route{faceID: faceID, flags: mgmt.RouteFlagChildInherit}
+		if args.Origin != nil {
+			newRoute.origin = *args.Origin
+		}
+		if args.Cost != nil {
+			newRoute.cost = *args.Cost
+		}
+		if args.Flags != nil {
+			newRoute.flags = *args.Flags
+		}
+		f.addRoute(args.Name, newRoute)
+		reply(200, "OK", &mgmt.ControlArgs{
+			Name:   args.Name,
+			FaceId: utils.IdPtr(faceID),
+			Origin: utils.IdPtr(newRoute.origin),
+			Cost:   utils.IdPtr(newRoute.cost),
+			Flags:  utils.IdPtr(newRoute.flags),
+		})
+
+	case "rib/unregister":
+		if args.Name == nil {
+			reply(400, "ControlParameters is incorrect", nil)
+			return
+		}
+		origin := uint64(0)
+		if args.Origin != nil {
+			origin = *args.Origin
+		}
+		f.removeRoute(args.Name, faceID, origin)
+		reply(200, "OK", &mgmt.ControlArgs{
+			Name:   args.Name,
+			FaceId: utils.IdPtr(faceID),
+			Origin: utils.IdPtr(origin),
+		})
+
+	case "faces/update":
+		face := f.faces[faceID]
+		if face == nil {
+			reply(410, "Face does not exist", nil)
+			return
+		}
+		if args.Mask != nil && args.Flags != nil && *args.Mask&0x01 != 0 {
+			face.localFields = *args.Flags&0x01 != 0
+		}
+		reply(200, "OK", &mgmt.ControlArgs{FaceId: utils.IdPtr(faceID)})
+
+	case "strategy-choice/set":
+		if args.Name == nil || args.Strategy == nil {
+			reply(400, "ControlParameters is incorrect", nil)
+			return
+		}
+		switch {
+		case multicastStrategy.IsPrefix(args.Strategy.Name):
+			f.strategies[args.Name.Hash()] = true
+		case bestRouteStrategy.IsPrefix(args.Strategy.Name):
+			f.strategies[args.Name.Hash()] = false
+		default:
+			reply(404, "Strategy not found", nil)
+			return
+		}
+		reply(200, "OK", args)
+
+	case "strategy-choice/unset":
+		if args.Name == nil || len(args.Name) == 0 {
+			reply(400, "ControlParameters is incorrect", nil)
+			return
+		}
+		delete(f.strategies, args.Name.Hash())
+		reply(200, "OK", args)
+
+	default:
+		reply(501, "Unknown command", nil)
+	}
+}
+
+// addRoute adds or updates a route, and readvertises the first client route of a prefix.
+func (f *Forwarder) addRoute(name enc.Name, newRoute route) {
+	nameHash := name.Hash()
+	entry := f.rib[nameHash]
+	if entry == nil {
+		entry = &ribEntry{name: name.Clone()}
+		f.rib[nameHash] = entry
+	}
+
+	advertised := entry.hasOrigin(clientOrigin)
+	entry.routes = slices.DeleteFunc(entry.routes, func(r route) bool {
+		return r.faceID == newRoute.faceID && r.origin == newRoute.origin
+	})
+	entry.routes = append(entry.routes, newRoute)
+
+	if !advertised && newRoute.origin == clientOrigin {
+		f.readvertise("register", name)
+	}
+}
+
+// removeRoute removes a route, and withdraws a prefix left without client routes.
+func (f *Forwarder) removeRoute(name enc.Name, faceID uint64, origin uint64) {
+	nameHash := name.Hash()
+	entry := f.rib[nameHash]
+	if entry == nil {
+		return
+	}
+
+	advertised := entry.hasOrigin(clientOrigin)
+	entry.routes = slices.DeleteFunc(entry.routes, func(r route) bool {
+		return r.faceID == faceID && r.origin == origin
+	})
+	if len(entry.routes) == 0 {
+		delete(f.rib, nameHash)
+	}
+
+	if advertised && !entry.hasOrigin(clientOrigin) {
+		f.readvertise("unregister", name)
+	}
+}
+
+func (e *ribEntry) hasOrigin(origin uint64) bool {
+	return slices.ContainsFunc(e.routes, func(r route) bool { return r.origin == origin })
+}
+
+// readvertise sends a command to the router to announce or withdraw a prefix.
+// The response of the router is dropped, as it matches no Interest of a face.
+func (f *Forwarder) readvertise(verb string, name enc.Name) {
+	params := &mgmt.ControlParameters{Val: &mgmt.ControlArgs{Name: name}}
+	cmd := append(localhost,
+		enc.NewStringComponent(enc.TypeGenericNameComponent, "nlsr"),
+		enc.NewStringComponent(enc.TypeGenericNameComponent, "rib"),
+		enc.NewStringComponent(enc.TypeGenericNameComponent, verb),
+		enc.NewBytesComponent(enc.TypeGenericNameComponent, params.Encode().Join()))
+
+	args := &mgmt.ControlArgs{Name: name, Origin: utils.IdPtr(clientOrigin)}
+	interest, err := spec.Spec{}.MakeInterest(cmd,
+		&ndn.InterestConfig{
+			MustBeFresh: true,
+			Lifetime:    utils.IdPtr(time.Second),
+			Nonce:       utils.IdPtr(rand.Uint64()),
+		},
+		args.Encode(),
+		security.NewSha256IntSigner(basic.NewTimer()))
+	if err != nil {
+		log.Warnf("emu %s: failed to make readvertise Interest: %+v", f, err)
+		return
+	}
+
+	// The Interest comes from no face, and is only forwarded to applications
+	for _, nexthop := range f.findNextHops(cmd) {
+		if face := f.faces[nexthop.FaceID]; face != nil && face.local {
+			face.send(interest.Wire.Join())
+			break
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// Package emu emulates a network of NDN forwarders and DV routers in a single program,
+// connected by virtual links with a delay, random losses and a bandwidth. It is meant
+// for tests of routing convergence and forwarding behaviour without sockets.
+package emu
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/named-data/ndnd/dv/config"
+	"github.com/named-data/ndnd/dv/dv"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/log"
+	"github.com/named-data/ndnd/std/ndn"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+)
+
+// Origin of the routes created by the emulation, as static routes of NFD
+const staticOrigin = uint64(255)
+
+// Network is an emulated network, created from a topology.
+type Network struct {
+	topo  *Topology
+	nodes []*Node
+	links []*Link
+}
+
+// Node is a node of an emulated network, running a forwarder and a DV router.
+type Node struct {
+	Name      string
+	Forwarder *Forwarder
+
+	config *config.Config
+	engine ndn.Engine
+	router *dv.Router
+	// closed when the router stops
+	done chan struct{}
+}
+
+// NewNetwork creates the nodes of a topology, which are started with Start.
+func NewNetwork(topo *Topology) (*Network, error) {
+	if err := topo.Validate(); err != nil {
+		return nil, err
+	}
+
+	n := &Network{topo: topo}
+	for _, nodeConfig := range topo.Nodes {
+		node := &Node{
+			Name:      nodeConfig.Name,
+			Forwarder: NewForwarder(nodeConfig.Name),
+			config:    config.DefaultConfig(),
+			done:      make(chan struct{}),
+		}
+
+		node.config.Network = topo.Network
+		node.config.Router = nodeConfig.Router
+		if node.config.Router == "" {
+			node.config.Router = topo.Network + "/" + nodeConfig.Name
+		}
+		node.config.AdvertisementSyncInterval_ms = topo.AdvertisementSyncInterval_ms
+		node.config.RouterDeadInterval_ms = topo.RouterDeadInterval_ms
+
+		var err error
+		node.engine = node.NewEngine()
+		node.router, err = dv.NewRouter(node.config, node.engine)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create router of %s: %w", node.Name, err)
+		}
+		n.nodes = append(n.nodes, node)
+	}
+
+	return n, nil
+}
+
+// Start starts the forwarders, links and routers. It returns once all the routers
+// are registered with their forwarder.
+func (n *Network) Start() error {
+	for _, node := range n.nodes {
+		go node.Forwarder.Run()
+	}
+
+	// Routers send active Sync Interests to the neighbors over all links
+	for i, linkConfig := range n.topo.Links {
+		a, b := n.Node(linkConfig.A), n.Node(linkConfig.B)
+		link := NewLink(linkConfig, a.Forwarder, b.Forwarder, uint64(n.topo.Seed)+uint64(i)<<32)
+		for _, node := range []*Node{a, b} {
+			faceID := link.FaceID(node.Forwarder)
+			node.Forwarder.exec(func() {
+				node.Forwarder.addRoute(node.config.AdvertisementSyncActivePrefix(), route{
+					faceID: faceID,
+					origin: staticOrigin,
+					flags:  mgmt.RouteFlagChildInherit,
+				})
+			})
+		}
+		n.links = append(n.links, link)
+	}
+
+	for _, node := range n.nodes {
+		if err := node.engine.Start(); err != nil {
+			return fmt.Errorf("failed to start engine of %s: %w", node.Name, err)
+		}
+		go func() {
+			defer close(node.done)
+			if err := node.router.Start(); err != nil {
+				log.Errorf("emu %s: failed to start router: %+v", node.Name, err)
+			}
+		}()
+	}
+
+	// The readvertise prefix is the last route registered by a router
+	deadline := time.Now().Add(5 * time.Second)
+	for _, node := range n.nodes {
+		for len(node.Forwarder.NextHops(node.config.ReadvertisePrefix())) == 0 {
+			if time.Now().After(deadline) {
+				return fmt.Errorf("router of %s did not register", node.Name)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	return nil
+}
+
+// Stop stops the routers, links and forwarders.
+func (n *Network) Stop() {
+	for _, node := range n.nodes {
+		node.router.Stop()
+	}
+	for _, node := range n.nodes {
+		<-node.done
+		node.engine.Stop()
+	}
+	for _, link := range n.links {
+		link.Close()
+	}
+	for _, node := range n.nodes {
+		node.Forwarder.Stop()
+	}
+}
+
+// Node returns a node by name, or nil.
+func (n *Network) Node(name string) *Node {
+	for _, node := range n.nodes {
+		if node.Name == name {
+			return node
+		}
+	}
+	return nil
+}
+
+// Link returns the link between two nodes, or nil.
+func (n *Network) Link(a string, b string) *Link {
+	for _, link := range n.links {
+		if (link.config.A == a && link.config.B == b) || (link.config.A == b && link.config.B == a) {
+			return link
+		}
+	}
+	return nil
+}
+
+// Converged returns whether the route of every node to every other router has the cost
+// of the shortest path over the links that are up, through a neighbor on such a path.
+func (n *Network) Converged() bool {
+	for _, src := range n.nodes {
+		for _, dst := range n.nodes {
+			if src == dst {
+				continue
+			}
+			if !n.routeConverged(src, dst) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// WaitConverged waits until the network is converged.
+func (n *Network) WaitConverged(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for !n.Converged() {
+		if time.Now().After(deadline) {
+			return errors.New("network did not converge")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return nil
+}
+
+// routeConverged returns whether the route of src to the router of dst is a shortest path.
+func (n *Network) routeConverged(src *Node, dst *Node) bool {
+	distances := n.distances(dst)
+	nexthops := src.Forwarder.NextHops(dst.RouterPrefix())
+
+	distance, reachable := distances[src]
+	if !reachable {
+		return len(nexthops) == 0
+	}
+	if len(nexthops) == 0 || nexthops[0].Cost != uint64(distance) {
+		return false
+	}
+
+	// The best nexthop is a neighbor closer to the destination
+	for _, link := range n.links {
+		if link.FaceID(src.Forwarder) == nexthops[0].FaceID {
+			peer := n.peer(link, src)
+			peerDistance, ok := distances[peer]
+			return link.up.Load() && ok && peerDistance == distance-1
+		}
+	}
+	return false
+}
+
+// distances returns the number of hops from each node to a destination, over the links that are up.
+func (n *Network) distances(dst *Node) map[*Node]int {
+	distances := map[*Node]int{dst: 0}
+	for queue := []*Node{dst}; len(queue) > 0; queue = queue[1:] {
+		node := queue[0]
+		for _, link := range n.links {
+			if !link.up.Load() || link.FaceID(node.Forwarder) == 0 {
+				continue
+			}
+			peer := n.peer(link, node)
+			if _, ok := distances[peer]; !ok {
+				distances[peer] = distances[node] + 1
+				queue = append(queue, peer)
+			}
+		}
+	}
+	return distances
+}
+
+// peer returns the node at the other end of a link.
+func (n *Network) peer(link *Link, node *Node) *Node {
+	if link.config.A == node.Name {
+		return n.Node(link.config.B)
+	}
+	return n.Node(link.config.A)
+}
+
+// NewEngine creates an engine for an application running on the node.
+func (n *Node) NewEngine() ndn.Engine {
+	return NewEngine(n.Forwarder)
+}
+
+// RouterName returns the name of the DV router of the node.
+func (n *Node) RouterName() enc.Name {
+	return n.config.RouterName()
+}
+
+// RouterPrefix returns the prefix routed to the DV router of the node.
+func (n *Node) RouterPrefix() enc.Name {
+	return append(n.config.RouterName().Clone(),
+		enc.NewStringComponent(enc.TypeKeywordNameComponent, "DV"))
+}
+
+// Router returns the DV router of the node.
+func (n *Node) Router() *dv.Router {
+	return n.router
+}
@@ -0,0 +1,125 @@
+package emu
+
+import (
+	"testing"
+	"time"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Time for routers to converge, including the retransmissions after losses
+const convergenceTimeout = 20 * time.Second
+
+// startNetwork starts the network of a topology file for the duration of a test.
+func startNetwork(t *testing.T, path string) *Network {
+	topo, err := LoadTopology(path)
+	require.NoError(t, err)
+	network, err := NewNetwork(topo)
+	require.NoError(t, err)
+	require.NoError(t, network.Start())
+	t.Cleanup(network.Stop)
+	return network
+}
+
+// routeCost returns the cost of the best route of a node to the router of another, or -1.
+func routeCost(network *Network, from string, to string) int {
+	nexthops := network.Node(from).Forwarder.NextHops(network.Node(to).RouterPrefix())
+	if len(nexthops) == 0 {
+		return -1
+	}
+	return int(nexthops[0].Cost)
+}
+
+func TestNetworkConvergence(t *testing.T) {
+	network := startNetwork(t, "testdata/line.yml")
+	require.NoError(t, network.WaitConverged(convergenceTimeout))
+
+	// Routes follow the line in both directions
+	a, d := network.Node("a"), network.Node("d")
+	assert.Equal(t, 3, routeCost(network, "a", "d"))
+	assert.Equal(t, 3, routeCost(network, "d", "a"))
+	assert.Equal(t, 1, routeCost(network, "b", "c"))
+	assert.Equal(t, network.Link("a", "b").FaceID(a.Forwarder),
+		a.Forwarder.NextHops(d.RouterPrefix())[0].FaceID)
+}
+
+func TestNetworkLinkFailure(t *testing.T) {
+	network := startNetwork(t, "testdata/ring.yml")
+	require.NoError(t, network.WaitConverged(convergenceTimeout))
+	assert.Equal(t, 1, routeCost(network, "a", "c"))
+	assert.Equal(t, 2, routeCost(network, "b", "e"))
+
+	// Routes avoid a link that goes down
+	network.Link("a", "c").SetUp(false)
+	require.NoError(t, network.WaitConverged(convergenceTimeout))
+	assert.Equal(t, 2, routeCost(network, "a", "c"))
+
+	network.Link("a", "b").SetUp(false)
+	require.NoError(t, network.WaitConverged(convergenceTimeout))
+	assert.Equal(t, 4, routeCost(network, "a", "b"))
+	assert.Equal(t, 3, routeCost(network, "b", "e"))
+
+	// and use it again once it is back up
+	network.Link("a", "c").SetUp(true)
+	require.NoError(t, network.WaitConverged(convergenceTimeout))
+	assert.Equal(t, 1, routeCost(network, "a", "c"))
+	assert.Equal(t, 2, routeCost(network, "a", "b"))
+}
+
+func TestNetworkForwarding(t *testing.T) {
+	network := startNetwork(t, "testdata/line.yml")
+	require.NoError(t, network.WaitConverged(convergenceTimeout))
+
+	// The producer prefix is readvertised by the router of its node
+	prefix, _ := enc.NameFromStr("/app/d")
+	producer := network.Node("d").NewEngine()
+	require.NoError(t, producer.Start())
+	defer producer.Stop()
+	require.NoError(t, producer.AttachHandler(prefix, func(args ndn.InterestHandlerArgs) {
+		data, err := producer.Spec().MakeData(args.Interest.Name(), &ndn.DataConfig{},
+			enc.Wire{[]byte("hello")}, nil)
+		if err == nil {
+			args.Reply(data.Wire)
+		}
+	}))
+	require.NoError(t, producer.ExecMgmtCmd("rib", "register", &mgmt.ControlArgs{
+		Name:   prefix,
+		Origin: utils.IdPtr(clientOrigin),
+	}))
+
+	a := network.Node("a")
+	require.Eventually(t, func() bool {
+		nexthops := a.Forwarder.NextHops(prefix)
+		return len(nexthops) > 0 && nexthops[0].Cost == 3
+	}, convergenceTimeout, 50*time.Millisecond)
+
+	// Interests of a consumer reach the producer over the routes of the routers
+	consumer := a.NewEngine()
+	require.NoError(t, consumer.Start())
+	defer consumer.Stop()
+
+	name, _ := enc.NameFromStr("/app/d/greeting")
+	interest, err := consumer.Spec().MakeInterest(name, &ndn.InterestConfig{
+		Lifetime: utils.IdPtr(time.Second),
+		Nonce:    utils.ConvertNonce(consumer.Timer().Nonce()),
+	}, nil, nil)
+	require.NoError(t, err)
+	result := make(chan ndn.ExpressCallbackArgs, 1)
+	require.NoError(t, consumer.Express(interest, func(args ndn.ExpressCallbackArgs) {
+		result <- args
+	}))
+	args := <-result
+	require.Equal(t, ndn.InterestResultData, args.Result)
+	assert.Equal(t, []byte("hello"), args.Data.Content().Join())
+
+	// The prefix is withdrawn when the producer leaves
+	producer.Stop()
+	require.Eventually(t, func() bool {
+		return len(a.Forwarder.NextHops(prefix)) == 0
+	}, convergenceTimeout, 50*time.Millisecond)
+}
@@ -0,0 +1,100 @@
+package emu
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Topology describes an emulated network, usually read from a topology file.
+type Topology struct {
+	// Network prefix of all DV routers.
+	Network string `json:"network"`
+	// Period of sending Advertisement Sync Interests (ms).
+	AdvertisementSyncInterval_ms uint64 `json:"advertise_interval"`
+	// Time after which a neighbor is considered dead (ms).
+	RouterDeadInterval_ms uint64 `json:"router_dead_interval"`
+	// Seed of the random packet losses on links.
+	Seed int64 `json:"seed"`
+	// Nodes, each running a forwarder and a DV router.
+	Nodes []NodeConfig `json:"nodes"`
+	// Links between two nodes.
+	Links []LinkConfig `json:"links"`
+}
+
+type NodeConfig struct {
+	// Unique name of the node in the topology.
+	Name string `json:"name"`
+	// Name of the DV router. Defaults to <network>/<name>.
+	Router string `json:"router"`
+}
+
+type LinkConfig struct {
+	// Names of the nodes at both ends of the link.
+	A string `json:"a"`
+	B string `json:"b"`
+	// One-way propagation delay (ms).
+	Delay_ms uint64 `json:"delay"`
+	// Probability that a packet is lost, from 0 to 1.
+	Loss float64 `json:"loss"`
+	// Bandwidth in each direction (bits per second). Zero for no limit.
+	Bandwidth uint64 `json:"bandwidth"`
+}
+
+// LoadTopology reads a topology file in YAML.
+func LoadTopology(path string) (*Topology, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTopology(bytes)
+}
+
+// ParseTopology parses a topology in YAML, with the default intervals of DV routers.
+func ParseTopology(bytes []byte) (*Topology, error) {
+	topo := &Topology{
+		AdvertisementSyncInterval_ms: 1000,
+		RouterDeadInterval_ms:        3000,
+	}
+	if err := yaml.Unmarshal(bytes, topo); err != nil {
+		return nil, err
+	}
+	if err := topo.Validate(); err != nil {
+		return nil, err
+	}
+	return topo, nil
+}
+
+// Validate checks that the nodes are unique and that links connect known nodes.
+func (t *Topology) Validate() error {
+	if t.Network == "" {
+		return errors.New("network must be set")
+	}
+
+	nodes := make(map[string]bool)
+	for _, node := range t.Nodes {
+		if node.Name == "" {
+			return errors.New("node name must be set")
+		}
+		if nodes[node.Name] {
+			return fmt.Errorf("duplicate node %s", node.Name)
+		}
+		nodes[node.Name] = true
+	}
+
+	for _, link := range t.Links {
+		if !nodes[link.A] || !nodes[link.B] {
+			return fmt.Errorf("link %s-%s connects an unknown node", link.A, link.B)
+		}
+		if link.A == link.B {
+			return fmt.Errorf("link %s-%s connects a node to itself", link.A, link.B)
+		}
+		if link.Loss < 0 || link.Loss > 1 {
+			return fmt.Errorf("loss of link %s-%s must be between 0 and 1", link.A, link.B)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package emu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTopology(t *testing.T) {
+	topo, err := ParseTopology([]byte(`
+network: /emu
+nodes:
+  - name: a
+  - name: b
+    router: /emu/site/b
+links:
+  - { a: a, b: b, delay: 10, loss: 0.1, bandwidth: 1000000 }
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "/emu", topo.Network)
+	assert.Equal(t, uint64(1000), topo.AdvertisementSyncInterval_ms)
+	assert.Equal(t, uint64(3000), topo.RouterDeadInterval_ms)
+	assert.Equal(t, []NodeConfig{{Name: "a"}, {Name: "b", Router: "/emu/site/b"}}, topo.Nodes)
+	assert.Equal(t, []LinkConfig{{A: "a", B: "b", Delay_ms: 10, Loss: 0.1, Bandwidth: 1000000}}, topo.Links)
+
+	// Invalid topologies
+	for _, topology := range []string{
+		"nodes: [{name: a}]",
+		"{network: /emu, nodes: [{name: a}, {name: a}]}",
+		"{network: /emu, nodes: [{name: a}], links: [{a: a, b: b}]}",
+		"{network: /emu, nodes: [{name: a}], links: [{a: a, b: a}]}",
+		"{network: /emu, nodes: [{name: a}, {name: b}], links: [{a: a, b: b, loss: 2}]}",
+		"network: [",
+	} {
+		_, err := ParseTopology([]byte(topology))
+		assert.Error(t, err, topology)
+	}
+
+	// The router intervals are checked when the network is created
+	topo.RouterDeadInterval_ms = 1000
+	_, err = NewNetwork(topo)
+	assert.Error(t, err)
+}
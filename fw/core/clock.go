@@ -0,0 +1,251 @@
+package core
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Clock is the source of time of the forwarder tables, threads and faces.
+// It is replaced by a ManualClock in tests to control timing deterministically.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After returns a channel that receives the current time once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+	// AfterFunc calls f in its own goroutine once d has elapsed.
+	AfterFunc(d time.Duration, f func()) Timer
+	// NewTicker returns a ticker that ticks every d.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is a pending call scheduled by Clock.AfterFunc.
+type Timer interface {
+	// Stop prevents the call. It returns false if the call already happened or was stopped.
+	Stop() bool
+}
+
+// Ticker delivers ticks at regular intervals.
+type Ticker interface {
+	// C returns the channel on which ticks are delivered.
+	C() <-chan time.Time
+	// Stop turns off the ticker.
+	Stop()
+}
+
+// clock is replaced atomically, since goroutines started at initialization already use it.
+var clock atomic.Pointer[Clock]
+
+func init() {
+	SetClock(SystemClock{})
+}
+
+// GetClock returns the clock in use.
+func GetClock() Clock {
+	return *clock.Load()
+}
+
+// SetClock replaces the clock in use. It must be called before the tables and
+// threads are created, and returns the previous clock.
+func SetClock(c Clock) Clock {
+	prev := clock.Swap(&c)
+	if prev == nil {
+		return nil
+	}
+	return *prev
+}
+
+// Now returns the current time of the clock in use.
+func Now() time.Time {
+	return GetClock().Now()
+}
+
+// Since returns the time elapsed since t according to the clock in use.
+func Since(t time.Time) time.Duration {
+	return GetClock().Now().Sub(t)
+}
+
+// Until returns the duration until t according to the clock in use.
+func Until(t time.Time) time.Duration {
+	return t.Sub(GetClock().Now())
+}
+
+// After waits for the duration to elapse on the clock in use.
+func After(d time.Duration) <-chan time.Time {
+	return GetClock().After(d)
+}
+
+// AfterFunc calls f once the duration elapsed on the clock in use.
+func AfterFunc(d time.Duration, f func()) Timer {
+	return GetClock().AfterFunc(d, f)
+}
+
+// NewTicker returns a ticker of the clock in use.
+func NewTicker(d time.Duration) Ticker {
+	return GetClock().NewTicker(d)
+}
+
+// SystemClock is the wall clock, as provided by the time package.
+type SystemClock struct{}
+
+type systemTicker struct {
+	*time.Ticker
+}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (SystemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (SystemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+func (SystemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+func (t systemTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// ManualClock is a clock that only moves forward when advanced, firing the
+// timers and tickers that became due in order of their deadlines.
+type ManualClock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	waiters []*manualWaiter
+}
+
+type manualWaiter struct {
+	clock    *ManualClock // set once scheduled
+	deadline time.Time
+	period   time.Duration // ticker period, zero for timers
+	fire     func(now time.Time)
+	ch       chan time.Time
+}
+
+// NewManualClock creates a manual clock starting at the specified time.
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	c.schedule(&manualWaiter{
+		fire: func(now time.Time) { ch <- now },
+	}, d)
+	return ch
+}
+
+func (c *ManualClock) AfterFunc(d time.Duration, f func()) Timer {
+	w := &manualWaiter{
+		fire: func(time.Time) { go f() },
+	}
+	c.schedule(w, d)
+	return w
+}
+
+func (c *ManualClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for ManualClock.NewTicker")
+	}
+	w := &manualWaiter{
+		period: d,
+		ch:     make(chan time.Time, 1),
+	}
+	w.fire = func(now time.Time) {
+		// Drop the tick if the previous one was not received, like time.Ticker
+		select {
+		case w.ch <- now:
+		default:
+		}
+	}
+	c.schedule(w, d)
+	return manualTicker{w}
+}
+
+// Advance moves the clock forward by d, firing the timers and tickers that
+// become due. Timers scheduled by fired callbacks also fire if they are due.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	end := c.now.Add(d)
+	for len(c.waiters) > 0 && !c.waiters[0].deadline.After(end) {
+		w := c.waiters[0]
+		c.waiters = c.waiters[1:]
+		c.now = w.deadline
+		if w.period > 0 {
+			w.deadline = w.deadline.Add(w.period)
+			c.insert(w)
+		}
+
+		now := c.now
+		c.mutex.Unlock()
+		w.fire(now)
+		c.mutex.Lock()
+	}
+	c.now = end
+	c.mutex.Unlock()
+}
+
+// Pending returns the number of timers and tickers waiting on the clock.
+func (c *ManualClock) Pending() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.waiters)
+}
+
+// schedule adds a waiter that fires once d has elapsed.
+func (c *ManualClock) schedule(w *manualWaiter, d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	w.clock = c
+	w.deadline = c.now.Add(d)
+	c.insert(w)
+}
+
+// insert adds a waiter after the waiters with the same deadline. The mutex must be held.
+func (c *ManualClock) insert(w *manualWaiter) {
+	i := sort.Search(len(c.waiters), func(i int) bool {
+		return c.waiters[i].deadline.After(w.deadline)
+	})
+	c.waiters = append(c.waiters, nil)
+	copy(c.waiters[i+1:], c.waiters[i:])
+	c.waiters[i] = w
+}
+
+// Stop removes the waiter from its clock.
+func (w *manualWaiter) Stop() bool {
+	c := w.clock
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for i, other := range c.waiters {
+		if other == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type manualTicker struct {
+	*manualWaiter
+}
+
+func (t manualTicker) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t manualTicker) Stop() {
+	t.manualWaiter.Stop()
+}
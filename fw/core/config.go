@@ -9,17 +9,30 @@ package core
 
 import (
 	"path/filepath"
+	"sync/atomic"
 )
 
-var (
-	baseDir string  = ""
-	config  *Config = DefaultConfig()
-)
+// loadedConfig is a configuration and the directory of its file.
+type loadedConfig struct {
+	config  *Config
+	baseDir string
+}
+
+// config is replaced as a whole when the configuration is reloaded,
+// while other threads may be reading it.
+var config atomic.Pointer[loadedConfig]
+
+func init() {
+	config.Store(&loadedConfig{config: DefaultConfig()})
+}
 
 type Config struct {
 	Core struct {
 		// Logging level
 		LogLevel string `json:"log_level"`
+		// Maximum time to finish pending Interests and flush queued packets
+		// when shutting down (in milliseconds), zero to stop immediately
+		ShutdownTimeout int `json:"shutdown_timeout"`
 	} `json:"core"`
 
 	Faces struct {
@@ -30,7 +43,18 @@ type Config struct {
 		// If true, face threads will be locked to processor cores
 		LockThreadsToCores bool `json:"lock_threads_to_cores"`
 
+		Reconnect struct {
+			// Delay before the first reconnection attempt of permanent faces (in milliseconds)
+			InitialInterval uint64 `json:"initial_interval"`
+			// Maximum delay between reconnection attempts (in milliseconds)
+			MaxInterval uint64 `json:"max_interval"`
+			// Random variation of each delay, as a fraction of the delay
+			Jitter float64 `json:"jitter"`
+		} `json:"reconnect"`
+
 		Udp struct {
+			// Whether UDP listeners for unicast faces are enabled
+			Enabled bool `json:"enabled"`
 			// Port used for unicast UDP faces
 			PortUnicast uint16 `json:"port_unicast"`
 			// Port used for multicast UDP faces
@@ -39,8 +63,25 @@ type Config struct {
 			MulticastAddressIpv4 string `json:"multicast_address_ipv4"`
 			// IPv6 address used for multicast UDP faces
 			MulticastAddressIpv6 string `json:"multicast_address_ipv6"`
+			// Whether to create multicast UDP faces on network interfaces
+			Multicast bool `json:"multicast"`
+			// If true, multicast faces are ad hoc, i.e., Interests can be forwarded back
+			// on the face they were received from (e.g., on wireless mesh networks)
+			MulticastAdHoc bool `json:"multicast_ad_hoc"`
+			// Interfaces where multicast faces are created. Each entry is an interface
+			// name (with * wildcards), a MAC address, or a subnet (e.g., 192.168.1.0/24).
+			// Names and MAC addresses select all addresses of the interface, while
+			// subnets only select the addresses of the interface in the subnet.
+			Whitelist []string `json:"whitelist"`
+			// Interfaces where multicast faces are not created, in the whitelist format
+			Blacklist []string `json:"blacklist"`
 			// Lifetime of on-demand faces (in seconds)
 			Lifetime uint64 `json:"lifetime"`
+			// If true, unicast UDP packets are sent with the Don't Fragment flag,
+			// and the MTU of faces is lowered to the path MTU discovered by the kernel.
+			// Only enable it on networks where ICMP "packet too big" messages are not
+			// filtered, otherwise oversized packets are silently lost.
+			PathMtuDiscovery bool `json:"path_mtu_discovery"`
 		} `json:"udp"`
 
 		Tcp struct {
@@ -57,6 +98,12 @@ type Config struct {
 			Enabled bool `json:"enabled"`
 			// Location of the socket file
 			SocketPath string `json:"socket_path"`
+			// Owner of the socket file (user name or UID), unchanged if empty
+			SocketOwner string `json:"socket_owner"`
+			// Group of the socket file (group name or GID), unchanged if empty
+			SocketGroup string `json:"socket_group"`
+			// Permissions of the socket file, in octal
+			SocketMode string `json:"socket_mode"`
 		} `json:"unix"`
 
 		WebSocket struct {
@@ -73,6 +120,36 @@ type Config struct {
 			// TLS private key (relative to the config file)
 			TlsKey string `json:"tls_key"`
 		} `json:"websocket"`
+
+		Quic struct {
+			// Whether to enable the QUIC listener (also accepting WebTransport sessions)
+			Enabled bool `json:"enabled"`
+			// Bind address for the QUIC listener
+			Bind string `json:"bind"`
+			// UDP port for the QUIC listener
+			Port uint16 `json:"port"`
+			// TLS certificate path (relative to the config file).
+			// If empty, a self-signed certificate is generated at startup.
+			TlsCert string `json:"tls_cert"`
+			// TLS private key (relative to the config file)
+			TlsKey string `json:"tls_key"`
+			// HTTP path of WebTransport sessions
+			WebTransportPath string `json:"webtransport_path"`
+			// If true, certificates of remote QUIC endpoints are not verified
+			// (e.g., for testing with self-signed certificates)
+			InsecureSkipVerify bool `json:"insecure_skip_verify"`
+		} `json:"quic"`
+
+		Capture struct {
+			// Directory where packet captures are written (relative to the config file)
+			Directory string `json:"directory"`
+			// Default maximum size of each capture file (in bytes)
+			MaxSize uint64 `json:"max_size"`
+		} `json:"capture"`
+
+		// List of faces created at startup. These faces are created again
+		// if they are permanent and go down.
+		Static []StaticFaceConfig `json:"static"`
 	} `json:"faces"`
 
 	Fw struct {
@@ -80,15 +157,47 @@ type Config struct {
 		Threads int `json:"threads"`
 		// Size of queues in the forwarding system
 		QueueSize int `json:"queue_size"`
+		// Maximum number of queued Interests and Data processed by a forwarding thread
+		// per wakeup. Larger batches amortize the wakeup cost at high packet rates.
+		BatchSize int `json:"batch_size"`
 		// If true, face threads will be locked to processor cores
 		LockThreadsToCores bool `json:"lock_threads_to_cores"`
+		// Packet dropped when a forwarding thread queue is full
+		// Allowed options: tail (the incoming packet), oldest (the oldest queued packet)
+		DropPolicy string `json:"drop_policy"`
+		// How dropped Interests are signaled to the downstream
+		// Allowed options: none, mark (congestion mark the next packet sent to
+		// the incoming face), nack (Nack with reason Congestion)
+		DropSignal string `json:"drop_signal"`
+
+		Trace struct {
+			// Fraction of incoming packets whose pipeline decisions are traced,
+			// from 0 (disabled) to 1 (every packet)
+			SampleRate float64 `json:"sample_rate"`
+			// Number of most recent traces published in the /localhost/nfd/trace dataset
+			Capacity int `json:"capacity"`
+		} `json:"trace"`
 	} `json:"fw"`
 
 	Mgmt struct {
 		// Controls whether management over /localhop is enabled or disabled
 		AllowLocalhop bool `json:"allow_localhop"`
+		// Restrictions of local users connected over the Unix socket, identified by
+		// their credentials. Users without an entry have full privileges. Faces
+		// without credentials (e.g., TCP or WebSocket from 127.0.0.1) get the rule
+		// of user *, or may only read status datasets if there is no such rule.
+		LocalUsers []LocalUserConfig `json:"local_users"`
 	} `json:"mgmt"`
 
+	Metrics struct {
+		// Enables or disables the OpenMetrics (Prometheus) HTTP listener
+		Enabled bool `json:"enabled"`
+		// Bind address for the metrics listener
+		Bind string `json:"bind"`
+		// Port for the metrics listener
+		Port uint16 `json:"port"`
+	} `json:"metrics"`
+
 	Tables struct {
 		// Size of queues in the table system
 		QueueSize int `json:"queue_size"`
@@ -120,8 +229,30 @@ type Config struct {
 		Rib struct {
 			// Enables or disables readvertising to the routing daemon
 			ReadvertiseNlsr bool `json:"readvertise_nlsr"`
+			// List of routes created at startup (with origin static)
+			StaticRoutes []StaticRouteConfig `json:"static_routes"`
 		} `json:"rib"`
 
+		Filter struct {
+			// Rules blocking or allowing packets by name, evaluated in order.
+			// The first matching rule applies; packets matching no rule are allowed.
+			Rules []FilterRuleConfig `json:"rules"`
+		} `json:"filter"`
+
+		Verify struct {
+			// Rules requiring the signature of Data under a prefix to be verified before
+			// it is cached. Data with an invalid signature is dropped, and Data signed by
+			// a key that is not known yet is forwarded without being cached. Only the rule
+			// with the longest prefix of a Data name applies.
+			Rules []VerifyRuleConfig `json:"rules"`
+			// Trust anchor certificate files (relative to the config file),
+			// encoded in base64 as exported by ndnsec or in binary
+			Anchors []string `json:"anchors"`
+			// Number of keys learned from the certificates forwarded by this node
+			// that are kept once verified
+			CacheSize int `json:"cache_size"`
+		} `json:"verify"`
+
 		Fib struct {
 			// Selects the algorithm used to implement the FIB
 			// Allowed options: nametree, hashtable
@@ -135,19 +266,96 @@ type Config struct {
 	} `json:"tables"`
 }
 
+// StaticFaceConfig describes a face declared in the configuration file.
+type StaticFaceConfig struct {
+	// Remote URI of the face (e.g. udp4://192.0.2.1:6363 or wss://gateway.example.net),
+	// or fd://N for a connected Unix or TCP socket passed by a supervisor
+	Uri string `json:"uri"`
+	// Persistency of the face (persistent or permanent, faces on fd:// are persistent)
+	Persistency string `json:"persistency"`
+	// MTU of the face (0 to use the default)
+	Mtu int `json:"mtu"`
+	// Whether local fields are enabled on the face
+	LocalFields bool `json:"local_fields"`
+	// Whether congestion marking is enabled on the face
+	CongestionMarking bool `json:"congestion_marking"`
+}
+
+// StaticRouteConfig describes a route declared in the configuration file.
+type StaticRouteConfig struct {
+	// Name prefix of the route
+	Prefix string `json:"prefix"`
+	// Remote URI of the nexthop face
+	Face string `json:"face"`
+	// Cost of the route
+	Cost uint64 `json:"cost"`
+	// If true, the ChildInherit flag is not set on the route
+	NoInherit bool `json:"no_inherit"`
+	// Whether the Capture flag is set on the route
+	Capture bool `json:"capture"`
+}
+
+// FilterRuleConfig describes a packet filter rule declared in the configuration file.
+type FilterRuleConfig struct {
+	// Action applied to matching packets (allow or deny)
+	Action string `json:"action"`
+	// Name prefix of matching packets
+	Prefix string `json:"prefix"`
+	// Direction of matching packets (in or out, both if empty)
+	Direction string `json:"direction"`
+	// Type of matching packets (interest or data, both if empty)
+	Type string `json:"type"`
+	// Remote URI pattern of the face of matching packets, where * also matches /
+	// (e.g. udp4://* or unix://*, any if empty)
+	Face string `json:"face"`
+	// Scope of the face of matching packets (local or non-local, both if empty)
+	Scope string `json:"scope"`
+}
+
+// VerifyRuleConfig describes a Data verification rule declared in the configuration file.
+type VerifyRuleConfig struct {
+	// Name prefix of the Data to verify
+	Prefix string `json:"prefix"`
+	// Prefix of the keys allowed to sign the Data (the Data prefix if empty)
+	KeyPrefix string `json:"key_prefix"`
+}
+
+// LocalUserConfig describes the restrictions of a local user.
+type LocalUserConfig struct {
+	// User name or UID, or * for all users without another entry and
+	// faces without peer credentials
+	User string `json:"user"`
+	// Whether the user may send management commands other than prefix
+	// registrations (status datasets can always be read)
+	Management bool `json:"management"`
+	// Prefixes under which the user may register routes (any if empty)
+	Prefixes []string `json:"prefixes"`
+}
+
 func DefaultConfig() *Config {
 	c := &Config{}
 	c.Core.LogLevel = "INFO"
+	c.Core.ShutdownTimeout = 5000
 
 	c.Faces.QueueSize = 1024
 	c.Faces.CongestionMarking = true
 	c.Faces.LockThreadsToCores = false
 
+	c.Faces.Reconnect.InitialInterval = 1000
+	c.Faces.Reconnect.MaxInterval = 60000
+	c.Faces.Reconnect.Jitter = 0.1
+
+	c.Faces.Udp.Enabled = true
 	c.Faces.Udp.PortUnicast = 6363
 	c.Faces.Udp.PortMulticast = 56363
 	c.Faces.Udp.MulticastAddressIpv4 = "224.0.23.170"
 	c.Faces.Udp.MulticastAddressIpv6 = "ff02::114"
+	c.Faces.Udp.Multicast = true
+	c.Faces.Udp.MulticastAdHoc = false
+	c.Faces.Udp.Whitelist = []string{"*"}
+	c.Faces.Udp.Blacklist = []string{}
 	c.Faces.Udp.Lifetime = 600
+	c.Faces.Udp.PathMtuDiscovery = false
 
 	c.Faces.Tcp.Enabled = true
 	c.Faces.Tcp.PortUnicast = 6363
@@ -155,6 +363,9 @@ func DefaultConfig() *Config {
 
 	c.Faces.Unix.Enabled = true
 	c.Faces.Unix.SocketPath = "/run/nfd/nfd.sock"
+	c.Faces.Unix.SocketOwner = ""
+	c.Faces.Unix.SocketGroup = ""
+	c.Faces.Unix.SocketMode = "0666"
 
 	c.Faces.WebSocket.Enabled = true
 	c.Faces.WebSocket.Bind = ""
@@ -163,11 +374,34 @@ func DefaultConfig() *Config {
 	c.Faces.WebSocket.TlsCert = ""
 	c.Faces.WebSocket.TlsKey = ""
 
+	c.Faces.Quic.Enabled = false
+	c.Faces.Quic.Bind = ""
+	c.Faces.Quic.Port = 6367
+	c.Faces.Quic.TlsCert = ""
+	c.Faces.Quic.TlsKey = ""
+	c.Faces.Quic.WebTransportPath = "/ndn"
+	c.Faces.Quic.InsecureSkipVerify = false
+
+	c.Faces.Capture.Directory = "/tmp"
+	c.Faces.Capture.MaxSize = 100 << 20
+
+	c.Faces.Static = []StaticFaceConfig{}
+
 	c.Fw.Threads = 8
 	c.Fw.QueueSize = 1024
+	c.Fw.BatchSize = 64
 	c.Fw.LockThreadsToCores = false
+	c.Fw.DropPolicy = "tail"
+	c.Fw.DropSignal = "none"
+	c.Fw.Trace.SampleRate = 0
+	c.Fw.Trace.Capacity = 256
 
 	c.Mgmt.AllowLocalhop = false
+	c.Mgmt.LocalUsers = []LocalUserConfig{}
+
+	c.Metrics.Enabled = false
+	c.Metrics.Bind = "127.0.0.1"
+	c.Metrics.Port = 9697
 
 	c.Tables.QueueSize = 1024
 
@@ -179,6 +413,13 @@ func DefaultConfig() *Config {
 	c.Tables.DeadNonceList.Lifetime = 6000
 	c.Tables.NetworkRegion.Regions = []string{}
 	c.Tables.Rib.ReadvertiseNlsr = true
+	c.Tables.Rib.StaticRoutes = []StaticRouteConfig{}
+
+	c.Tables.Filter.Rules = []FilterRuleConfig{}
+
+	c.Tables.Verify.Rules = []VerifyRuleConfig{}
+	c.Tables.Verify.Anchors = []string{}
+	c.Tables.Verify.CacheSize = 1024
 
 	c.Tables.Fib.Algorithm = "nametree"
 	c.Tables.Fib.Hashtable.M = 5
@@ -190,12 +431,11 @@ func LoadConfig(cfg *Config, basedir string) {
 	if cfg == nil {
 		LogFatal("Config", "Config is nil")
 	}
-	config = cfg
-	baseDir = basedir
+	config.Store(&loadedConfig{config: cfg, baseDir: basedir})
 }
 
 func GetConfig() *Config {
-	return config
+	return config.Load().config
 }
 
 // ResolveConfigFileRelPath resolves a possibly relative path based on config file path.
@@ -203,5 +443,5 @@ func ResolveConfigFileRelPath(target string) (abs string) {
 	if filepath.IsAbs(target) {
 		return target
 	}
-	return filepath.Join(baseDir, target)
+	return filepath.Join(config.Load().baseDir, target)
 }
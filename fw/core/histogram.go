@@ -0,0 +1,56 @@
+package core
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// Histogram counts observations in buckets with fixed upper bounds.
+// Observations and snapshots may happen concurrently.
+type Histogram struct {
+	bounds []float64
+	counts []atomic.Uint64 // one per bound, plus +Inf
+	sum    atomic.Uint64   // float64 bits
+}
+
+// NewHistogram creates a histogram with the given ascending bucket upper bounds.
+func NewHistogram(bounds []float64) *Histogram {
+	return &Histogram{
+		bounds: bounds,
+		counts: make([]atomic.Uint64, len(bounds)+1),
+	}
+}
+
+// Observe adds a value to the histogram.
+func (h *Histogram) Observe(v float64) {
+	i := 0
+	for i < len(h.bounds) && v > h.bounds[i] {
+		i++
+	}
+	h.counts[i].Add(1)
+
+	for {
+		old := h.sum.Load()
+		sum := math.Float64bits(math.Float64frombits(old) + v)
+		if h.sum.CompareAndSwap(old, sum) {
+			break
+		}
+	}
+}
+
+// Bounds returns the bucket upper bounds, excluding +Inf.
+func (h *Histogram) Bounds() []float64 {
+	return h.bounds
+}
+
+// Snapshot returns the cumulative count of each bucket (the last one being +Inf),
+// the sum of all observations and the number of observations.
+func (h *Histogram) Snapshot() (buckets []uint64, sum float64, count uint64) {
+	buckets = make([]uint64, len(h.counts))
+	var total uint64
+	for i := range h.counts {
+		total += h.counts[i].Load()
+		buckets[i] = total
+	}
+	return buckets, math.Float64frombits(h.sum.Load()), total
+}
@@ -12,12 +12,14 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"github.com/named-data/ndnd/std/log"
 )
 
-var shouldPrintTraceLogs = false
-var logLevel log.Level
+// The level of the forwarder's messages may be changed on reload while other threads are logging.
+var shouldPrintTraceLogs atomic.Bool
+var logLevel atomic.Int64 // log.Level
 var logFileObj *os.File
 
 // InitializeLogger initializes the logger.
@@ -33,20 +35,32 @@ func InitializeLogger(logFile string) {
 		log.SetHandler(log.NewText(logFileObj))
 	}
 
-	logLevelString := GetConfig().Core.LogLevel
+	// Messages are filtered by the level of the forwarder, which unlike
+	// the level of the handler can be changed while running
+	log.SetLevel(log.DebugLevel)
+	SetLogLevel(GetConfig().Core.LogLevel)
+}
+
+// InitializeEmbeddedLogger initializes the logger of a forwarder running inside an application.
+// Messages are written to the handler of the application, whose level is left unchanged.
+func InitializeEmbeddedLogger() {
+	SetLogLevel(GetConfig().Core.LogLevel)
+}
 
-	var err error
-	logLevel, err = log.ParseLevel(logLevelString)
-	if err == nil {
-		log.SetLevel(logLevel)
-	} else if logLevelString == "TRACE" {
+// SetLogLevel sets the level of the forwarder's messages. Unknown levels default to INFO.
+func SetLogLevel(logLevelString string) {
+	level, err := log.ParseLevel(logLevelString)
+	trace := false
+	if err != nil && logLevelString == "TRACE" {
 		// Apex doesn't support the TRACE level, so we have to work around that by calling them DEBUG,
 		// but not printing them if not TRACE
-		log.SetLevel(log.DebugLevel)
-		shouldPrintTraceLogs = true
-	} else {
-		log.SetLevel(log.InfoLevel)
+		level = log.DebugLevel
+		trace = true
+	} else if err != nil {
+		level = log.InfoLevel
 	}
+	logLevel.Store(int64(level))
+	shouldPrintTraceLogs.Store(trace)
 }
 
 // ShutdownLogger shuts down the logger.
@@ -98,42 +112,42 @@ func generateLogMessage(module interface{}, components ...interface{}) string {
 
 // LogFatal logs a message at the FATAL level. Note: Fatal will let the program exit
 func LogFatal(module interface{}, components ...interface{}) {
-	if logLevel <= log.FatalLevel {
+	if log.Level(logLevel.Load()) <= log.FatalLevel {
 		log.Fatal(generateLogMessage(module, components...))
 	}
 }
 
 // LogError logs a message at the ERROR level.
 func LogError(module interface{}, components ...interface{}) {
-	if logLevel <= log.ErrorLevel {
+	if log.Level(logLevel.Load()) <= log.ErrorLevel {
 		log.Error(generateLogMessage(module, components...))
 	}
 }
 
 // LogWarn logs a message at the WARN level.
 func LogWarn(module interface{}, components ...interface{}) {
-	if logLevel <= log.WarnLevel {
+	if log.Level(logLevel.Load()) <= log.WarnLevel {
 		log.Warn(generateLogMessage(module, components...))
 	}
 }
 
 // LogInfo logs a message at the INFO level.
 func LogInfo(module interface{}, components ...interface{}) {
-	if logLevel <= log.InfoLevel {
+	if log.Level(logLevel.Load()) <= log.InfoLevel {
 		log.Info(generateLogMessage(module, components...))
 	}
 }
 
 // LogDebug logs a message at the DEBUG level.
 func LogDebug(module interface{}, components ...interface{}) {
-	if logLevel <= log.DebugLevel {
+	if log.Level(logLevel.Load()) <= log.DebugLevel {
 		log.Debug(generateLogMessage(module, components...))
 	}
 }
 
 // LogTrace logs a message at the TRACE level (really just additional DEBUG messages).
 func LogTrace(module interface{}, components ...interface{}) {
-	if shouldPrintTraceLogs {
+	if shouldPrintTraceLogs.Load() {
 		log.Debug(generateLogMessage(module, components...))
 	}
 }
@@ -7,5 +7,11 @@
 
 package core
 
+import "sync/atomic"
+
 // ShouldQuit indicates whether threads should quit
-var ShouldQuit = false
+var ShouldQuit atomic.Bool
+
+// Draining indicates whether the forwarder is shutting down, and only finishes the
+// work in progress without accepting new faces or Interests.
+var Draining atomic.Bool
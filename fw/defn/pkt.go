@@ -19,8 +19,21 @@ type Pkt struct {
 	L3   *spec.Packet
 	Raw  []byte
 
+	// SigCovered is the signed portion of a Data packet in Raw, kept from its
+	// decoding so that the signature is verified without decoding it again.
+	SigCovered enc.Wire
+
+	// NameHash is the hash of Name, computed once when the packet
+	// is dispatched to a forwarding thread, and reused to index the CS.
+	NameHash uint64
+
+	// TraceID identifies the trace of the packet through the forwarding
+	// pipelines, or is zero if the packet is not sampled for tracing.
+	TraceID uint64
+
 	PitToken       []byte
 	CongestionMark *uint64
+	NackReason     *uint64
 	IncomingFaceID *uint64
 	NextHopFaceID  *uint64
 	CachePolicy    *uint64
@@ -40,14 +40,16 @@ const (
 	unixURI
 	wsURI
 	wsclientURI
+	quicURI
 )
 
 // URI represents a URI for a face.
 type URI struct {
-	uriType URIType
-	scheme  string
-	path    string
-	port    uint16
+	uriType  URIType
+	scheme   string
+	path     string
+	port     uint16
+	resource string // HTTP request path of WebSocket URIs, empty for the root
 }
 
 // MakeDevFaceURI constucts a URI for a network interface.
@@ -131,13 +133,19 @@ func MakeUnixFaceURI(path string) *URI {
 }
 
 // MakeWebSocketServerFaceURI constructs a URI for a WebSocket server.
+// The path of the URL is kept, so that servers behind a gateway can be reached.
 func MakeWebSocketServerFaceURI(u *url.URL) *URI {
 	port, _ := strconv.ParseUint(u.Port(), 10, 16)
+	resource := u.EscapedPath()
+	if resource == "/" {
+		resource = ""
+	}
 	return &URI{
-		uriType: wsURI,
-		scheme:  u.Scheme,
-		path:    u.Hostname(),
-		port:    uint16(port),
+		uriType:  wsURI,
+		scheme:   u.Scheme,
+		path:     u.Hostname(),
+		port:     uint16(port),
+		resource: resource,
 	}
 }
 
@@ -153,6 +161,16 @@ func MakeWebSocketClientFaceURI(addr net.Addr) *URI {
 	}
 }
 
+// MakeQUICFaceURI constructs a URI for a QUIC face.
+func MakeQUICFaceURI(host string, port uint16) *URI {
+	return &URI{
+		uriType: quicURI,
+		scheme:  "quic",
+		path:    host,
+		port:    port,
+	}
+}
+
 // DecodeURIString decodes a URI from a string.
 func DecodeURIString(str string) *URI {
 	u := new(URI)
@@ -271,12 +289,19 @@ func DecodeURIString(str string) *URI {
 		u.path = matches[2]
 	case strings.EqualFold("ws", schemeSplit[0]),
 		strings.EqualFold("wss", schemeSplit[0]):
+		uri, e := url.Parse(str)
+		if e != nil || uri.User != nil || uri.RawQuery != "" || uri.Fragment != "" {
+			return nil
+		}
+		u = MakeWebSocketServerFaceURI(uri)
+	case strings.EqualFold("quic", schemeSplit[0]):
 		uri, e := url.Parse(str)
 		if e != nil || uri.User != nil || strings.TrimLeft(uri.Path, "/") != "" ||
 			uri.RawQuery != "" || uri.Fragment != "" {
 			return nil
 		}
-		return MakeWebSocketServerFaceURI(uri)
+		port, _ := strconv.ParseUint(uri.Port(), 10, 16)
+		return MakeQUICFaceURI(uri.Hostname(), uint16(port))
 	case strings.EqualFold("wsclient", schemeSplit[0]):
 		addr, e := net.ResolveTCPAddr("tcp", strings.Trim(schemeSplit[1], "/"))
 		if e != nil {
@@ -359,6 +384,13 @@ func (u *URI) IsCanonical() bool {
 	case unixURI:
 		// Do not check whether file exists, because it may fail due to lack of privilege in testing environment
 		return u.scheme == "unix" && u.path != "" && u.port == 0
+	case wsURI:
+		// Host names are kept, as they are needed to verify TLS certificates
+		return (u.scheme == "ws" || u.scheme == "wss") && u.path != "" && u.port > 0 &&
+			(u.resource == "" || strings.HasPrefix(u.resource, "/"))
+	case quicURI:
+		// Host names are kept, as they are needed to verify TLS certificates
+		return u.scheme == "quic" && u.path != "" && u.port > 0
 	default:
 		// Of unknown type
 		return false
@@ -423,6 +455,27 @@ func (u *URI) Canonize() error {
 			return core.ErrNotCanonical
 		}
 		u.port = 0
+	case wsURI:
+		u.scheme = strings.ToLower(u.scheme)
+		if u.port == 0 {
+			if u.scheme == "wss" {
+				u.port = 443
+			} else {
+				u.port = 80
+			}
+		}
+		if !u.IsCanonical() {
+			return core.ErrNotCanonical
+		}
+	case quicURI:
+		u.scheme = "quic"
+		if u.port == 0 {
+			// Default port of the QUIC listener
+			u.port = 6367
+		}
+		if !u.IsCanonical() {
+			return core.ErrNotCanonical
+		}
 	default:
 		return core.ErrNotCanonical
 	}
@@ -450,6 +503,11 @@ func (u *URI) Scope() Scope {
 		return NonLocal
 	case unixURI:
 		return Local
+	case wsURI, quicURI:
+		if ip := net.ParseIP(u.path); u.path == "localhost" || (ip != nil && ip.IsLoopback()) {
+			return Local
+		}
+		return NonLocal
 	}
 
 	// Only valid types left is internal, which is by definition local
@@ -466,8 +524,10 @@ func (u *URI) String() string {
 		return "internal://"
 	case nullURI:
 		return "null://"
-	case udpURI, tcpURI, wsURI, wsclientURI:
+	case udpURI, tcpURI, wsclientURI, quicURI:
 		return u.scheme + "://" + net.JoinHostPort(u.path, strconv.FormatUint(uint64(u.port), 10))
+	case wsURI:
+		return u.scheme + "://" + net.JoinHostPort(u.path, strconv.FormatUint(uint64(u.port), 10)) + u.resource
 	case unixURI:
 		return u.scheme + "://" + u.path
 	default:
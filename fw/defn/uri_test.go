@@ -0,0 +1,57 @@
+package defn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebSocketURI(t *testing.T) {
+	tests := []struct {
+		uri       string
+		canonical string
+		scope     Scope
+	}{
+		{"ws://example.com:8080/ndn", "ws://example.com:8080/ndn", NonLocal},
+		{"WS://localhost/ndn/", "ws://localhost:80/ndn/", Local},
+		{"wss://gateway.example.net", "wss://gateway.example.net:443", NonLocal},
+		{"wss://gateway.example.net/", "wss://gateway.example.net:443", NonLocal},
+		{"wss://gateway.example.net/ws/ndn", "wss://gateway.example.net:443/ws/ndn", NonLocal},
+		{"ws://127.0.0.1:9696", "ws://127.0.0.1:9696", Local},
+		{"wss://[::1]:9696/a%20b", "wss://[::1]:9696/a%20b", Local},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			u := DecodeURIString(tt.uri)
+			require.NotNil(t, u)
+			assert.True(t, u.IsCanonical())
+			assert.Equal(t, tt.canonical, u.String())
+			assert.Equal(t, tt.scope, u.Scope())
+
+			// Round trip
+			again := DecodeURIString(u.String())
+			require.NotNil(t, again)
+			assert.Equal(t, u.String(), again.String())
+			assert.Equal(t, u.Scheme(), again.Scheme())
+		})
+	}
+
+	// Credentials, queries and fragments are not sent to the server
+	for _, uri := range []string{
+		"ws://user:pass@example.com/ndn",
+		"ws://example.com/ndn?key=value",
+		"wss://example.com/ndn#fragment",
+	} {
+		assert.Nil(t, DecodeURIString(uri), uri)
+	}
+}
+
+func TestWebSocketClientURI(t *testing.T) {
+	u := DecodeURIString("wsclient://127.0.0.1:51234")
+	require.NotNil(t, u)
+	assert.Equal(t, "wsclient://127.0.0.1:51234", u.String())
+	assert.Equal(t, "wsclient", u.Scheme())
+	assert.Equal(t, uint16(51234), u.Port())
+	assert.Equal(t, "wsclient://127.0.0.1:51234", DecodeURIString(u.String()).String())
+}
@@ -28,6 +28,8 @@ type Face interface {
 	State() defn.State
 
 	SendPacket(out OutPkt)
+	// SignalCongestion marks the next packet sent on the face with a congestion mark.
+	SignalCongestion()
 }
 
 type OutPkt struct {
@@ -0,0 +1,51 @@
+package executor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/std/engine"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureMgmt(t *testing.T) {
+	prevConfig := core.GetConfig()
+	config := *prevConfig
+	config.Faces.Capture.Directory = t.TempDir()
+	core.LoadConfig(&config, "")
+	defer core.LoadConfig(prevConfig, "")
+
+	client := engine.NewBasicEngine(engine.NewUnixFace(testSocket))
+	require.NoError(t, client.Start())
+	defer client.Stop()
+
+	// Unknown faces and missing captures
+	err := client.ExecMgmtCmd("capture", "start", &mgmt.ControlArgs{FaceId: utils.IdPtr(uint64(1 << 40))})
+	assert.ErrorContains(t, err, "410")
+	err = client.ExecMgmtCmd("capture", "stop", &mgmt.ControlArgs{FaceId: utils.IdPtr(uint64(1 << 40))})
+	assert.ErrorContains(t, err, "404")
+
+	// Capture on all faces, limited by Count and Capacity
+	require.NoError(t, client.ExecMgmtCmd("capture", "start", &mgmt.ControlArgs{
+		Count:    utils.IdPtr(uint64(100)),
+		Capacity: utils.IdPtr(uint64(1 << 20)),
+	}))
+	require.NoError(t, client.ExecMgmtCmd("capture", "start", &mgmt.ControlArgs{
+		Count: utils.IdPtr(uint64(100)),
+	}))
+	err = client.ExecMgmtCmd("capture", "start", &mgmt.ControlArgs{
+		Count: utils.IdPtr(uint64(10)),
+	})
+	assert.ErrorContains(t, err, "409")
+	require.NoError(t, client.ExecMgmtCmd("capture", "stop", &mgmt.ControlArgs{}))
+
+	files, err := filepath.Glob(filepath.Join(config.Faces.Capture.Directory, "yanfd-face-all-*.pcapng"))
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+	err = client.ExecMgmtCmd("capture", "stop", &mgmt.ControlArgs{})
+	assert.ErrorContains(t, err, "404")
+}
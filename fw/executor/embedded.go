@@ -0,0 +1,111 @@
+package executor
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/face"
+	enc "github.com/named-data/ndnd/std/encoding"
+	ndn_face "github.com/named-data/ndnd/std/engine/face"
+)
+
+// StartEmbedded starts a forwarder inside the current program, for applications that
+// bundle their own forwarder instead of connecting to a separate daemon. The configuration
+// is typically core.DefaultConfig() with the listeners that are not needed disabled.
+// Applications connect to the forwarder with the faces returned by NewFace.
+// Messages are logged with the logger of the application.
+// Note: only one forwarder can run in a program, and it cannot be restarted after Stop.
+func StartEmbedded(config *core.Config) *YaNFD {
+	if config == nil {
+		config = core.DefaultConfig()
+	}
+
+	y := NewYaNFD(&YaNFDConfig{
+		Version:  core.Version,
+		Config:   config,
+		Embedded: true,
+	})
+	y.Start()
+	return y
+}
+
+// NewFace makes a face for an application engine (e.g., std/engine/basic.Engine),
+// connected to an internal face of the forwarder without going through a socket.
+// The internal face is created when the face is opened, and closed with it.
+func (y *YaNFD) NewFace() ndn_face.Face {
+	return &embeddedFace{}
+}
+
+// embeddedFace is the application side of an in-process face.
+type embeddedFace struct {
+	transport atomic.Pointer[face.InProcessTransport]
+	running   atomic.Bool
+	onPkt     func(r enc.ParseReader) error
+	onError   func(err error) error
+}
+
+func (f *embeddedFace) Open() error {
+	if f.onError == nil || f.onPkt == nil {
+		return errors.New("face callbacks are not set")
+	}
+	if f.running.Load() {
+		return errors.New("face is already running")
+	}
+
+	_, transport := face.RegisterInProcessTransport()
+	f.transport.Store(transport)
+	f.running.Store(true)
+	go f.run(transport)
+	return nil
+}
+
+func (f *embeddedFace) run(transport *face.InProcessTransport) {
+	for {
+		frame, ok := transport.ReceiveFromForwarder()
+		if !ok {
+			// Report that the forwarder closed the face, unless the application did
+			if f.transport.Load() == transport && f.running.Load() {
+				f.onError(errors.New("face closed by the forwarder"))
+			}
+			break
+		}
+		if err := f.onPkt(enc.NewBufferReader(frame)); err != nil {
+			// The engine interrupts the face loop with an error
+			break
+		}
+	}
+	transport.Close()
+	if f.transport.Load() == transport {
+		f.running.Store(false)
+	}
+}
+
+func (f *embeddedFace) Close() error {
+	if !f.running.Swap(false) {
+		return errors.New("face is not running")
+	}
+	f.transport.Load().Close()
+	return nil
+}
+
+func (f *embeddedFace) Send(pkt enc.Wire) error {
+	if !f.running.Load() {
+		return errors.New("face is not running")
+	}
+	return f.transport.Load().SendToForwarder(pkt.Join())
+}
+
+func (f *embeddedFace) IsRunning() bool {
+	return f.running.Load()
+}
+
+func (f *embeddedFace) IsLocal() bool {
+	return true
+}
+
+func (f *embeddedFace) SetCallback(onPkt func(r enc.ParseReader) error,
+	onError func(err error) error) {
+	f.onPkt = onPkt
+	f.onError = onError
+}
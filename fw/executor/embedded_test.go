@@ -0,0 +1,85 @@
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/face"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/engine"
+	"github.com/named-data/ndnd/std/ndn"
+	sec "github.com/named-data/ndnd/std/security"
+	"github.com/named-data/ndnd/std/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedRoundTrip(t *testing.T) {
+	y := testForwarder
+
+	// Producer
+	producer := engine.NewBasicEngine(y.NewFace())
+	require.NoError(t, producer.Start())
+	defer producer.Stop()
+
+	prefix, err := enc.NameFromStr("/embedded/test")
+	require.NoError(t, err)
+	require.NoError(t, producer.AttachHandler(prefix, func(args ndn.InterestHandlerArgs) {
+		data, err := producer.Spec().MakeData(args.Interest.Name(), &ndn.DataConfig{},
+			enc.Wire{[]byte("hello")}, sec.NewSha256Signer())
+		if err == nil {
+			args.Reply(data.Wire)
+		}
+	}))
+	require.NoError(t, producer.RegisterRoute(prefix))
+
+	// Consumer, on another face of the forwarder
+	consumer := engine.NewBasicEngine(y.NewFace())
+	require.NoError(t, consumer.Start())
+	defer consumer.Stop()
+
+	name, err := enc.NameFromStr("/embedded/test/1")
+	require.NoError(t, err)
+	interest, err := consumer.Spec().MakeInterest(name, &ndn.InterestConfig{
+		Lifetime: utils.IdPtr(time.Second),
+		Nonce:    utils.IdPtr(uint64(1)),
+	}, nil, nil)
+	require.NoError(t, err)
+
+	result := make(chan ndn.ExpressCallbackArgs, 1)
+	require.NoError(t, consumer.Express(interest, func(args ndn.ExpressCallbackArgs) {
+		result <- args
+	}))
+
+	select {
+	case args := <-result:
+		require.Equal(t, ndn.InterestResultData, args.Result)
+		assert.Equal(t, name.String(), args.Data.Name().String())
+		assert.Equal(t, []byte("hello"), args.Data.Content().Join())
+	case <-time.After(5 * time.Second):
+		t.Fatal("Data not received through the embedded forwarder")
+	}
+
+	// The application is told when the forwarder closes its face
+	f := y.NewFace()
+	closed := make(chan error, 1)
+	f.SetCallback(func(r enc.ParseReader) error { return nil }, func(err error) error {
+		closed <- err
+		return err
+	})
+	require.NoError(t, f.Open())
+
+	transport := f.(*embeddedFace).transport.Load()
+	for _, linkService := range face.FaceTable.GetAll() {
+		if linkService.Transport() == transport {
+			linkService.Close()
+		}
+	}
+	select {
+	case err := <-closed:
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Closed face not reported to the application")
+	}
+	require.Eventually(t, func() bool { return !f.IsRunning() }, 5*time.Second, 10*time.Millisecond)
+}
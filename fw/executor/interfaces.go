@@ -0,0 +1,219 @@
+package executor
+
+import (
+	"net"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/face"
+)
+
+// interfaceAddr is an address of a network interface that is up.
+type interfaceAddr struct {
+	iface     net.Interface
+	ip        net.IP
+	ipVersion int
+	path      string // with the zone of IPv6 addresses
+}
+
+// addrListener is a UDP or TCP listener bound to an interface address.
+type addrListener interface {
+	Run()
+	Close()
+	Stopped() bool
+}
+
+// interfaceAddrs returns the addresses of the network interfaces that are up.
+func interfaceAddrs() ([]interfaceAddr, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	var addrs []interfaceAddr
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 {
+			continue
+		}
+
+		ifaceAddrs, err := iface.Addrs()
+		if err != nil {
+			core.LogError("Main", "Unable to access addresses on network interface ", iface.Name, ": ", err)
+			continue
+		}
+		for _, addr := range ifaceAddrs {
+			ipAddr, ok := addr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+
+			ifaceAddr := interfaceAddr{
+				iface:     iface,
+				ip:        ipAddr.IP,
+				ipVersion: 4,
+				path:      ipAddr.IP.String(),
+			}
+			if ipAddr.IP.To4() == nil {
+				ifaceAddr.ipVersion = 6
+				ifaceAddr.path += "%" + iface.Name
+			}
+			addrs = append(addrs, ifaceAddr)
+		}
+	}
+	return addrs, nil
+}
+
+// updateListeners creates a listener for every interface address, and closes the
+// listeners whose address is gone. Listeners that stopped, e.g., because the address
+// was not ready yet, are created again. It returns the number of listeners.
+func updateListeners(
+	kind string,
+	listeners map[string]addrListener,
+	addrs []interfaceAddr,
+	makeListener func(ipVersion int, path string) (addrListener, error),
+) int {
+	wanted := make(map[string]bool)
+	for _, addr := range addrs {
+		wanted[addr.path] = true
+		if listener, ok := listeners[addr.path]; ok && !listener.Stopped() {
+			continue
+		}
+
+		listener, err := makeListener(addr.ipVersion, addr.path)
+		if err != nil {
+			core.LogError("Main", "Unable to create ", kind, " listener for ", addr.path, " on ", addr.iface.Name, ": ", err)
+			continue
+		}
+		go listener.Run()
+		listeners[addr.path] = listener
+		core.LogInfo("Main", "Created ", kind, " listener for ", addr.path, " on ", addr.iface.Name)
+	}
+
+	for path, listener := range listeners {
+		if !wanted[path] {
+			core.LogInfo("Main", "Closing ", kind, " listener for ", path, " because the address is gone")
+			listener.Close()
+			delete(listeners, path)
+		}
+	}
+	return len(listeners)
+}
+
+// updateUDPListeners creates a UDP listener for every address on every interface that is up.
+// It returns the number of listeners.
+func (y *YaNFD) updateUDPListeners(addrs []interfaceAddr) int {
+	return updateListeners("UDP", y.udpListeners, addrs, func(ipVersion int, path string) (addrListener, error) {
+		return face.MakeUDPListener(defn.MakeUDPFaceURI(ipVersion, path, face.UDPUnicastPort))
+	})
+}
+
+// updateTCPListeners creates a TCP listener for every address on every interface that is up.
+// It returns the number of listeners.
+func (y *YaNFD) updateTCPListeners(addrs []interfaceAddr) int {
+	return updateListeners("TCP", y.tcpListeners, addrs, func(ipVersion int, path string) (addrListener, error) {
+		return face.MakeTCPListener(defn.MakeTCPFaceURI(ipVersion, path, face.TCPUnicastPort))
+	})
+}
+
+// updateMulticastFaces creates a multicast UDP face for every address of every allowed
+// interface, and closes the faces whose address is gone or no longer allowed.
+// It returns the number of multicast faces.
+func (y *YaNFD) updateMulticastFaces(addrs []interfaceAddr) int {
+	wanted := make(map[string]bool)
+	for _, addr := range addrs {
+		if addr.ip.IsLoopback() || addr.iface.Flags&net.FlagMulticast == 0 {
+			continue
+		}
+		if !face.MulticastAddressAllowed(&addr.iface, addr.ip) {
+			core.LogDebug("Main", "Skipping multicast on ", addr.path, " of interface ", addr.iface.Name, " because not allowed")
+			continue
+		}
+
+		localURI := defn.MakeUDPFaceURI(addr.ipVersion, addr.path, face.UDPMulticastPort)
+		wanted[localURI.String()] = true
+
+		// Keep the existing face unless it failed meanwhile
+		if faceID, ok := y.multicastFaces[localURI.String()]; ok && face.FaceTable.Get(faceID) != nil {
+			continue
+		}
+
+		multicastUDPTransport, err := face.MakeMulticastUDPTransport(localURI)
+		if err != nil {
+			core.LogError("Main", "Unable to create MulticastUDPTransport for ", addr.path, " on ", addr.iface.Name, ": ", err)
+			continue
+		}
+
+		linkService := face.MakeNDNLPLinkService(multicastUDPTransport, face.MakeNDNLPLinkServiceOptions())
+		linkService.Run(nil)
+		y.multicastFaces[localURI.String()] = linkService.FaceID()
+		core.LogInfo("Main", "Created multicast UDP face for ", addr.path, " on ", addr.iface.Name)
+	}
+
+	// Close faces of addresses that disappeared
+	for localURI, faceID := range y.multicastFaces {
+		if wanted[localURI] {
+			continue
+		}
+		if multicastFace := face.FaceTable.Get(faceID); multicastFace != nil {
+			core.LogInfo("Main", "Closing multicast UDP face for ", localURI, " because the address is gone")
+			multicastFace.Close()
+		}
+		delete(y.multicastFaces, localURI)
+	}
+
+	return len(y.multicastFaces)
+}
+
+// closeOrphanedFaces closes the on-demand UDP and TCP faces whose local address is gone,
+// since their remote endpoint cannot reach them anymore.
+func closeOrphanedFaces(addrs []interfaceAddr) {
+	present := make(map[string]bool)
+	for _, addr := range addrs {
+		present[addr.ip.String()] = true
+	}
+
+	for _, linkService := range face.FaceTable.GetAll() {
+		if linkService.Persistency() != face.PersistencyOnDemand || linkService.LocalURI() == nil {
+			continue
+		}
+		switch linkService.LocalURI().Scheme() {
+		case "udp4", "udp6", "tcp4", "tcp6":
+		default:
+			continue
+		}
+
+		ip := net.ParseIP(linkService.LocalURI().PathHost())
+		if ip == nil || ip.IsUnspecified() || present[ip.String()] {
+			continue
+		}
+		core.LogInfo("Main", "Closing face ", linkService.FaceID(), " because local address ", ip, " is gone")
+		linkService.Close()
+	}
+}
+
+// onInterfacesChanged updates the listeners and faces bound to network interfaces.
+func (y *YaNFD) onInterfacesChanged() {
+	y.reloadMu.Lock()
+	defer y.reloadMu.Unlock()
+
+	if y.stopping || core.ShouldQuit.Load() {
+		return
+	}
+
+	addrs, err := interfaceAddrs()
+	if err != nil {
+		core.LogError("Main", "Unable to access network interfaces: ", err)
+		return
+	}
+
+	if core.GetConfig().Faces.Udp.Enabled && !y.activated["udp"] {
+		y.updateUDPListeners(addrs)
+	}
+	if core.GetConfig().Faces.Tcp.Enabled && !y.activated["tcp"] {
+		y.updateTCPListeners(addrs)
+	}
+	if core.GetConfig().Faces.Udp.Multicast {
+		y.updateMulticastFaces(addrs)
+	}
+	closeOrphanedFaces(addrs)
+}
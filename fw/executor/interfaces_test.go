@@ -0,0 +1,114 @@
+package executor
+
+import (
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/face"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testListener is a listener that records how it is used.
+type testListener struct {
+	path    string
+	running atomic.Bool
+	closed  atomic.Bool
+	stopped atomic.Bool
+}
+
+func (l *testListener) Run()          { l.running.Store(true) }
+func (l *testListener) Close()        { l.closed.Store(true) }
+func (l *testListener) Stopped() bool { return l.stopped.Load() }
+
+func testInterfaceAddr(ip string) interfaceAddr {
+	addr := interfaceAddr{
+		iface:     net.Interface{Name: "test0"},
+		ip:        net.ParseIP(ip),
+		ipVersion: 4,
+		path:      ip,
+	}
+	if addr.ip.To4() == nil {
+		addr.ipVersion = 6
+		addr.path += "%test0"
+	}
+	return addr
+}
+
+func TestUpdateListeners(t *testing.T) {
+	listeners := make(map[string]addrListener)
+	var created []*testListener
+	makeListener := func(ipVersion int, path string) (addrListener, error) {
+		if path == "192.0.2.99" {
+			return nil, errors.New("address not ready")
+		}
+		l := &testListener{path: path}
+		created = append(created, l)
+		return l, nil
+	}
+	a := testInterfaceAddr("192.0.2.1")
+	b := testInterfaceAddr("2001:db8::1")
+
+	// A listener per address, started in the background
+	n := updateListeners("Test", listeners, []interfaceAddr{a, b}, makeListener)
+	assert.Equal(t, 2, n)
+	require.Len(t, created, 2)
+	assert.Equal(t, "192.0.2.1", created[0].path)
+	assert.Equal(t, "2001:db8::1%test0", created[1].path)
+	for _, l := range created {
+		require.Eventually(t, l.running.Load, 5*time.Second, time.Millisecond)
+	}
+
+	// Existing listeners are kept, and failed ones are not added
+	n = updateListeners("Test", listeners, []interfaceAddr{a, b, testInterfaceAddr("192.0.2.99")}, makeListener)
+	assert.Equal(t, 2, n)
+	assert.Len(t, created, 2)
+
+	// Stopped listeners are created again
+	created[0].stopped.Store(true)
+	n = updateListeners("Test", listeners, []interfaceAddr{a, b}, makeListener)
+	assert.Equal(t, 2, n)
+	require.Len(t, created, 3)
+	assert.Equal(t, "192.0.2.1", created[2].path)
+	assert.Same(t, created[2], listeners["192.0.2.1"])
+
+	// Listeners of addresses that are gone are closed
+	n = updateListeners("Test", listeners, []interfaceAddr{a}, makeListener)
+	assert.Equal(t, 1, n)
+	assert.True(t, created[1].closed.Load())
+	assert.False(t, created[2].closed.Load())
+	assert.NotContains(t, listeners, "2001:db8::1%test0")
+}
+
+func TestCloseOrphanedFaces(t *testing.T) {
+	makeFace := func(persistency face.Persistency) face.LinkService {
+		remote, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		require.NoError(t, err)
+		t.Cleanup(func() { remote.Close() })
+		transport, err := face.MakeUnicastUDPTransport(
+			defn.DecodeURIString("udp4://"+remote.LocalAddr().String()), nil, persistency)
+		require.NoError(t, err)
+		linkService := face.MakeNDNLPLinkService(transport, face.MakeNDNLPLinkServiceOptions())
+		linkService.Run(nil)
+		return linkService
+	}
+	onDemand := makeFace(face.PersistencyOnDemand)
+	persistent := makeFace(face.PersistencyPersistent)
+	defer persistent.Close()
+	require.Equal(t, "127.0.0.1", onDemand.LocalURI().PathHost())
+
+	// Faces are kept while their local address is present
+	closeOrphanedFaces([]interfaceAddr{testInterfaceAddr("127.0.0.1"), testInterfaceAddr("::1")})
+	time.Sleep(50 * time.Millisecond)
+	assert.NotNil(t, face.FaceTable.Get(onDemand.FaceID()))
+
+	// Only on-demand faces are closed once it is gone
+	closeOrphanedFaces([]interfaceAddr{testInterfaceAddr("::1")})
+	require.Eventually(t, func() bool { return face.FaceTable.Get(onDemand.FaceID()) == nil },
+		5*time.Second, 10*time.Millisecond)
+	assert.NotNil(t, face.FaceTable.Get(persistent.FaceID()))
+}
@@ -1,6 +1,7 @@
 package executor
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -46,19 +47,13 @@ func Main(args []string) {
 		flagset.Usage()
 		os.Exit(3)
 	}
+	config.ConfigFile = configfile
 	config.BaseDir = filepath.Dir(configfile)
 
-	f, err := os.Open(configfile)
+	var err error
+	config.Config, err = ReadConfigFile(configfile)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Unable to open configuration file: "+err.Error())
-		os.Exit(3)
-	}
-	defer f.Close()
-
-	config.Config = core.DefaultConfig()
-	dec := yaml.NewDecoder(f, yaml.Strict())
-	if err = dec.Decode(config.Config); err != nil {
-		fmt.Fprintln(os.Stderr, "Unable to parse configuration file: "+err.Error())
+		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(3)
 	}
 
@@ -68,9 +63,46 @@ func Main(args []string) {
 
 	// set up signal handler channel and wait for interrupt
 	sigChannel := make(chan os.Signal, 1)
-	signal.Notify(sigChannel, os.Interrupt, syscall.SIGTERM)
-	receivedSig := <-sigChannel
-	core.LogInfo("Main", "Received signal ", receivedSig, " - exiting")
+	signal.Notify(sigChannel, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	for {
+		receivedSig := <-sigChannel
+		if receivedSig == syscall.SIGHUP {
+			core.LogInfo("Main", "Received signal ", receivedSig, " - reloading configuration")
+			if _, err := yanfd.Reload(); err != nil {
+				core.LogError("Main", "Unable to reload configuration: ", err)
+			}
+			continue
+		}
+
+		core.LogInfo("Main", "Received signal ", receivedSig, " - exiting")
+		break
+	}
+
+	// Exit immediately on another signal while draining
+	go func() {
+		for receivedSig := range sigChannel {
+			if receivedSig != syscall.SIGHUP {
+				core.LogWarn("Main", "Received signal ", receivedSig, " again - exiting immediately")
+				os.Exit(1)
+			}
+		}
+	}()
 
 	yanfd.Stop()
 }
+
+// ReadConfigFile reads a YAML configuration file on top of the default configuration.
+func ReadConfigFile(configfile string) (*core.Config, error) {
+	f, err := os.Open(configfile)
+	if err != nil {
+		return nil, errors.New("Unable to open configuration file: " + err.Error())
+	}
+	defer f.Close()
+
+	config := core.DefaultConfig()
+	dec := yaml.NewDecoder(f, yaml.Strict())
+	if err = dec.Decode(config); err != nil {
+		return nil, errors.New("Unable to parse configuration file: " + err.Error())
+	}
+	return config, nil
+}
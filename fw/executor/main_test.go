@@ -0,0 +1,46 @@
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+)
+
+// testForwarder is the forwarder of the tests, since a forwarder cannot be
+// restarted in the same program. Applications can connect to it on testSocket.
+var testForwarder *YaNFD
+var testSocket string
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "yanfd-test")
+	if err != nil {
+		panic(err)
+	}
+	testSocket = filepath.Join(dir, "nfd.sock")
+
+	config := core.DefaultConfig()
+	config.Core.LogLevel = "ERROR"
+	config.Core.ShutdownTimeout = 0
+	config.Faces.Udp.Enabled = false
+	config.Faces.Udp.Multicast = false
+	config.Faces.Tcp.Enabled = false
+	config.Faces.Unix.SocketPath = testSocket
+	config.Faces.WebSocket.Enabled = false
+	testForwarder = StartEmbedded(config)
+
+	// The Unix listener is created in the background
+	for i := 0; i < 500; i++ {
+		if _, err := os.Stat(testSocket); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	code := m.Run()
+	testForwarder.Stop()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
@@ -0,0 +1,235 @@
+package executor
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/face"
+	"github.com/named-data/ndnd/fw/fw"
+	"github.com/named-data/ndnd/fw/table"
+)
+
+// metricsContentType is the content type of the OpenMetrics text format.
+const metricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// MetricsServer exposes the forwarder counters over HTTP in the OpenMetrics
+// text format, so they can be scraped by Prometheus.
+type MetricsServer struct {
+	server http.Server
+	addr   string
+}
+
+// NewMetricsServer creates a metrics server listening on the given address.
+func NewMetricsServer(bind string, port uint16) *MetricsServer {
+	m := &MetricsServer{addr: net.JoinHostPort(bind, strconv.Itoa(int(port)))}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", m.handler)
+	m.server.Handler = mux
+	return m
+}
+
+func (m *MetricsServer) String() string {
+	return "MetricsServer, " + m.addr
+}
+
+// Run starts listening and serves requests in the background.
+func (m *MetricsServer) Run() error {
+	ln, err := net.Listen("tcp", m.addr)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		err := m.server.Serve(ln)
+		if !errors.Is(err, http.ErrServerClosed) {
+			core.LogError(m, "Unable to serve metrics: ", err)
+		}
+	}()
+	return nil
+}
+
+// Close stops the metrics server.
+func (m *MetricsServer) Close() {
+	m.server.Shutdown(context.TODO())
+}
+
+func (m *MetricsServer) handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", metricsContentType)
+	b := bufio.NewWriter(w)
+	writeMetrics(b)
+	b.Flush()
+}
+
+// writeMetrics writes all metric families to w.
+func writeMetrics(w *bufio.Writer) {
+	// General
+	family(w, "yanfd_build_info", "gauge", "Version of the forwarder")
+	sample(w, "yanfd_build_info", labels("version", core.Version), 1)
+	family(w, "yanfd_start_time_seconds", "gauge", "Time the forwarder was started")
+	sample(w, "yanfd_start_time_seconds", "", float64(core.StartTimestamp.UnixMilli())/1000)
+
+	// Forwarding threads
+	threadCounters := []struct {
+		name  string
+		help  string
+		value func(t *fw.Thread) uint64
+	}{
+		{"yanfd_fw_in_interests", "Interests received by the forwarding thread",
+			func(t *fw.Thread) uint64 { return t.NInInterests.Load() }},
+		{"yanfd_fw_in_data", "Data packets received by the forwarding thread",
+			func(t *fw.Thread) uint64 { return t.NInData.Load() }},
+		{"yanfd_fw_out_interests", "Interests sent by the forwarding thread",
+			func(t *fw.Thread) uint64 { return t.NOutInterests.Load() }},
+		{"yanfd_fw_out_data", "Data packets sent by the forwarding thread",
+			func(t *fw.Thread) uint64 { return t.NOutData.Load() }},
+		{"yanfd_fw_satisfied_interests", "Interests satisfied by the forwarding thread",
+			func(t *fw.Thread) uint64 { return t.NSatisfiedInterests.Load() }},
+		{"yanfd_fw_unsatisfied_interests", "Interests expired without being satisfied",
+			func(t *fw.Thread) uint64 { return t.NUnsatisfiedInterests.Load() }},
+	}
+	for _, c := range threadCounters {
+		family(w, c.name, "counter", c.help)
+		for _, t := range fw.Threads {
+			sample(w, c.name+"_total", threadLabels(t), float64(c.value(t)))
+		}
+	}
+
+	family(w, "yanfd_fw_queue_drops", "counter", "Packets dropped because the forwarding thread queue was full")
+	for _, t := range fw.Threads {
+		sample(w, "yanfd_fw_queue_drops_total", threadLabels(t, "type", "interest"), float64(t.NDroppedInterests.Load()))
+		sample(w, "yanfd_fw_queue_drops_total", threadLabels(t, "type", "data"), float64(t.NDroppedData.Load()))
+	}
+
+	family(w, "yanfd_fw_queue_drop_burst_packets", "histogram", "Packets dropped in a row because the forwarding thread queue was full")
+	for _, t := range fw.Threads {
+		histogram(w, "yanfd_fw_queue_drop_burst_packets", threadLabels(t, "type", "interest"), t.InterestDropBursts)
+		histogram(w, "yanfd_fw_queue_drop_burst_packets", threadLabels(t, "type", "data"), t.DataDropBursts)
+	}
+
+	family(w, "yanfd_pit_entries", "gauge", "Number of entries in the PIT")
+	for _, t := range fw.Threads {
+		sample(w, "yanfd_pit_entries", threadLabels(t), float64(t.GetNumPitEntries()))
+	}
+	family(w, "yanfd_cs_entries", "gauge", "Number of entries in the Content Store")
+	for _, t := range fw.Threads {
+		sample(w, "yanfd_cs_entries", threadLabels(t), float64(t.GetNumCsEntries()))
+	}
+
+	family(w, "yanfd_pit_entry_lifetime_seconds", "histogram", "Time between the creation and removal of PIT entries")
+	for _, t := range fw.Threads {
+		histogram(w, "yanfd_pit_entry_lifetime_seconds", threadLabels(t), t.PitLifetime)
+	}
+
+	// Tables
+	if table.FibStrategyTable != nil {
+		family(w, "yanfd_fib_entries", "gauge", "Number of entries in the FIB")
+		sample(w, "yanfd_fib_entries", "", float64(len(table.FibStrategyTable.GetAllFIBEntries())))
+	}
+	family(w, "yanfd_rib_entries", "gauge", "Number of entries in the RIB")
+	sample(w, "yanfd_rib_entries", "", float64(table.Rib.Len()))
+
+	nValid, nInvalid, nUnknownKey := table.Verifier.Counters()
+	family(w, "yanfd_verified_data", "counter", "Data packets whose signature was verified before caching")
+	sample(w, "yanfd_verified_data_total", labels("result", "valid"), float64(nValid))
+	sample(w, "yanfd_verified_data_total", labels("result", "invalid"), float64(nInvalid))
+	sample(w, "yanfd_verified_data_total", labels("result", "unknown-key"), float64(nUnknownKey))
+
+	// Faces
+	faces := face.FaceTable.GetAll()
+	faceCounters := []struct {
+		name  string
+		help  string
+		value func(f face.LinkService) uint64
+	}{
+		{"yanfd_face_in_interests", "Interests received on the face",
+			func(f face.LinkService) uint64 { return f.NInInterests() }},
+		{"yanfd_face_in_data", "Data packets received on the face",
+			func(f face.LinkService) uint64 { return f.NInData() }},
+		{"yanfd_face_in_bytes", "Bytes received on the face",
+			func(f face.LinkService) uint64 { return f.NInBytes() }},
+		{"yanfd_face_out_interests", "Interests sent on the face",
+			func(f face.LinkService) uint64 { return f.NOutInterests() }},
+		{"yanfd_face_out_data", "Data packets sent on the face",
+			func(f face.LinkService) uint64 { return f.NOutData() }},
+		{"yanfd_face_out_bytes", "Bytes sent on the face",
+			func(f face.LinkService) uint64 { return f.NOutBytes() }},
+	}
+	for _, c := range faceCounters {
+		family(w, c.name, "counter", c.help)
+		for _, f := range faces {
+			sample(w, c.name+"_total", faceLabels(f), float64(c.value(f)))
+		}
+	}
+
+	family(w, "yanfd_face_up", "gauge", "Whether the face is up")
+	for _, f := range faces {
+		up := 0.0
+		if f.State() == defn.Up {
+			up = 1
+		}
+		sample(w, "yanfd_face_up", faceLabels(f), up)
+	}
+
+	w.WriteString("# EOF\n")
+}
+
+func family(w *bufio.Writer, name string, kind string, help string) {
+	fmt.Fprintf(w, "# TYPE %s %s\n# HELP %s %s\n", name, kind, name, help)
+}
+
+func sample(w *bufio.Writer, name string, labels string, value float64) {
+	w.WriteString(name)
+	if labels != "" {
+		w.WriteString("{" + labels + "}")
+	}
+	w.WriteString(" " + strconv.FormatFloat(value, 'g', -1, 64) + "\n")
+}
+
+func histogram(w *bufio.Writer, name string, labels string, h *core.Histogram) {
+	buckets, sum, count := h.Snapshot()
+	prefix := labels
+	if prefix != "" {
+		prefix += ","
+	}
+	for i, bound := range h.Bounds() {
+		le := strconv.FormatFloat(bound, 'g', -1, 64)
+		sample(w, name+"_bucket", prefix+`le="`+le+`"`, float64(buckets[i]))
+	}
+	sample(w, name+"_bucket", prefix+`le="+Inf"`, float64(buckets[len(buckets)-1]))
+	sample(w, name+"_count", labels, float64(count))
+	sample(w, name+"_sum", labels, sum)
+}
+
+// labels formats label pairs, escaping the values.
+func labels(kv ...string) string {
+	var sb strings.Builder
+	for i := 0; i+1 < len(kv); i += 2 {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(kv[i] + `="` + labelEscaper.Replace(kv[i+1]) + `"`)
+	}
+	return sb.String()
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func threadLabels(t *fw.Thread, kv ...string) string {
+	return labels(append([]string{"thread", strconv.Itoa(t.GetID())}, kv...)...)
+}
+
+func faceLabels(f face.LinkService) string {
+	return labels(
+		"face", strconv.FormatUint(f.FaceID(), 10),
+		"remote_uri", f.RemoteURI().String(),
+		"local_uri", f.LocalURI().String(),
+	)
+}
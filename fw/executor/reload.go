@@ -0,0 +1,146 @@
+package executor
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/fw"
+	"github.com/named-data/ndnd/fw/table"
+	enc "github.com/named-data/ndnd/std/encoding"
+)
+
+// Reload re-reads the configuration file and applies the settings that are
+// safe to change while the forwarder is running. It returns the names of the
+// changed settings that only take effect after a restart.
+//
+// The configuration is applied by the management thread, which owns the RIB
+// and the management state, as for the config/reload command.
+func (y *YaNFD) Reload() (restart []string, err error) {
+	if !y.mgmt.Exec(func() { restart, err = y.reload() }) {
+		return nil, fmt.Errorf("forwarder is shutting down")
+	}
+	return restart, err
+}
+
+// reload implements Reload on the management thread.
+func (y *YaNFD) reload() ([]string, error) {
+	y.reloadMu.Lock()
+	defer y.reloadMu.Unlock()
+
+	if y.stopping {
+		return nil, fmt.Errorf("forwarder is shutting down")
+	}
+	if y.config.ConfigFile == "" {
+		return nil, fmt.Errorf("no configuration file to reload")
+	}
+
+	newConfig, err := ReadConfigFile(y.config.ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	for _, region := range newConfig.Tables.NetworkRegion.Regions {
+		if _, err := enc.NameFromStr(region); err != nil {
+			return nil, fmt.Errorf("invalid network region %s: %w", region, err)
+		}
+	}
+
+	oldConfig := core.GetConfig()
+	restart := restartRequired(oldConfig, newConfig)
+	core.LoadConfig(newConfig, y.config.BaseDir)
+	y.config.Config = newConfig
+
+	// Logging
+	core.SetLogLevel(newConfig.Core.LogLevel)
+
+	// Content Store and Network Region Table
+	table.Reconfigure()
+
+	// Packet tracing
+	fw.ConfigureTrace()
+
+	// Management over /localhop
+	y.mgmt.Reconfigure()
+
+	// Static faces and routes
+	y.static.Reload(newConfig)
+
+	// Listeners
+	if oldConfig.Faces.Tcp.Enabled != newConfig.Faces.Tcp.Enabled && !y.activated["tcp"] {
+		if newConfig.Faces.Tcp.Enabled {
+			if addrs, err := interfaceAddrs(); err != nil {
+				core.LogError("Main", "Unable to access network interfaces: ", err)
+			} else {
+				y.updateTCPListeners(addrs)
+			}
+		} else {
+			y.stopTCPListeners()
+		}
+	}
+	if oldConfig.Faces.Unix.Enabled != newConfig.Faces.Unix.Enabled && !y.activated["unix"] {
+		if newConfig.Faces.Unix.Enabled {
+			y.startUnixListener()
+		} else {
+			y.stopUnixListener()
+		}
+	}
+	if oldConfig.Faces.WebSocket != newConfig.Faces.WebSocket {
+		y.stopWebSocketListener()
+		if newConfig.Faces.WebSocket.Enabled {
+			y.startWebSocketListener()
+		}
+	}
+	if oldConfig.Faces.Quic != newConfig.Faces.Quic {
+		y.stopQUICListener()
+		if newConfig.Faces.Quic.Enabled {
+			y.startQUICListener()
+		}
+	}
+	if oldConfig.Metrics != newConfig.Metrics {
+		y.stopMetrics()
+		if newConfig.Metrics.Enabled {
+			y.startMetrics()
+		}
+	}
+
+	if len(restart) > 0 {
+		core.LogWarn("Main", "Configuration reloaded, restart required for changes to ", restart)
+	} else {
+		core.LogInfo("Main", "Configuration reloaded")
+	}
+	return restart, nil
+}
+
+// restartRequired returns the settings that differ between two configurations
+// and cannot be applied while the forwarder is running.
+func restartRequired(oldConfig *core.Config, newConfig *core.Config) []string {
+	// Tracing is applied on reload
+	oldFw, newFw := oldConfig.Fw, newConfig.Fw
+	oldFw.Trace = newFw.Trace
+
+	settings := []struct {
+		name     string
+		old, new any
+	}{
+		{"faces.queue_size", oldConfig.Faces.QueueSize, newConfig.Faces.QueueSize},
+		{"faces.congestion_marking", oldConfig.Faces.CongestionMarking, newConfig.Faces.CongestionMarking},
+		{"faces.lock_threads_to_cores", oldConfig.Faces.LockThreadsToCores, newConfig.Faces.LockThreadsToCores},
+		{"faces.udp", oldConfig.Faces.Udp, newConfig.Faces.Udp},
+		{"faces.tcp.port_unicast", oldConfig.Faces.Tcp.PortUnicast, newConfig.Faces.Tcp.PortUnicast},
+		{"faces.tcp.lifetime", oldConfig.Faces.Tcp.Lifetime, newConfig.Faces.Tcp.Lifetime},
+		{"faces.unix.socket_path", oldConfig.Faces.Unix.SocketPath, newConfig.Faces.Unix.SocketPath},
+		{"fw", oldFw, newFw},
+		{"tables.queue_size", oldConfig.Tables.QueueSize, newConfig.Tables.QueueSize},
+		{"tables.dead_nonce_list", oldConfig.Tables.DeadNonceList, newConfig.Tables.DeadNonceList},
+		{"tables.rib.readvertise_nlsr", oldConfig.Tables.Rib.ReadvertiseNlsr, newConfig.Tables.Rib.ReadvertiseNlsr},
+		{"tables.fib", oldConfig.Tables.Fib, newConfig.Tables.Fib},
+	}
+
+	restart := make([]string, 0)
+	for _, setting := range settings {
+		if !reflect.DeepEqual(setting.old, setting.new) {
+			restart = append(restart, setting.name)
+		}
+	}
+	return restart
+}
@@ -0,0 +1,97 @@
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestartRequired(t *testing.T) {
+	oldConfig := core.DefaultConfig()
+
+	tests := []struct {
+		name     string
+		change   func(c *core.Config)
+		expected []string
+	}{{
+		name:     "unchanged",
+		change:   func(c *core.Config) {},
+		expected: []string{},
+	}, {
+		name: "applied on reload",
+		change: func(c *core.Config) {
+			c.Core.LogLevel = "DEBUG"
+			c.Fw.Trace.SampleRate = 0.5
+			c.Tables.ContentStore.Capacity = 42
+			c.Mgmt.AllowLocalhop = !c.Mgmt.AllowLocalhop
+			c.Faces.Tcp.Enabled = !c.Faces.Tcp.Enabled
+		},
+		expected: []string{},
+	}, {
+		name: "restart required",
+		change: func(c *core.Config) {
+			c.Faces.QueueSize++
+			c.Faces.Udp.PortUnicast++
+			c.Fw.Threads++
+			c.Tables.Fib.Algorithm = "hashtable"
+		},
+		expected: []string{"faces.queue_size", "faces.udp", "fw", "tables.fib"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newConfig := core.DefaultConfig()
+			tt.change(newConfig)
+			assert.Equal(t, tt.expected, restartRequired(oldConfig, newConfig))
+		})
+	}
+}
+
+// writeTestConfig writes a configuration file for reload.
+func writeTestConfig(t *testing.T, path string, config *core.Config) {
+	out, err := yaml.Marshal(config)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, out, 0644))
+}
+
+func TestReload(t *testing.T) {
+	y := testForwarder
+	path := filepath.Join(t.TempDir(), "yanfd.yml")
+	y.config.ConfigFile = path
+	defer func() { y.config.ConfigFile = "" }()
+
+	// Missing or invalid file
+	_, err := y.Reload()
+	assert.Error(t, err)
+	require.NoError(t, os.WriteFile(path, []byte("core:\n  no_such_setting: 1\n"), 0644))
+	_, err = y.Reload()
+	assert.Error(t, err)
+
+	// Settings applied while running, and settings that need a restart
+	oldConfig := core.GetConfig()
+	writeTestConfig(t, path, oldConfig)
+	newConfig, err := ReadConfigFile(path)
+	require.NoError(t, err)
+	newConfig.Tables.ContentStore.Capacity = oldConfig.Tables.ContentStore.Capacity + 1
+	newConfig.Faces.QueueSize = oldConfig.Faces.QueueSize + 1
+	writeTestConfig(t, path, newConfig)
+
+	restart, err := y.Reload()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"faces.queue_size"}, restart)
+	assert.Equal(t, newConfig.Tables.ContentStore.Capacity, core.GetConfig().Tables.ContentStore.Capacity)
+	assert.Equal(t, int(newConfig.Tables.ContentStore.Capacity), table.CsCapacity())
+
+	// Back to the initial configuration
+	writeTestConfig(t, path, oldConfig)
+	restart, err = y.Reload()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"faces.queue_size"}, restart)
+	assert.Equal(t, int(oldConfig.Tables.ContentStore.Capacity), table.CsCapacity())
+}
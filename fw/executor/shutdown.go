@@ -0,0 +1,47 @@
+package executor
+
+import (
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/face"
+	"github.com/named-data/ndnd/fw/fw"
+	"github.com/named-data/ndnd/fw/table"
+)
+
+// drainCheckInterval is how often the forwarder checks whether it has drained when shutting down.
+const drainCheckInterval = 50 * time.Millisecond
+
+// drain lets the forwarder finish its work before shutting down. Routes are withdrawn
+// from the readvertisers (e.g., the routing daemon), and pending Interests and queued
+// packets are given time until the deadline.
+func (y *YaNFD) drain(timeout time.Duration) {
+	core.LogInfo("Main", "Draining for up to ", timeout)
+	deadline := time.Now().Add(timeout)
+
+	// Refuse new faces and Interests
+	core.Draining.Store(true)
+
+	// The RIB belongs to the management thread
+	y.mgmt.Exec(table.WithdrawAllReadvertised)
+
+	var pending, queued int
+	for {
+		pending = 0
+		for _, thread := range fw.Threads {
+			pending += thread.GetNumPitEntries()
+		}
+		queued = face.NumQueuedPackets()
+
+		if pending == 0 && queued == 0 {
+			core.LogInfo("Main", "Drained pending Interests and queued packets")
+			return
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(drainCheckInterval)
+	}
+
+	core.LogWarn("Main", "Shutting down with ", pending, " pending Interests and ", queued, " queued packets")
+}
@@ -0,0 +1,96 @@
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/fw"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/engine"
+	"github.com/named-data/ndnd/std/ndn"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	sec "github.com/named-data/ndnd/std/security"
+	"github.com/named-data/ndnd/std/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// numPitEntries returns the number of PIT entries in all forwarding threads.
+func numPitEntries() int {
+	n := 0
+	for _, thread := range fw.Threads {
+		n += thread.GetNumPitEntries()
+	}
+	return n
+}
+
+func TestDrain(t *testing.T) {
+	y := testForwarder
+	defer core.Draining.Store(false)
+
+	// Producer answering only some Interests
+	producer := engine.NewBasicEngine(engine.NewUnixFace(testSocket))
+	require.NoError(t, producer.Start())
+	defer producer.Stop()
+	prefix, err := enc.NameFromStr("/drain")
+	require.NoError(t, err)
+	require.NoError(t, producer.AttachHandler(prefix, func(args ndn.InterestHandlerArgs) {
+		if args.Interest.Name()[1].String() != "answered" {
+			return
+		}
+		data, err := producer.Spec().MakeData(args.Interest.Name(), &ndn.DataConfig{},
+			enc.Wire{[]byte("drained")}, sec.NewSha256Signer())
+		if err == nil {
+			args.Reply(data.Wire)
+		}
+	}))
+	require.NoError(t, producer.RegisterRoute(prefix))
+
+	consumer := engine.NewBasicEngine(engine.NewUnixFace(testSocket))
+	require.NoError(t, consumer.Start())
+	defer consumer.Stop()
+	express := func(name string, lifetime time.Duration) <-chan ndn.InterestResult {
+		n, err := enc.NameFromStr(name)
+		require.NoError(t, err)
+		interest, err := consumer.Spec().MakeInterest(n, &ndn.InterestConfig{
+			Lifetime: utils.IdPtr(lifetime),
+			Nonce:    utils.ConvertNonce(consumer.Timer().Nonce()),
+		}, nil, nil)
+		require.NoError(t, err)
+		result := make(chan ndn.InterestResult, 1)
+		require.NoError(t, consumer.Express(interest, func(args ndn.ExpressCallbackArgs) {
+			result <- args.Result
+		}))
+		return result
+	}
+	require.Equal(t, ndn.InterestResultData, <-express("/drain/answered/1", time.Second))
+
+	// Content Store hits do not leave PIT entries behind
+	require.Eventually(t, func() bool { return numPitEntries() == 0 }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, ndn.InterestResultData, <-express("/drain/answered/1", time.Second))
+	require.Eventually(t, func() bool { return numPitEntries() == 0 }, 5*time.Second, 10*time.Millisecond)
+
+	// The deadline is reached while an Interest is pending
+	pending := express("/drain/pending", time.Second)
+	require.Eventually(t, func() bool { return numPitEntries() > 0 }, 5*time.Second, 10*time.Millisecond)
+	start := time.Now()
+	y.drain(200 * time.Millisecond)
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+	assert.True(t, core.Draining.Load())
+
+	// New Interests and faces are refused, while management remains reachable
+	assert.Equal(t, ndn.InterestResultTimeout, <-express("/drain/answered/2", 300*time.Millisecond))
+	err = consumer.ExecMgmtCmd("faces", "create", &mgmt.ControlArgs{
+		Uri: utils.IdPtr("udp4://127.0.0.1:56366"),
+	})
+	assert.Error(t, err)
+
+	// Drains immediately once nothing is pending
+	core.Draining.Store(false)
+	assert.Equal(t, ndn.InterestResultTimeout, <-pending)
+	require.Eventually(t, func() bool { return numPitEntries() == 0 }, 5*time.Second, 10*time.Millisecond)
+	start = time.Now()
+	y.drain(5 * time.Second)
+	assert.Less(t, time.Since(start), time.Second)
+}
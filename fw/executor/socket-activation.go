@@ -0,0 +1,109 @@
+package executor
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/face"
+)
+
+// listenFDsStart is the first file descriptor passed by systemd socket activation.
+// It is only changed by tests, whose low descriptors are used by the runtime.
+var listenFDsStart = 3
+
+// activatedFiles returns the sockets passed by the init system with systemd socket
+// activation (see sd_listen_fds), and unsets the environment variables describing them.
+func activatedFiles() []*os.File {
+	defer func() {
+		os.Unsetenv("LISTEN_PID")
+		os.Unsetenv("LISTEN_FDS")
+		os.Unsetenv("LISTEN_FDNAMES")
+	}()
+
+	if pid, err := strconv.Atoi(os.Getenv("LISTEN_PID")); err != nil || pid != os.Getpid() {
+		return nil
+	}
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || count <= 0 {
+		return nil
+	}
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+
+	files := make([]*os.File, 0, count)
+	for i := 0; i < count; i++ {
+		name := "LISTEN_FD_" + strconv.Itoa(listenFDsStart+i)
+		if i < len(names) && names[i] != "" {
+			name = names[i]
+		}
+		files = append(files, os.NewFile(uintptr(listenFDsStart+i), name))
+	}
+	return files
+}
+
+// startActivatedListeners creates listeners on the sockets passed by socket activation.
+// Listeners of a kind (unix, tcp or udp) replace the listeners created from the configuration.
+// It returns the number of listeners created.
+func (y *YaNFD) startActivatedListeners() int {
+	count := 0
+	for _, file := range activatedFiles() {
+		if ln, err := net.FileListener(file); err == nil {
+			switch ln.Addr().Network() {
+			case "unix":
+				listener, err := face.MakeUnixStreamListenerFromConn(ln)
+				if err != nil {
+					core.LogError("Main", "Unable to create Unix stream listener on ", file.Name(), ": ", err)
+					ln.Close()
+					break
+				}
+				y.unixListener = listener
+				y.activated["unix"] = true
+				go listener.Run()
+				count++
+				core.LogInfo("Main", "Created Unix stream listener on activated socket ", file.Name())
+			case "tcp":
+				listener, err := face.MakeTCPListenerFromConn(ln)
+				if err != nil {
+					core.LogError("Main", "Unable to create TCP listener on ", file.Name(), ": ", err)
+					ln.Close()
+					break
+				}
+				y.activatedListeners = append(y.activatedListeners, listener)
+				y.activated["tcp"] = true
+				go listener.Run()
+				count++
+				core.LogInfo("Main", "Created TCP listener on activated socket ", file.Name())
+			default:
+				core.LogWarn("Main", "Ignoring activated socket ", file.Name(), " of network ", ln.Addr().Network())
+				ln.Close()
+			}
+		} else if conn, err := net.FilePacketConn(file); err == nil {
+			if _, ok := conn.(*net.UDPConn); !ok {
+				core.LogWarn("Main", "Ignoring activated socket ", file.Name(), " of network ", conn.LocalAddr().Network())
+				conn.Close()
+				file.Close()
+				continue
+			}
+			listener, err := face.MakeUDPListenerFromConn(conn)
+			if err != nil {
+				core.LogError("Main", "Unable to create UDP listener on ", file.Name(), ": ", err)
+				conn.Close()
+				file.Close()
+				continue
+			}
+			y.activatedListeners = append(y.activatedListeners, listener)
+			y.activated["udp"] = true
+			go listener.Run()
+			count++
+			core.LogInfo("Main", "Created UDP listener on activated socket ", file.Name())
+		} else {
+			core.LogWarn("Main", "Ignoring activated socket ", file.Name(), ": ", err)
+		}
+
+		// The listeners use duplicates of the descriptors
+		file.Close()
+	}
+	return count
+}
@@ -0,0 +1,179 @@
+//go:build unix
+
+package executor
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/face"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+// activationTestFD is the first descriptor passed in tests, above those of the test process.
+const activationTestFD = 200
+
+// setListenFDs passes files to the test process as systemd socket activation does.
+func setListenFDs(t *testing.T, pid int, names string, files ...*os.File) {
+	prevStart := listenFDsStart
+	listenFDsStart = activationTestFD
+	t.Cleanup(func() { listenFDsStart = prevStart })
+
+	for i, file := range files {
+		require.NoError(t, unix.Dup2(int(file.Fd()), activationTestFD+i))
+		file.Close()
+	}
+	t.Setenv("LISTEN_PID", strconv.Itoa(pid))
+	t.Setenv("LISTEN_FDS", strconv.Itoa(len(files)))
+	t.Setenv("LISTEN_FDNAMES", names)
+}
+
+func TestActivatedFiles(t *testing.T) {
+	unset := func() bool {
+		_, pid := os.LookupEnv("LISTEN_PID")
+		_, fds := os.LookupEnv("LISTEN_FDS")
+		_, names := os.LookupEnv("LISTEN_FDNAMES")
+		return !pid && !fds && !names
+	}
+
+	// Sockets passed to another process are ignored
+	setListenFDs(t, os.Getpid()+1, "")
+	t.Setenv("LISTEN_FDS", "2")
+	assert.Empty(t, activatedFiles())
+	assert.True(t, unset())
+
+	setListenFDs(t, os.Getpid(), "")
+	t.Setenv("LISTEN_FDS", "none")
+	assert.Empty(t, activatedFiles())
+	assert.True(t, unset())
+
+	// Files are named after LISTEN_FDNAMES, or their descriptor
+	setListenFDs(t, os.Getpid(), "nfd.socket")
+	t.Setenv("LISTEN_FDS", "2")
+	files := activatedFiles()
+	require.Len(t, files, 2)
+	assert.Equal(t, "nfd.socket", files[0].Name())
+	assert.Equal(t, uintptr(activationTestFD), files[0].Fd())
+	assert.Equal(t, "LISTEN_FD_"+strconv.Itoa(activationTestFD+1), files[1].Name())
+	assert.True(t, unset())
+	for _, file := range files {
+		file.Close()
+	}
+}
+
+func TestActivatedListeners(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nfd.sock")
+	unixListener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	unixListener.(*net.UnixListener).SetUnlinkOnClose(false)
+	unixFile, err := unixListener.(*net.UnixListener).File()
+	require.NoError(t, err)
+	unixListener.Close()
+
+	tcpListener, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	tcpAddr := tcpListener.Addr().String()
+	tcpFile, err := tcpListener.(*net.TCPListener).File()
+	require.NoError(t, err)
+	tcpListener.Close()
+
+	udpConn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	udpFile, err := udpConn.File()
+	require.NoError(t, err)
+	udpConn.Close()
+
+	// Other files are ignored
+	other, err := os.CreateTemp(t.TempDir(), "other")
+	require.NoError(t, err)
+
+	setListenFDs(t, os.Getpid(), "unix:tcp:udp:other", unixFile, tcpFile, udpFile, other)
+	y := &YaNFD{activated: make(map[string]bool)}
+	assert.Equal(t, 3, y.startActivatedListeners())
+	assert.Equal(t, map[string]bool{"unix": true, "tcp": true, "udp": true}, y.activated)
+	require.NotNil(t, y.unixListener)
+	require.Len(t, y.activatedListeners, 2)
+	defer func() {
+		y.stopUnixListener()
+		for _, listener := range y.activatedListeners {
+			listener.Close()
+		}
+	}()
+
+	// Faces are created from connections to the activated sockets
+	faceTo := func(localURI string) face.LinkService {
+		for _, linkService := range face.FaceTable.GetAll() {
+			if linkService.LocalURI().String() == localURI {
+				return linkService
+			}
+		}
+		return nil
+	}
+	unixClient, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	defer unixClient.Close()
+	tcpClient, err := net.Dial("tcp4", tcpAddr)
+	require.NoError(t, err)
+	defer tcpClient.Close()
+	require.Eventually(t, func() bool {
+		return faceTo("unix://"+path) != nil && faceTo("tcp4://"+tcpAddr) != nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestStaticFDFace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.sock")
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer listener.Close()
+	client, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	defer client.Close()
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	file, err := conn.(*net.UnixConn).File()
+	require.NoError(t, err)
+	conn.Close()
+
+	// The supervisor passes a descriptor that the forwarder owns
+	fd, err := unix.Dup(int(file.Fd()))
+	require.NoError(t, err)
+	file.Close()
+	uri := "fd://" + strconv.Itoa(fd)
+
+	s, posted := newTestStaticConfig(staticTestConfig([]core.StaticFaceConfig{
+		{Uri: uri},
+	}, []core.StaticRouteConfig{
+		{Prefix: "/static/fd", Face: uri},
+	}))
+	require.Len(t, s.faces, 1)
+	require.Len(t, s.routes, 1)
+
+	// The face is found by its configured URI, although its remote URI differs
+	s.check()
+	faceID := s.faces[0].faceID
+	require.NotZero(t, faceID)
+	created := face.FaceTable.Get(faceID)
+	require.NotNil(t, created)
+	assert.Equal(t, "unix://"+path, created.LocalURI().String())
+	assert.Equal(t, defn.Local, created.Scope())
+	assert.Equal(t, face.PersistencyPersistent, created.Persistency())
+	assert.Equal(t, faceID, s.routes[0].faceID)
+	assert.Equal(t, 1, *posted)
+
+	// The descriptor cannot be used again once the face is closed
+	created.Close()
+	require.Eventually(t, func() bool { return face.FaceTable.Get(faceID) == nil },
+		5*time.Second, 10*time.Millisecond)
+	s.check()
+	assert.Equal(t, faceID, s.faces[0].faceID)
+	assert.True(t, s.faces[0].fdUsed)
+	assert.Equal(t, 1, *posted)
+}
@@ -0,0 +1,366 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/face"
+	"github.com/named-data/ndnd/fw/table"
+	enc "github.com/named-data/ndnd/std/encoding"
+)
+
+// staticCheckInterval is the interval at which static faces are checked.
+const staticCheckInterval = 5 * time.Second
+
+// StaticConfig creates the faces and routes declared in the configuration file.
+// Permanent static faces that are removed from the face table (e.g. after a
+// socket error) are created again, together with their routes.
+type StaticConfig struct {
+	faces  []*staticFace
+	routes []*staticRoute
+	stop   chan bool
+	mutex  sync.Mutex
+	// post runs a RIB change on the management thread, which owns the RIB
+	post func(task func())
+}
+
+type staticFace struct {
+	cfg         core.StaticFaceConfig
+	uri         *defn.URI
+	persistency face.Persistency
+	faceID      uint64
+	fdUsed      bool // sockets passed as file descriptors can be used only once
+}
+
+type staticRoute struct {
+	cfg    core.StaticRouteConfig
+	name   enc.Name
+	uri    *defn.URI
+	flags  uint64
+	faceID uint64
+}
+
+// NewStaticConfig parses the static faces and routes in the configuration.
+// Invalid entries are logged and skipped. Changes to the RIB are passed to post,
+// which runs them on the management thread.
+func NewStaticConfig(config *core.Config, post func(task func())) *StaticConfig {
+	s := &StaticConfig{stop: make(chan bool, 1), post: post}
+	s.faces, s.routes = s.parse(config)
+	return s
+}
+
+func (s *StaticConfig) parse(config *core.Config) ([]*staticFace, []*staticRoute) {
+	faces := make([]*staticFace, 0, len(config.Faces.Static))
+	for _, cfg := range config.Faces.Static {
+		f, err := parseStaticFace(cfg)
+		if err != nil {
+			core.LogError(s, "Invalid static face ", cfg.Uri, ": ", err)
+			continue
+		}
+		faces = append(faces, f)
+	}
+
+	routes := make([]*staticRoute, 0, len(config.Tables.Rib.StaticRoutes))
+	for _, cfg := range config.Tables.Rib.StaticRoutes {
+		r, err := parseStaticRoute(cfg)
+		if err != nil {
+			core.LogError(s, "Invalid static route ", cfg.Prefix, ": ", err)
+			continue
+		}
+		routes = append(routes, r)
+	}
+
+	return faces, routes
+}
+
+func (s *StaticConfig) String() string {
+	return "StaticConfig"
+}
+
+func parseStaticFace(cfg core.StaticFaceConfig) (*staticFace, error) {
+	uri := defn.DecodeURIString(cfg.Uri)
+	if uri == nil || uri.Canonize() != nil || !uri.IsCanonical() {
+		return nil, core.ErrNotCanonical
+	}
+
+	switch uri.Scheme() {
+	case "udp4", "udp6", "tcp4", "tcp6", "ws", "wss", "quic", "fd":
+	default:
+		return nil, fmt.Errorf("unsupported scheme %s", uri.Scheme())
+	}
+
+	f := &staticFace{cfg: cfg, uri: uri}
+	switch strings.ToLower(cfg.Persistency) {
+	case "":
+		f.persistency = face.PersistencyPermanent
+		if uri.Scheme() == "fd" {
+			// Sockets passed by a supervisor cannot be opened again
+			f.persistency = face.PersistencyPersistent
+		}
+	case "permanent":
+		if uri.Scheme() == "fd" {
+			return nil, errors.New("faces on file descriptors cannot be permanent")
+		}
+		f.persistency = face.PersistencyPermanent
+	case "persistent":
+		f.persistency = face.PersistencyPersistent
+	default:
+		return nil, fmt.Errorf("unacceptable persistency %s", cfg.Persistency)
+	}
+
+	if cfg.Mtu < 0 {
+		return nil, errors.New("MTU must not be negative")
+	}
+
+	return f, nil
+}
+
+func parseStaticRoute(cfg core.StaticRouteConfig) (*staticRoute, error) {
+	name, err := enc.NameFromStr(cfg.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := defn.DecodeURIString(cfg.Face)
+	if uri == nil || uri.Canonize() != nil {
+		return nil, core.ErrNotCanonical
+	}
+
+	r := &staticRoute{cfg: cfg, name: name, uri: uri}
+	if !cfg.NoInherit {
+		r.flags |= table.RouteFlagChildInherit
+	}
+	if cfg.Capture {
+		r.flags |= table.RouteFlagCapture
+	}
+	return r, nil
+}
+
+// Start creates all static faces and routes, and starts watching permanent faces.
+func (s *StaticConfig) Start() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, f := range s.faces {
+		s.createFace(f)
+	}
+	for _, r := range s.routes {
+		s.addRoute(r)
+	}
+	go s.run()
+}
+
+// Stop stops watching static faces. Faces and routes are not removed.
+func (s *StaticConfig) Stop() {
+	s.stop <- true
+}
+
+// Reload replaces the static faces and routes with those in the new configuration.
+// Routes that are no longer configured are removed. Faces that are no longer
+// configured are kept, but are not created again if they go down.
+func (s *StaticConfig) Reload(config *core.Config) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	faces, routes := s.parse(config)
+
+	// Keep the state of faces that are still configured
+	oldFaces := make(map[string]*staticFace, len(s.faces))
+	for _, f := range s.faces {
+		oldFaces[f.uri.String()] = f
+	}
+	for _, f := range faces {
+		if old, ok := oldFaces[f.uri.String()]; ok {
+			f.faceID = old.faceID
+			f.fdUsed = old.fdUsed
+		} else {
+			s.createFace(f)
+		}
+	}
+
+	// Remove routes that are no longer configured
+	newRoutes := make(map[string]bool, len(routes))
+	for _, r := range routes {
+		newRoutes[r.key()] = true
+	}
+	oldRoutes := make(map[string]*staticRoute, len(s.routes))
+	for _, r := range s.routes {
+		oldRoutes[r.key()] = r
+		if !newRoutes[r.key()] && r.faceID != 0 {
+			name, faceID := r.name, r.faceID
+			s.post(func() {
+				table.Rib.RemoveRouteEnc(name, faceID, table.RouteOriginStatic)
+				core.LogInfo(s, "Removed static route for Prefix=", name, ", FaceID=", faceID)
+			})
+		}
+	}
+
+	// Add new routes and update changed ones
+	for _, r := range routes {
+		old, ok := oldRoutes[r.key()]
+		if ok && old.faceID != 0 && old.cfg.Cost == r.cfg.Cost && old.flags == r.flags {
+			r.faceID = old.faceID
+			continue
+		}
+		s.addRoute(r)
+	}
+
+	s.faces, s.routes = faces, routes
+}
+
+func (s *StaticConfig) run() {
+	ticker := time.NewTicker(staticCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.check()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// check re-creates permanent faces that are gone and the routes through them.
+func (s *StaticConfig) check() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, f := range s.faces {
+		if f.faceID != 0 && face.FaceTable.Get(f.faceID) != nil {
+			continue
+		}
+		if f.faceID != 0 && f.persistency != face.PersistencyPermanent || f.fdUsed {
+			continue
+		}
+		s.createFace(f)
+	}
+
+	// Add routes whose nexthop face was (re-)created
+	for _, r := range s.routes {
+		if r.faceID != 0 && face.FaceTable.Get(r.faceID) != nil {
+			continue
+		}
+		if s.faceByURI(r.uri) != nil {
+			s.addRoute(r)
+		}
+	}
+}
+
+func (s *StaticConfig) createFace(f *staticFace) bool {
+	if existing := face.FaceTable.GetByURI(f.uri); existing != nil {
+		f.faceID = existing.FaceID()
+		return true
+	}
+
+	options := face.MakeNDNLPLinkServiceOptions()
+	options.IsConsumerControlledForwardingEnabled = f.cfg.LocalFields
+	options.IsIncomingFaceIndicationEnabled = f.cfg.LocalFields
+	options.IsLocalCachePolicyEnabled = f.cfg.LocalFields
+	options.IsCongestionMarkingEnabled = f.cfg.CongestionMarking
+
+	mtu := defn.MaxNDNPacketSize
+	if f.cfg.Mtu > 0 {
+		mtu = min(f.cfg.Mtu, defn.MaxNDNPacketSize)
+	}
+
+	var linkService *face.NDNLPLinkService
+	switch f.uri.Scheme() {
+	case "udp4", "udp6":
+		transport, err := face.MakeUnicastUDPTransport(f.uri, nil, f.persistency)
+		if err != nil {
+			core.LogWarn(s, "Unable to create static face ", f.uri, ": ", err)
+			return false
+		}
+		transport.SetMTU(mtu)
+		linkService = face.MakeNDNLPLinkService(transport, options)
+	case "tcp4", "tcp6":
+		transport, err := face.MakeUnicastTCPTransport(f.uri, nil, f.persistency)
+		if err != nil {
+			core.LogWarn(s, "Unable to create static face ", f.uri, ": ", err)
+			return false
+		}
+		transport.SetMTU(mtu)
+		options.IsFragmentationEnabled = false // reliable stream
+		linkService = face.MakeNDNLPLinkService(transport, options)
+	case "ws", "wss":
+		transport, err := face.MakeWebSocketTransport(f.uri, f.persistency)
+		if err != nil {
+			core.LogWarn(s, "Unable to create static face ", f.uri, ": ", err)
+			return false
+		}
+		transport.SetMTU(mtu)
+		options.IsFragmentationEnabled = false // message-oriented
+		linkService = face.MakeNDNLPLinkService(transport, options)
+	case "quic":
+		transport, err := face.MakeQUICTransport(f.uri, f.persistency)
+		if err != nil {
+			core.LogWarn(s, "Unable to create static face ", f.uri, ": ", err)
+			return false
+		}
+		transport.SetMTU(mtu)
+		linkService = face.MakeNDNLPLinkService(transport, options)
+	case "fd":
+		f.fdUsed = true
+		transport, err := face.MakeFDTransport(f.uri)
+		if err != nil {
+			core.LogWarn(s, "Unable to create static face ", f.uri, ": ", err)
+			return false
+		}
+		transport.SetMTU(mtu)
+		options.IsFragmentationEnabled = false // reliable stream
+		linkService = face.MakeNDNLPLinkService(transport, options)
+	}
+
+	linkService.Run(nil)
+	f.faceID = linkService.FaceID()
+
+	core.LogInfo(s, "Created static face FaceID=", f.faceID, " with URI ", f.uri,
+		", Persistency=", f.persistency)
+	return true
+}
+
+func (r *staticRoute) key() string {
+	return r.name.String() + " " + r.uri.String()
+}
+
+// faceByURI returns the face with a remote URI, or the static face declared with this URI,
+// whose remote URI may differ (e.g., a face on a socket passed as a file descriptor).
+func (s *StaticConfig) faceByURI(uri *defn.URI) face.LinkService {
+	for _, f := range s.faces {
+		if f.faceID != 0 && f.uri.String() == uri.String() {
+			if existing := face.FaceTable.Get(f.faceID); existing != nil {
+				return existing
+			}
+		}
+	}
+	return face.FaceTable.GetByURI(uri)
+}
+
+func (s *StaticConfig) addRoute(r *staticRoute) {
+	nexthop := s.faceByURI(r.uri)
+	if nexthop == nil {
+		core.LogWarn(s, "Unable to add static route ", r.name, ": no face with URI ", r.uri)
+		r.faceID = 0
+		return
+	}
+	r.faceID = nexthop.FaceID()
+
+	name, route := r.name, &table.Route{
+		FaceID: r.faceID,
+		Origin: table.RouteOriginStatic,
+		Cost:   r.cfg.Cost,
+		Flags:  r.flags,
+	}
+	s.post(func() {
+		table.Rib.AddEncRoute(name, route)
+		core.LogInfo(s, "Created static route for Prefix=", name, ", FaceID=", route.FaceID,
+			", Cost=", route.Cost, ", Flags=", route.Flags)
+	})
+}
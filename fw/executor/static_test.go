@@ -0,0 +1,150 @@
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/face"
+	"github.com/named-data/ndnd/fw/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticTestConfig returns a configuration with the given static faces and routes.
+func staticTestConfig(faces []core.StaticFaceConfig, routes []core.StaticRouteConfig) *core.Config {
+	config := core.DefaultConfig()
+	config.Faces.Static = faces
+	config.Tables.Rib.StaticRoutes = routes
+	return config
+}
+
+// newTestStaticConfig creates a StaticConfig whose RIB changes are counted instead of
+// being run, since the RIB belongs to the management thread.
+func newTestStaticConfig(config *core.Config) (*StaticConfig, *int) {
+	posted := new(int)
+	return NewStaticConfig(config, func(func()) { *posted++ }), posted
+}
+
+func TestParseStaticConfig(t *testing.T) {
+	s, _ := newTestStaticConfig(staticTestConfig([]core.StaticFaceConfig{
+		{Uri: "udp4://127.0.0.1:6363"},
+		{Uri: "tcp4://127.0.0.1:6363", Persistency: "persistent", Mtu: 1400},
+		{Uri: "fd://3"},
+		{Uri: "fd://4", Persistency: "permanent"}, // cannot be reopened
+		{Uri: "unix:///run/nfd.sock"},             // unsupported scheme
+		{Uri: "udp4://127.0.0.1:6363", Mtu: -1},   // negative MTU
+		{Uri: "udp4://127.0.0.1:6363", Persistency: "on-demand"},
+		{Uri: "not a uri"},
+	}, []core.StaticRouteConfig{
+		{Prefix: "/a", Face: "udp4://127.0.0.1:6363", Cost: 10},
+		{Prefix: "/b", Face: "tcp4://127.0.0.1:6363", NoInherit: true, Capture: true},
+		{Prefix: "/c", Face: "not a uri"},
+	}))
+
+	require.Len(t, s.faces, 3)
+	assert.Equal(t, "udp4://127.0.0.1:6363", s.faces[0].uri.String())
+	assert.Equal(t, face.PersistencyPermanent, s.faces[0].persistency)
+	assert.Equal(t, face.PersistencyPersistent, s.faces[1].persistency)
+	assert.Equal(t, 1400, s.faces[1].cfg.Mtu)
+	assert.Equal(t, "fd", s.faces[2].uri.Scheme())
+	assert.Equal(t, face.PersistencyPersistent, s.faces[2].persistency)
+
+	require.Len(t, s.routes, 2)
+	assert.Equal(t, "/a", s.routes[0].name.String())
+	assert.Equal(t, table.RouteFlagChildInherit, s.routes[0].flags)
+	assert.Equal(t, uint64(10), s.routes[0].cfg.Cost)
+	assert.Equal(t, "/b", s.routes[1].name.String())
+	assert.Equal(t, table.RouteFlagCapture, s.routes[1].flags)
+}
+
+func TestStaticFaceRecreated(t *testing.T) {
+	s, posted := newTestStaticConfig(staticTestConfig([]core.StaticFaceConfig{
+		{Uri: "udp4://127.0.0.1:56363"},
+	}, []core.StaticRouteConfig{
+		{Prefix: "/static/recreated", Face: "udp4://127.0.0.1:56363"},
+	}))
+	require.Len(t, s.faces, 1)
+	require.Len(t, s.routes, 1)
+
+	// Creates the face, then the route through it
+	s.check()
+	faceID := s.faces[0].faceID
+	require.NotZero(t, faceID)
+	created := face.FaceTable.Get(faceID)
+	require.NotNil(t, created)
+	assert.Equal(t, face.PersistencyPermanent, created.Persistency())
+	assert.Equal(t, faceID, s.routes[0].faceID)
+	assert.Equal(t, 1, *posted)
+
+	// Nothing to do while the face is up
+	s.check()
+	assert.Equal(t, faceID, s.faces[0].faceID)
+	assert.Equal(t, 1, *posted)
+
+	// The face and its route are created again once it is gone
+	created.Close()
+	require.Eventually(t, func() bool { return face.FaceTable.Get(faceID) == nil },
+		5*time.Second, 10*time.Millisecond)
+	s.check()
+	newFaceID := s.faces[0].faceID
+	require.NotZero(t, newFaceID)
+	assert.NotEqual(t, faceID, newFaceID)
+	assert.NotNil(t, face.FaceTable.Get(newFaceID))
+	assert.Equal(t, newFaceID, s.routes[0].faceID)
+	assert.Equal(t, 2, *posted)
+
+	face.FaceTable.Get(newFaceID).Close()
+}
+
+func TestStaticReload(t *testing.T) {
+	faceA := core.StaticFaceConfig{Uri: "udp4://127.0.0.1:56364"}
+	faceB := core.StaticFaceConfig{Uri: "udp4://127.0.0.1:56365"}
+	s, posted := newTestStaticConfig(staticTestConfig([]core.StaticFaceConfig{faceA}, []core.StaticRouteConfig{
+		{Prefix: "/static/a", Face: faceA.Uri},
+		{Prefix: "/static/b", Face: faceA.Uri},
+	}))
+	s.check()
+	faceIDA := s.faces[0].faceID
+	require.NotZero(t, faceIDA)
+	defer func() { face.FaceTable.Get(faceIDA).Close() }()
+	assert.Equal(t, 2, *posted)
+
+	// New face and route, changed route, and unchanged route
+	*posted = 0
+	s.Reload(staticTestConfig([]core.StaticFaceConfig{faceA, faceB}, []core.StaticRouteConfig{
+		{Prefix: "/static/a", Face: faceA.Uri},
+		{Prefix: "/static/b", Face: faceA.Uri, Cost: 5},
+		{Prefix: "/static/c", Face: faceB.Uri},
+	}))
+	require.Len(t, s.faces, 2)
+	assert.Equal(t, faceIDA, s.faces[0].faceID)
+	faceIDB := s.faces[1].faceID
+	require.NotZero(t, faceIDB)
+	assert.NotEqual(t, faceIDA, faceIDB)
+	require.Len(t, s.routes, 3)
+	assert.Equal(t, faceIDA, s.routes[0].faceID)
+	assert.Equal(t, faceIDA, s.routes[1].faceID)
+	assert.Equal(t, faceIDB, s.routes[2].faceID)
+	assert.Equal(t, 2, *posted)
+
+	// Removed routes are withdrawn, removed faces are kept but no longer watched
+	*posted = 0
+	s.Reload(staticTestConfig([]core.StaticFaceConfig{faceA}, []core.StaticRouteConfig{
+		{Prefix: "/static/a", Face: faceA.Uri},
+	}))
+	require.Len(t, s.faces, 1)
+	require.Len(t, s.routes, 1)
+	assert.Equal(t, 2, *posted)
+	removedFace := face.FaceTable.Get(faceIDB)
+	require.NotNil(t, removedFace)
+
+	removedFace.Close()
+	require.Eventually(t, func() bool { return face.FaceTable.Get(faceIDB) == nil },
+		5*time.Second, 10*time.Millisecond)
+	*posted = 0
+	s.check()
+	assert.Nil(t, face.FaceTable.GetByURI(defn.DecodeURIString(faceB.Uri)))
+	assert.Zero(t, *posted)
+}
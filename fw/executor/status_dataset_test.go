@@ -0,0 +1,106 @@
+package executor
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/fw"
+	"github.com/named-data/ndnd/fw/table"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/engine"
+	"github.com/named-data/ndnd/std/ndn"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/object"
+	"github.com/named-data/ndnd/std/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expressForTest expresses an Interest and returns the Data received for it.
+func expressForTest(t *testing.T, e ndn.Engine, name enc.Name, canBePrefix bool) ndn.Data {
+	interest, err := e.Spec().MakeInterest(name, &ndn.InterestConfig{
+		CanBePrefix: canBePrefix,
+		MustBeFresh: true,
+		Lifetime:    utils.IdPtr(time.Second),
+		Nonce:       utils.ConvertNonce(e.Timer().Nonce()),
+	}, nil, nil)
+	require.NoError(t, err)
+
+	result := make(chan ndn.ExpressCallbackArgs, 1)
+	require.NoError(t, e.Express(interest, func(args ndn.ExpressCallbackArgs) {
+		result <- args
+	}))
+	args := <-result
+	require.Equal(t, ndn.InterestResultData, args.Result, "no Data for %s", name)
+	return args.Data
+}
+
+func TestEmbeddedStatusDataset(t *testing.T) {
+	y := testForwarder
+
+	// Enough strategy choices for a dataset of several segments
+	strategy, err := enc.NameFromStr(fw.StrategyPrefix + "/multicast/v=1")
+	require.NoError(t, err)
+	addStrategies := func(prefix string, count int) {
+		for i := 0; i < count; i++ {
+			name, err := enc.NameFromStr(prefix + "/" + strconv.Itoa(i) + "/status-dataset-test")
+			require.NoError(t, err)
+			table.FibStrategyTable.SetStrategyEnc(name, strategy)
+		}
+	}
+	addStrategies("/dataset", 300)
+	nChoices := len(table.FibStrategyTable.GetAllForwardingStrategies())
+
+	consumer := engine.NewBasicEngine(y.NewFace())
+	require.NoError(t, consumer.Start())
+	defer consumer.Stop()
+	client := object.NewClient(consumer, object.NewMemoryStore())
+	require.NoError(t, client.Start())
+	defer client.Stop()
+
+	// The client reassembles all segments
+	datasetName, err := enc.NameFromStr("/localhost/nfd/strategy-choice/list")
+	require.NoError(t, err)
+	type result struct {
+		content enc.Wire
+		err     error
+	}
+	ch := make(chan result, 1)
+	client.ConsumeStatusDataset(datasetName, func(content enc.Wire, err error) {
+		ch <- result{content, err}
+	})
+	res := <-ch
+	require.NoError(t, res.err)
+	assert.Greater(t, res.content.Length(), uint64(2*8000))
+	msg, err := mgmt.ParseStrategyChoiceMsg(enc.NewWireReader(res.content), true)
+	require.NoError(t, err)
+	assert.Equal(t, nChoices, len(msg.StrategyChoices))
+
+	// A new version is published for the first segment, and the other segments
+	// are served from its snapshot after the table changes. The CS would otherwise
+	// answer with the segments fetched by the client.
+	table.SetCsServe(false)
+	defer table.SetCsServe(true)
+	first := expressForTest(t, consumer, datasetName, true)
+	version := first.Name()[:len(datasetName)+1]
+	require.Equal(t, enc.TypeVersionNameComponent, version[len(version)-1].Typ)
+	require.NotNil(t, first.FinalBlockID())
+	lastSeg := first.FinalBlockID().NumberVal()
+	require.GreaterOrEqual(t, lastSeg, uint64(2))
+	assert.Equal(t, uint64(0), first.Name()[len(first.Name())-1].NumberVal())
+
+	addStrategies("/changed", 10)
+
+	content := enc.Wire{first.Content().Join()}
+	for seg := uint64(1); seg <= lastSeg; seg++ {
+		segName := append(version.Clone(), enc.NewSegmentComponent(seg))
+		data := expressForTest(t, consumer, segName, false)
+		require.NotNil(t, data.FinalBlockID())
+		assert.Equal(t, lastSeg, data.FinalBlockID().NumberVal())
+		content = append(content, data.Content().Join())
+	}
+	msg, err = mgmt.ParseStrategyChoiceMsg(enc.NewWireReader(content), true)
+	require.NoError(t, err)
+	assert.Equal(t, nChoices, len(msg.StrategyChoices))
+}
@@ -0,0 +1,68 @@
+package executor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/face"
+	"github.com/named-data/ndnd/std/engine"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebSocketOutgoingFace(t *testing.T) {
+	// WebSocket server accepting connections on a path only
+	connected := make(chan *websocket.Conn, 1)
+	upgrader := websocket.Upgrader{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/gateway/ws", func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err == nil {
+			connected <- c
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	uri := "ws://" + strings.TrimPrefix(server.URL, "http://") + "/gateway/ws"
+
+	client := engine.NewBasicEngine(engine.NewUnixFace(testSocket))
+	require.NoError(t, client.Start())
+	defer client.Stop()
+
+	// On-demand faces cannot be created to a server
+	err := client.ExecMgmtCmd("faces", "create", &mgmt.ControlArgs{
+		Uri:             utils.IdPtr(uri),
+		FacePersistency: utils.IdPtr(uint64(face.PersistencyOnDemand)),
+	})
+	assert.Error(t, err)
+
+	require.NoError(t, client.ExecMgmtCmd("faces", "create", &mgmt.ControlArgs{Uri: utils.IdPtr(uri)}))
+	created := face.FaceTable.GetByURI(defn.DecodeURIString(uri))
+	require.NotNil(t, created)
+	defer created.Close()
+	assert.Equal(t, uri, created.RemoteURI().String())
+	assert.Equal(t, face.PersistencyPersistent, created.Persistency())
+
+	// The face connects to the path of the URI, and reports the local address of the connection
+	var c *websocket.Conn
+	select {
+	case c = <-connected:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "face did not connect")
+	}
+	defer c.Close()
+	require.Eventually(t, func() bool {
+		return created.LocalURI().String() == "wsclient://"+c.RemoteAddr().String()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Creating the face again returns the existing face
+	err = client.ExecMgmtCmd("faces", "create", &mgmt.ControlArgs{Uri: utils.IdPtr(uri)})
+	assert.ErrorContains(t, err, "409")
+}
@@ -8,8 +8,8 @@
 package executor
 
 import (
-	"net"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/named-data/ndnd/fw/core"
@@ -26,13 +26,17 @@ type YaNFDConfig struct {
 	Version string
 	LogFile string
 
-	Config  *core.Config
-	BaseDir string
+	Config     *core.Config
+	ConfigFile string
+	BaseDir    string
 
 	CpuProfile        string
 	MemProfile        string
 	BlockProfile      string
 	MemoryBallastSize int
+
+	// Embedded indicates that YaNFD runs inside an application (see StartEmbedded)
+	Embedded bool
 }
 
 // YaNFD is the wrapper class for the NDN Forwarding Daemon.
@@ -40,11 +44,25 @@ type YaNFDConfig struct {
 type YaNFD struct {
 	config   *YaNFDConfig
 	profiler *Profiler
+	mgmt     *mgmt.Thread
+	static   *StaticConfig
+	reloadMu sync.Mutex
+	stopping bool // set under reloadMu when Stop begins
 
 	unixListener *face.UnixStreamListener
 	wsListener   *face.WebSocketListener
-	tcpListeners []*face.TCPListener
-	udpListener  *face.UDPListener
+	quicListener *face.QUICListener
+	tcpListeners map[string]addrListener // interface address to listener
+	udpListeners map[string]addrListener
+
+	// Listeners on sockets passed by the init system, and their kinds
+	activatedListeners []addrListener
+	activated          map[string]bool
+
+	multicastFaces   map[string]uint64 // local URI to face ID
+	interfaceMonitor *face.InterfaceMonitor
+
+	metrics *MetricsServer
 }
 
 // NewYaNFD creates a YaNFD. Don't call this function twice.
@@ -60,15 +78,23 @@ func NewYaNFD(config *YaNFDConfig) *YaNFD {
 
 	// Initialize config file
 	core.LoadConfig(config.Config, config.BaseDir)
-	core.InitializeLogger(config.LogFile)
+	if config.Embedded && config.LogFile == "" {
+		core.InitializeEmbeddedLogger()
+	} else {
+		core.InitializeLogger(config.LogFile)
+	}
 	face.Configure()
 	fw.Configure()
 	table.Configure()
 	mgmt.Configure()
 
 	return &YaNFD{
-		config:   config,
-		profiler: NewProfiler(config),
+		config:         config,
+		profiler:       NewProfiler(config),
+		tcpListeners:   make(map[string]addrListener),
+		udpListeners:   make(map[string]addrListener),
+		multicastFaces: make(map[string]uint64),
+		activated:      make(map[string]bool),
 	}
 }
 
@@ -88,7 +114,11 @@ func (y *YaNFD) Start() {
 	face.MakeNullLinkService(face.MakeNullTransport()).Run(nil)
 
 	// Start management thread
-	go mgmt.MakeMgmtThread().Run()
+	y.mgmt = mgmt.MakeMgmtThread()
+	if y.config.ConfigFile != "" {
+		y.mgmt.SetReloadHandler(y.reload)
+	}
+	go y.mgmt.Run()
 
 	// Create forwarding threads
 	if fw.NumFwThreads < 1 || fw.NumFwThreads > fw.MaxFwThreads {
@@ -106,138 +136,220 @@ func (y *YaNFD) Start() {
 	}
 	dispatch.InitializeFWThreads(fwForDispatch)
 
-	// Perform setup operations for each network interface
-	faceCnt := 0
-	ifaces, err := net.Interfaces()
+	// Create listeners and multicast faces on every network interface
+	addrs, err := interfaceAddrs()
 	if err != nil {
 		core.LogFatal("Main", "Unable to access network interfaces: ", err)
 		os.Exit(2)
 	}
-	tcpEnabled := core.GetConfig().Faces.Tcp.Enabled
-	tcpPort := face.TCPUnicastPort
-	y.tcpListeners = make([]*face.TCPListener, 0)
-	for _, iface := range ifaces {
-		if iface.Flags&net.FlagUp == 0 {
-			core.LogInfo("Main", "Skipping interface ", iface.Name, " because not up")
-			continue
-		}
-
-		// Create UDP/TCP listener and multicast UDP interface for every address on interface
-		addrs, err := iface.Addrs()
-		if err != nil {
-			core.LogFatal("Main", "Unable to access addresses on network interface ", iface.Name, ": ", err)
-		}
-		for _, addr := range addrs {
-			ipAddr := addr.(*net.IPNet)
-
-			ipVersion := 4
-			path := ipAddr.IP.String()
-			if ipAddr.IP.To4() == nil {
-				ipVersion = 6
-				path += "%" + iface.Name
-			}
-
-			if !addr.(*net.IPNet).IP.IsLoopback() {
-				multicastUDPTransport, err := face.MakeMulticastUDPTransport(
-					defn.MakeUDPFaceURI(ipVersion, path, face.UDPMulticastPort))
-				if err != nil {
-					core.LogError("Main", "Unable to create MulticastUDPTransport for ", path, " on ", iface.Name, ": ", err)
-					continue
-				}
-
-				face.MakeNDNLPLinkService(
-					multicastUDPTransport,
-					face.MakeNDNLPLinkServiceOptions(),
-				).Run(nil)
-
-				faceCnt += 1
-				core.LogInfo("Main", "Created multicast UDP face for ", path, " on ", iface.Name)
-			}
-
-			udpListener, err := face.MakeUDPListener(defn.MakeUDPFaceURI(ipVersion, path, face.UDPUnicastPort))
-			if err != nil {
-				core.LogError("Main", "Unable to create UDP listener for ", path, " on ", iface.Name, ": ", err)
-				continue
-			}
-			faceCnt += 1
-			go udpListener.Run()
-			y.udpListener = udpListener
-			core.LogInfo("Main", "Created UDP listener for ", path, " on ", iface.Name)
-
-			if tcpEnabled {
-				tcpListener, err := face.MakeTCPListener(defn.MakeTCPFaceURI(ipVersion, path, tcpPort))
-				if err != nil {
-					core.LogError("Main", "Unable to create TCP listener for ", path, " on ", iface.Name, ": ", err)
-					continue
-				}
-				faceCnt += 1
-				go tcpListener.Run()
-				y.tcpListeners = append(y.tcpListeners, tcpListener)
-				core.LogInfo("Main", "Created TCP listener for ", path, " on ", iface.Name)
-			}
-		}
-	}
-	if core.GetConfig().Faces.Unix.Enabled {
-		// Set up Unix stream listener
-		y.unixListener, err = face.MakeUnixStreamListener(defn.MakeUnixFaceURI(face.UnixSocketPath))
-		if err != nil {
-			core.LogError("Main", "Unable to create Unix stream listener at ", face.UnixSocketPath, ": ", err)
-		} else {
-			faceCnt += 1
-			go y.unixListener.Run()
-			core.LogInfo("Main", "Created Unix stream listener for ", face.UnixSocketPath)
-		}
-	}
 
+	faceCnt := y.startActivatedListeners()
+	if core.GetConfig().Faces.Udp.Enabled && !y.activated["udp"] {
+		faceCnt += y.updateUDPListeners(addrs)
+	}
+	if core.GetConfig().Faces.Udp.Multicast {
+		faceCnt += y.updateMulticastFaces(addrs)
+	}
+	if core.GetConfig().Faces.Tcp.Enabled && !y.activated["tcp"] {
+		faceCnt += y.updateTCPListeners(addrs)
+	}
+	if core.GetConfig().Faces.Unix.Enabled && !y.activated["unix"] {
+		faceCnt += y.startUnixListener()
+	}
 	if core.GetConfig().Faces.WebSocket.Enabled {
-		cfg := face.WebSocketListenerConfig{
-			Bind:       core.GetConfig().Faces.WebSocket.Bind,
-			Port:       core.GetConfig().Faces.WebSocket.Port,
-			TLSEnabled: core.GetConfig().Faces.WebSocket.TlsEnabled,
-			TLSCert:    core.ResolveConfigFileRelPath(core.GetConfig().Faces.WebSocket.TlsCert),
-			TLSKey:     core.ResolveConfigFileRelPath(core.GetConfig().Faces.WebSocket.TlsKey),
-		}
-		y.wsListener, err = face.NewWebSocketListener(cfg)
-		if err != nil {
-			core.LogError("Main", "Unable to create ", cfg, ": ", err)
-		} else {
-			faceCnt++
-			go y.wsListener.Run()
-			core.LogInfo("Main", "Created ", cfg)
-		}
-	}
-
-	if faceCnt <= 0 {
+		faceCnt += y.startWebSocketListener()
+	}
+	if core.GetConfig().Faces.Quic.Enabled {
+		faceCnt += y.startQUICListener()
+	}
+
+	if faceCnt <= 0 && !y.config.Embedded {
 		core.LogFatal("Main", "No face or listener is successfully created. Quit.")
 		os.Exit(2)
 	}
+
+	// Create static faces and routes
+	y.static = NewStaticConfig(core.GetConfig(), y.mgmt.Post)
+	y.static.Start()
+
+	// Follow changes of network interfaces
+	y.interfaceMonitor = face.NewInterfaceMonitor(y.onInterfacesChanged)
+	go y.interfaceMonitor.Run()
+
+	// Start metrics listener
+	if core.GetConfig().Metrics.Enabled {
+		y.startMetrics()
+	}
 }
 
-// Stop shuts down YaNFD.
-func (y *YaNFD) Stop() {
-	core.LogInfo("Main", "Forwarder shutting down ...")
-	core.ShouldQuit = true
+// stopTCPListeners closes all TCP listeners.
+func (y *YaNFD) stopTCPListeners() {
+	for _, tcpListener := range y.tcpListeners {
+		tcpListener.Close()
+	}
+	clear(y.tcpListeners)
+}
 
-	// Stop profiler
-	y.profiler.Stop()
+// startUnixListener creates the Unix stream listener.
+// It returns the number of listeners created.
+func (y *YaNFD) startUnixListener() int {
+	var err error
+	y.unixListener, err = face.MakeUnixStreamListener(defn.MakeUnixFaceURI(face.UnixSocketPath))
+	if err != nil {
+		core.LogError("Main", "Unable to create Unix stream listener at ", face.UnixSocketPath, ": ", err)
+		return 0
+	}
 
-	// Wait for unix socket listener to quit
+	go y.unixListener.Run()
+	core.LogInfo("Main", "Created Unix stream listener for ", face.UnixSocketPath)
+	return 1
+}
+
+// stopUnixListener closes the Unix stream listener.
+func (y *YaNFD) stopUnixListener() {
 	if y.unixListener != nil {
 		y.unixListener.Close()
+		y.unixListener = nil
 	}
+}
+
+// startWebSocketListener creates the WebSocket listener.
+// It returns the number of listeners created.
+func (y *YaNFD) startWebSocketListener() int {
+	cfg := face.WebSocketListenerConfig{
+		Bind:       core.GetConfig().Faces.WebSocket.Bind,
+		Port:       core.GetConfig().Faces.WebSocket.Port,
+		TLSEnabled: core.GetConfig().Faces.WebSocket.TlsEnabled,
+		TLSCert:    core.ResolveConfigFileRelPath(core.GetConfig().Faces.WebSocket.TlsCert),
+		TLSKey:     core.ResolveConfigFileRelPath(core.GetConfig().Faces.WebSocket.TlsKey),
+	}
+
+	var err error
+	y.wsListener, err = face.NewWebSocketListener(cfg)
+	if err != nil {
+		core.LogError("Main", "Unable to create ", cfg, ": ", err)
+		return 0
+	}
+
+	go y.wsListener.Run()
+	core.LogInfo("Main", "Created ", cfg)
+	return 1
+}
+
+// stopWebSocketListener closes the WebSocket listener.
+func (y *YaNFD) stopWebSocketListener() {
 	if y.wsListener != nil {
 		y.wsListener.Close()
+		y.wsListener = nil
 	}
+}
 
-	// Wait for UDP listener to quit
-	if y.udpListener != nil {
-		y.udpListener.Close()
+// startQUICListener creates the QUIC listener.
+// It returns the number of listeners created.
+func (y *YaNFD) startQUICListener() int {
+	cfg := face.QUICListenerConfig{
+		Bind:             core.GetConfig().Faces.Quic.Bind,
+		Port:             core.GetConfig().Faces.Quic.Port,
+		WebTransportPath: core.GetConfig().Faces.Quic.WebTransportPath,
+	}
+	if core.GetConfig().Faces.Quic.TlsCert != "" {
+		// Use a self-signed certificate otherwise
+		cfg.TLSCert = core.ResolveConfigFileRelPath(core.GetConfig().Faces.Quic.TlsCert)
+		cfg.TLSKey = core.ResolveConfigFileRelPath(core.GetConfig().Faces.Quic.TlsKey)
 	}
 
-	// Wait for TCP listeners to quit
-	for _, tcpListener := range y.tcpListeners {
-		tcpListener.Close()
+	var err error
+	y.quicListener, err = face.NewQUICListener(cfg)
+	if err != nil {
+		core.LogError("Main", "Unable to create ", cfg, ": ", err)
+		return 0
+	}
+
+	go y.quicListener.Run()
+	core.LogInfo("Main", "Created ", cfg)
+	return 1
+}
+
+// stopQUICListener closes the QUIC listener.
+func (y *YaNFD) stopQUICListener() {
+	if y.quicListener != nil {
+		y.quicListener.Close()
+		y.quicListener = nil
 	}
+}
+
+// startMetrics creates the OpenMetrics HTTP listener.
+func (y *YaNFD) startMetrics() {
+	cfg := core.GetConfig().Metrics
+	metrics := NewMetricsServer(cfg.Bind, cfg.Port)
+	if err := metrics.Run(); err != nil {
+		core.LogError("Main", "Unable to create ", metrics, ": ", err)
+		return
+	}
+	y.metrics = metrics
+	core.LogInfo("Main", "Created ", metrics)
+}
+
+// stopMetrics closes the OpenMetrics HTTP listener.
+func (y *YaNFD) stopMetrics() {
+	if y.metrics != nil {
+		y.metrics.Close()
+		y.metrics = nil
+	}
+}
+
+// Stop shuts down YaNFD. Unless the shutdown timeout is zero, listeners are closed first,
+// and pending Interests and queued packets are drained until the timeout.
+func (y *YaNFD) Stop() {
+	// Wait for a running reload, and refuse further reloads
+	y.reloadMu.Lock()
+	y.stopping = true
+	y.reloadMu.Unlock()
+
+	core.LogInfo("Main", "Forwarder shutting down ...")
+	timeout := time.Duration(core.GetConfig().Core.ShutdownTimeout) * time.Millisecond
+	if timeout <= 0 {
+		core.ShouldQuit.Store(true)
+	}
+
+	// Stop profiler
+	y.profiler.Stop()
+
+	// Stop watching network interfaces
+	if y.interfaceMonitor != nil {
+		y.interfaceMonitor.Close()
+	}
+
+	// Stop watching static faces
+	if y.static != nil {
+		y.static.Stop()
+	}
+
+	// Stop metrics listener
+	y.stopMetrics()
+
+	// Wait for listeners to quit
+	y.stopUnixListener()
+	y.stopWebSocketListener()
+	y.stopQUICListener()
+	y.stopTCPListeners()
+
+	// Wait for UDP listeners to quit
+	for _, udpListener := range y.udpListeners {
+		udpListener.Close()
+	}
+	for _, listener := range y.activatedListeners {
+		listener.Close()
+	}
+
+	// Let existing faces finish their work
+	if timeout > 0 {
+		y.drain(timeout)
+		core.ShouldQuit.Store(true)
+	}
+
+	// Flush packet captures
+	face.StopAllCaptures()
 
 	// Tell all faces to quit
 	for _, face := range face.FaceTable.GetAll() {
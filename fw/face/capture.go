@@ -0,0 +1,301 @@
+package face
+
+import (
+	"bufio"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	enc "github.com/named-data/ndnd/std/encoding"
+)
+
+// Capture writes the network layer packets sent and received on a face
+// (or on all faces) to a pcapng file.
+//
+// Each record is a complete Interest or Data: received packets are captured after
+// reassembly and sent packets when they are queued on the face, before fragmentation.
+// NDNLPv2 headers (PIT tokens, congestion marks, Nacks) are therefore not captured,
+// which lets the Name filter apply to every record.
+//
+// Packets are handed to a writer goroutine through a bounded queue, so that the
+// faces do not wait for the file. Packets that do not fit in the queue are dropped
+// from the capture and counted.
+type Capture struct {
+	FaceID     uint64 // zero to capture on all faces
+	Path       string
+	MaxSize    uint64
+	MaxPackets uint64 // zero for no limit
+
+	prefixes atomic.Pointer[[]enc.Name] // nil to capture all packets
+	records  chan captureRecord
+	quit     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+	nDropped atomic.Uint64
+
+	// Owned by the writer goroutine
+	nPackets uint64
+	file     *os.File
+	buf      *bufio.Writer
+	writer   *pcapngWriter
+}
+
+// captureRecord is a packet waiting to be written to a capture.
+type captureRecord struct {
+	faceID  uint64
+	ifName  string
+	time    time.Time
+	wire    []byte
+	inbound bool
+}
+
+// captureQueueSize is the number of packets that can wait for each capture writer.
+const captureQueueSize = 1024
+
+// captures contains the running captures, indexed by face ID.
+// The map is copied on write, so that faces look up captures without a lock.
+var captures = struct {
+	sync.Mutex
+	byFace atomic.Pointer[map[uint64]*Capture]
+}{}
+
+// nCaptures is the number of running captures, checked on the fast path.
+var nCaptures atomic.Int32
+
+func init() {
+	captures.byFace.Store(&map[uint64]*Capture{})
+}
+
+// ErrCaptureExists is returned when a capture with different limits is already running on the face.
+var ErrCaptureExists = errors.New("a capture is already running on this face")
+
+// StartCapture starts capturing packets on a face, or on all faces if faceID is zero.
+// If prefix is not empty, only packets under the prefix are captured. Starting a capture
+// on a face that is already being captured adds the prefix to the existing capture.
+// If maxSize is zero, the configured default size limit is used.
+func StartCapture(faceID uint64, prefix enc.Name, maxSize uint64, maxPackets uint64) (*Capture, error) {
+	captures.Lock()
+	defer captures.Unlock()
+
+	if c, ok := (*captures.byFace.Load())[faceID]; ok {
+		if (maxSize != 0 && maxSize != c.MaxSize) || maxPackets != c.MaxPackets {
+			return nil, ErrCaptureExists
+		}
+		if prefixes := c.prefixes.Load(); len(prefix) == 0 {
+			c.prefixes.Store(nil)
+			core.LogInfo(c, "Capturing all packets")
+		} else if prefixes != nil {
+			newPrefixes := append(slices.Clone(*prefixes), prefix.Clone())
+			c.prefixes.Store(&newPrefixes)
+			core.LogInfo(c, "Added prefix ", prefix, " to capture")
+		}
+		return c, nil
+	}
+
+	cfg := core.GetConfig().Faces.Capture
+	if maxSize == 0 {
+		maxSize = cfg.MaxSize
+	}
+
+	faceName := "all"
+	if faceID != 0 {
+		faceName = strconv.FormatUint(faceID, 10)
+	}
+	fileName := "yanfd-face-" + faceName + "-" + time.Now().Format("20060102-150405") + ".pcapng"
+	path := filepath.Join(core.ResolveConfigFileRelPath(cfg.Directory), fileName)
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0640)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Capture{
+		FaceID:     faceID,
+		Path:       path,
+		MaxSize:    maxSize,
+		MaxPackets: maxPackets,
+		records:    make(chan captureRecord, captureQueueSize),
+		quit:       make(chan struct{}),
+		done:       make(chan struct{}),
+		file:       file,
+		buf:        bufio.NewWriter(file),
+	}
+	if len(prefix) > 0 {
+		c.prefixes.Store(&[]enc.Name{prefix.Clone()})
+	}
+	if c.writer, err = newPcapngWriter(c.buf); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	byFace := maps.Clone(*captures.byFace.Load())
+	byFace[faceID] = c
+	captures.byFace.Store(&byFace)
+	nCaptures.Add(1)
+	go c.run()
+
+	core.LogInfo(c, "Started capture to ", path)
+	return c, nil
+}
+
+// StopCapture stops the capture on a face. It returns false if there is no such capture.
+// The capture file is complete when the function returns.
+func StopCapture(faceID uint64) bool {
+	c, ok := (*captures.byFace.Load())[faceID]
+	if !ok {
+		return false
+	}
+	c.stop()
+	<-c.done
+	return true
+}
+
+// StopAllCaptures stops all running captures, and waits for their files to be written.
+func StopAllCaptures() {
+	for _, c := range *captures.byFace.Load() {
+		c.stop()
+		<-c.done
+	}
+}
+
+// stopFaceCapture stops the capture on a face that is being removed.
+// It does not wait for the capture file to be written.
+func stopFaceCapture(faceID uint64) {
+	if c, ok := (*captures.byFace.Load())[faceID]; ok {
+		c.stop()
+	}
+}
+
+func (c *Capture) String() string {
+	if c.FaceID == 0 {
+		return "Capture, all faces"
+	}
+	return "Capture, FaceID=" + strconv.FormatUint(c.FaceID, 10)
+}
+
+// stop removes the capture from the running captures and tells the writer to finish.
+func (c *Capture) stop() {
+	c.stopOnce.Do(func() {
+		captures.Lock()
+		byFace := maps.Clone(*captures.byFace.Load())
+		delete(byFace, c.FaceID)
+		captures.byFace.Store(&byFace)
+		nCaptures.Add(-1)
+		captures.Unlock()
+
+		close(c.quit)
+	})
+}
+
+// run writes queued packets until the capture is stopped, then closes the file.
+func (c *Capture) run() {
+	defer close(c.done)
+
+	writing := true
+	for writing {
+		select {
+		case r := <-c.records:
+			writing = c.write(r)
+		case <-c.quit:
+			// Write the packets queued before the capture was stopped
+			for writing && len(c.records) > 0 {
+				writing = c.write(<-c.records)
+			}
+			writing = false
+		}
+	}
+	c.stop()
+
+	if err := c.buf.Flush(); err != nil {
+		core.LogWarn(c, "Unable to write capture: ", err)
+	}
+	c.file.Close()
+	core.LogInfo(c, "Stopped capture after ", c.nPackets, " packets (", c.writer.written, " bytes, ",
+		c.nDropped.Load(), " dropped)")
+}
+
+// matches returns whether a packet should be captured.
+func (c *Capture) matches(pkt *defn.Pkt) bool {
+	prefixes := c.prefixes.Load()
+	if prefixes == nil {
+		return true
+	}
+
+	name := pkt.Name
+	if name == nil {
+		if pkt.L3.Interest != nil {
+			name = pkt.L3.Interest.NameV
+		} else if pkt.L3.Data != nil {
+			name = pkt.L3.Data.NameV
+		}
+	}
+	for _, prefix := range *prefixes {
+		if prefix.IsPrefix(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// enqueue hands a packet to the writer goroutine, or drops it if the writer is behind.
+func (c *Capture) enqueue(l *linkServiceBase, pkt *defn.Pkt, inbound bool) {
+	ifName := "FaceID=" + strconv.FormatUint(l.FaceID(), 10)
+	if l.transport != nil {
+		ifName += " " + l.RemoteURI().String()
+	}
+
+	select {
+	case c.records <- captureRecord{
+		faceID:  l.FaceID(),
+		ifName:  ifName,
+		time:    time.Now(),
+		wire:    slices.Clone(pkt.Raw),
+		inbound: inbound,
+	}:
+	default:
+		c.nDropped.Add(1)
+	}
+}
+
+// write adds a packet to the capture file. It returns false once a limit is reached.
+func (c *Capture) write(r captureRecord) bool {
+	if c.writer.written+c.writer.blockSize(r.faceID, r.ifName, r.wire) > c.MaxSize {
+		core.LogInfo(c, "Capture size limit reached")
+		return false
+	}
+
+	if err := c.writer.writePacket(r.faceID, r.ifName, r.time, r.wire, r.inbound); err != nil {
+		core.LogWarn(c, "Unable to write capture: ", err)
+		return false
+	}
+
+	c.nPackets++
+	if c.MaxPackets != 0 && c.nPackets >= c.MaxPackets {
+		core.LogInfo(c, "Capture packet limit reached")
+		return false
+	}
+	return true
+}
+
+// capturePacket tees a packet sent or received on a face to the running captures.
+func capturePacket(l *linkServiceBase, pkt *defn.Pkt, inbound bool) {
+	if nCaptures.Load() == 0 || pkt == nil || pkt.Raw == nil {
+		return
+	}
+
+	byFace := *captures.byFace.Load()
+	if c, ok := byFace[l.FaceID()]; ok && c.matches(pkt) {
+		c.enqueue(l, pkt, inbound)
+	}
+	if c, ok := byFace[0]; ok && c.matches(pkt) {
+		c.enqueue(l, pkt, inbound)
+	}
+}
@@ -0,0 +1,263 @@
+package face
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pcapngBlock is a block read back from a capture.
+type pcapngBlock struct {
+	typ  uint32
+	body []byte
+}
+
+// readPcapng splits a pcapng stream into blocks, checking their lengths.
+func readPcapng(t *testing.T, b []byte) []pcapngBlock {
+	var blocks []pcapngBlock
+	for len(b) > 0 {
+		require.GreaterOrEqual(t, len(b), 12)
+		size := int(binary.LittleEndian.Uint32(b[4:]))
+		require.Zero(t, size%4)
+		require.GreaterOrEqual(t, size, 12)
+		require.LessOrEqual(t, size, len(b))
+		require.Equal(t, uint32(size), binary.LittleEndian.Uint32(b[size-4:]))
+		blocks = append(blocks, pcapngBlock{
+			typ:  binary.LittleEndian.Uint32(b[0:]),
+			body: b[8 : size-4],
+		})
+		b = b[size:]
+	}
+	return blocks
+}
+
+// capturedPacket is an Enhanced Packet Block read back from a capture.
+type capturedPacket struct {
+	ifID  uint32
+	time  time.Time
+	wire  []byte
+	flags uint32
+}
+
+func parsePacketBlock(t *testing.T, block pcapngBlock) capturedPacket {
+	require.Equal(t, uint32(pcapngEnhancedPacket), block.typ)
+	b := block.body
+	ts := uint64(binary.LittleEndian.Uint32(b[4:]))<<32 | uint64(binary.LittleEndian.Uint32(b[8:]))
+	capLen := int(binary.LittleEndian.Uint32(b[12:]))
+	require.Equal(t, uint32(capLen), binary.LittleEndian.Uint32(b[16:]))
+	opts := b[20+pad4(capLen):]
+	require.Equal(t, uint16(pcapngOptEpbFlags), binary.LittleEndian.Uint16(opts[0:]))
+	require.Equal(t, uint16(4), binary.LittleEndian.Uint16(opts[2:]))
+	require.Equal(t, uint32(pcapngOptEndOfOpt), binary.LittleEndian.Uint32(opts[8:]))
+	return capturedPacket{
+		ifID:  binary.LittleEndian.Uint32(b[0:]),
+		time:  time.UnixMicro(int64(ts)),
+		wire:  b[20 : 20+capLen],
+		flags: binary.LittleEndian.Uint32(opts[4:]),
+	}
+}
+
+func parseInterfaceBlock(t *testing.T, block pcapngBlock) string {
+	require.Equal(t, uint32(pcapngInterfaceDesc), block.typ)
+	b := block.body
+	assert.Equal(t, uint16(pcapngLinkType), binary.LittleEndian.Uint16(b[0:]))
+	require.Equal(t, uint16(pcapngOptIfName), binary.LittleEndian.Uint16(b[8:]))
+	nameLen := int(binary.LittleEndian.Uint16(b[10:]))
+	assert.Equal(t, make([]byte, 4), b[12+pad4(nameLen):])
+	return string(b[12 : 12+nameLen])
+}
+
+func TestPcapngWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := newPcapngWriter(&buf)
+	require.NoError(t, err)
+
+	now := time.UnixMicro(time.Now().UnixMicro())
+	packets := []struct {
+		faceID  uint64
+		ifName  string
+		wire    []byte
+		inbound bool
+	}{
+		{7, "FaceID=7", []byte{0x05, 0x01, 0x00}, true},
+		{9, "FaceID=9 udp4://127.0.0.1:6363", []byte{0x06, 0x02, 0x00, 0x00}, false},
+		{7, "FaceID=7", []byte{0x06, 0x03, 0x00, 0x00, 0x00}, false},
+	}
+	expectedSize := uint64(pcapngSectionHeaderLen)
+	for _, p := range packets {
+		expectedSize += w.blockSize(p.faceID, p.ifName, p.wire)
+		require.NoError(t, w.writePacket(p.faceID, p.ifName, now, p.wire, p.inbound))
+	}
+	assert.Equal(t, expectedSize, w.written)
+	assert.Equal(t, uint64(buf.Len()), w.written)
+
+	// Section header, then an interface per face before its first packet
+	blocks := readPcapng(t, buf.Bytes())
+	require.Len(t, blocks, 6)
+	require.Equal(t, uint32(pcapngSectionHeader), blocks[0].typ)
+	assert.Equal(t, uint32(pcapngByteOrderMagic), binary.LittleEndian.Uint32(blocks[0].body[0:]))
+	assert.Equal(t, uint16(1), binary.LittleEndian.Uint16(blocks[0].body[4:]))
+	assert.Equal(t, uint16(0), binary.LittleEndian.Uint16(blocks[0].body[6:]))
+	assert.Equal(t, ^uint64(0), binary.LittleEndian.Uint64(blocks[0].body[8:]))
+
+	assert.Equal(t, "FaceID=7", parseInterfaceBlock(t, blocks[1]))
+	first := parsePacketBlock(t, blocks[2])
+	assert.Equal(t, uint32(0), first.ifID)
+	assert.Equal(t, now, first.time)
+	assert.Equal(t, packets[0].wire, first.wire)
+	assert.Equal(t, uint32(pcapngFlagInbound), first.flags)
+
+	assert.Equal(t, "FaceID=9 udp4://127.0.0.1:6363", parseInterfaceBlock(t, blocks[3]))
+	second := parsePacketBlock(t, blocks[4])
+	assert.Equal(t, uint32(1), second.ifID)
+	assert.Equal(t, packets[1].wire, second.wire)
+	assert.Equal(t, uint32(pcapngFlagOutbound), second.flags)
+
+	third := parsePacketBlock(t, blocks[5])
+	assert.Equal(t, uint32(0), third.ifID)
+	assert.Equal(t, packets[2].wire, third.wire)
+	assert.Equal(t, uint32(pcapngFlagOutbound), third.flags)
+}
+
+// setupCaptureTest writes captures to a temporary directory, and returns a running face.
+func setupCaptureTest(t *testing.T) *NDNLPLinkService {
+	prevConfig := core.GetConfig()
+	config := *prevConfig
+	config.Faces.Capture.Directory = t.TempDir()
+	core.LoadConfig(&config, "")
+	t.Cleanup(func() { core.LoadConfig(prevConfig, "") })
+
+	linkService := MakeNDNLPLinkService(MakeInProcessTransport(), MakeNDNLPLinkServiceOptions())
+	linkService.Run(nil)
+	t.Cleanup(linkService.Close)
+	return linkService
+}
+
+// testCapturePacket returns a packet of the given size under a name.
+func testCapturePacket(t *testing.T, name string, size int) *defn.Pkt {
+	n, err := enc.NameFromStr(name)
+	require.NoError(t, err)
+	return &defn.Pkt{Name: n, Raw: bytes.Repeat([]byte{0x06}, size)}
+}
+
+// readCapture waits for a capture to be written, and returns its packets.
+func readCapture(t *testing.T, c *Capture) []capturedPacket {
+	select {
+	case <-c.done:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "capture did not stop")
+	}
+
+	b, err := os.ReadFile(c.Path)
+	require.NoError(t, err)
+	blocks := readPcapng(t, b)
+	require.GreaterOrEqual(t, len(blocks), 1)
+	require.Equal(t, uint32(pcapngSectionHeader), blocks[0].typ)
+
+	var packets []capturedPacket
+	for _, block := range blocks[1:] {
+		if block.typ == pcapngInterfaceDesc {
+			parseInterfaceBlock(t, block)
+			continue
+		}
+		packets = append(packets, parsePacketBlock(t, block))
+	}
+	return packets
+}
+
+func TestCaptureLimits(t *testing.T) {
+	l := setupCaptureTest(t)
+	faceID := l.FaceID()
+
+	// Packet limit
+	c, err := StartCapture(faceID, nil, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, core.GetConfig().Faces.Capture.MaxSize, c.MaxSize)
+	for i := 0; i < 3; i++ {
+		capturePacket(&l.linkServiceBase, testCapturePacket(t, "/capture/count", 10+i), i%2 == 0)
+	}
+	packets := readCapture(t, c)
+	require.Len(t, packets, 2)
+	assert.Len(t, packets[0].wire, 10)
+	assert.Equal(t, uint32(pcapngFlagInbound), packets[0].flags)
+	assert.Len(t, packets[1].wire, 11)
+	assert.Equal(t, uint32(pcapngFlagOutbound), packets[1].flags)
+	assert.False(t, StopCapture(faceID))
+
+	// Size limit, with room for two packets only
+	pkt := testCapturePacket(t, "/capture/capacity", 100)
+	maxSize := uint64(pcapngSectionHeaderLen +
+		interfaceBlockSize("FaceID="+strconv.FormatUint(faceID, 10)+" "+l.RemoteURI().String()) +
+		2*packetBlockSize(pkt.Raw))
+	c, err = StartCapture(faceID, nil, maxSize, 0)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		capturePacket(&l.linkServiceBase, pkt, true)
+	}
+	packets = readCapture(t, c)
+	require.Len(t, packets, 2)
+	info, err := os.Stat(c.Path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(maxSize), info.Size())
+
+	// Only packets under the prefix are captured
+	prefix, err := enc.NameFromStr("/capture/filtered")
+	require.NoError(t, err)
+	c, err = StartCapture(faceID, prefix, 0, 0)
+	require.NoError(t, err)
+	capturePacket(&l.linkServiceBase, testCapturePacket(t, "/capture/other", 10), true)
+	capturePacket(&l.linkServiceBase, testCapturePacket(t, "/capture/filtered/a", 20), true)
+	_, err = StartCapture(faceID, prefix, 0, 1)
+	assert.ErrorIs(t, err, ErrCaptureExists)
+	require.True(t, StopCapture(faceID))
+	packets = readCapture(t, c)
+	require.Len(t, packets, 1)
+	assert.Len(t, packets[0].wire, 20)
+}
+
+func TestCaptureQueueFull(t *testing.T) {
+	l := setupCaptureTest(t)
+
+	// No writer is running, so the queue fills up
+	c := &Capture{records: make(chan captureRecord, captureQueueSize)}
+	pkt := testCapturePacket(t, "/capture/queue", 10)
+	for i := 0; i < captureQueueSize+10; i++ {
+		c.enqueue(&l.linkServiceBase, pkt, true)
+	}
+	assert.Len(t, c.records, captureQueueSize)
+	assert.Equal(t, uint64(10), c.nDropped.Load())
+
+	// Queued packets are copied
+	r := <-c.records
+	assert.Equal(t, pkt.Raw, r.wire)
+	pkt.Raw[0] = 0x05
+	assert.Equal(t, byte(0x06), r.wire[0])
+}
+
+func TestCaptureFaceClosed(t *testing.T) {
+	l := setupCaptureTest(t)
+	faceID := l.FaceID()
+
+	c, err := StartCapture(faceID, nil, 0, 0)
+	require.NoError(t, err)
+	capturePacket(&l.linkServiceBase, testCapturePacket(t, "/capture/closed", 10), true)
+
+	// The capture is complete once the face is gone
+	l.Close()
+	require.Eventually(t, func() bool { return FaceTable.Get(faceID) == nil },
+		5*time.Second, 10*time.Millisecond)
+	packets := readCapture(t, c)
+	require.Len(t, packets, 1)
+	assert.NotContains(t, *captures.byFace.Load(), faceID)
+	assert.Zero(t, nCaptures.Load())
+	assert.False(t, StopCapture(faceID))
+}
@@ -0,0 +1,52 @@
+package face
+
+import "sync"
+
+// FaceEventKind is the kind of a face event, as in NFD face event notifications.
+type FaceEventKind uint64
+
+const (
+	FaceEventCreated   FaceEventKind = 1
+	FaceEventDestroyed FaceEventKind = 2
+	FaceEventUp        FaceEventKind = 3
+	FaceEventDown      FaceEventKind = 4
+)
+
+func (k FaceEventKind) String() string {
+	switch k {
+	case FaceEventCreated:
+		return "Created"
+	case FaceEventDestroyed:
+		return "Destroyed"
+	case FaceEventUp:
+		return "Up"
+	case FaceEventDown:
+		return "Down"
+	default:
+		return "Unknown"
+	}
+}
+
+// FaceEventHandler is called when a face is created or destroyed, or goes up or down.
+// It is called from the goroutine of the face and must not block.
+type FaceEventHandler func(kind FaceEventKind, face LinkService)
+
+var faceEventHandlers struct {
+	sync.RWMutex
+	handlers []FaceEventHandler
+}
+
+// AddFaceEventHandler registers a handler for face events.
+func AddFaceEventHandler(handler FaceEventHandler) {
+	faceEventHandlers.Lock()
+	defer faceEventHandlers.Unlock()
+	faceEventHandlers.handlers = append(faceEventHandlers.handlers, handler)
+}
+
+func emitFaceEvent(kind FaceEventKind, face LinkService) {
+	faceEventHandlers.RLock()
+	defer faceEventHandlers.RUnlock()
+	for _, handler := range faceEventHandlers.handlers {
+		handler(kind, face)
+	}
+}
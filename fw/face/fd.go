@@ -0,0 +1,106 @@
+package face
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"syscall"
+
+	"github.com/named-data/ndnd/fw/core"
+	defn "github.com/named-data/ndnd/fw/defn"
+)
+
+// MakeFDTransport makes a transport for a connected stream socket opened by a supervisor
+// and passed as the file descriptor in an fd:// URI, which the forwarder then owns.
+// Unix sockets make local faces, whose remote URI is the fd:// URI of the descriptor
+// used by the forwarder, and TCP sockets make unicast TCP faces.
+func MakeFDTransport(remoteURI *defn.URI) (transport, error) {
+	if !remoteURI.IsCanonical() || remoteURI.Scheme() != "fd" {
+		return nil, core.ErrNotCanonical
+	}
+
+	fd, err := strconv.Atoi(remoteURI.Path())
+	if err != nil {
+		return nil, core.ErrNotCanonical
+	}
+	if fd <= 2 {
+		return nil, errors.New("standard streams cannot be used as faces")
+	}
+
+	// The connection uses a duplicate of the descriptor
+	file := os.NewFile(uintptr(fd), remoteURI.String())
+	conn, err := net.FileConn(file)
+	file.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	switch c := conn.(type) {
+	case *net.UnixConn:
+		localURI := defn.MakeUnixFaceURI(UnixSocketPath)
+		if addr := c.LocalAddr().String(); addr != "" {
+			localURI = defn.MakeUnixFaceURI(addr)
+		}
+		return MakeUnixStreamTransport(defn.MakeFDFaceURI(connFD(c)), localURI, c)
+	case *net.TCPConn:
+		return AcceptUnicastTCPTransport(c, addrURI(c.LocalAddr()), PersistencyPersistent)
+	default:
+		conn.Close()
+		return nil, fmt.Errorf("unsupported socket type %T", conn)
+	}
+}
+
+// connFD returns the file descriptor of a connection, or -1 if unknown.
+func connFD(conn net.Conn) int {
+	syscallConn, ok := conn.(syscall.Conn)
+	if !ok {
+		return -1
+	}
+	rawConn, err := syscallConn.SyscallConn()
+	if err != nil {
+		return -1
+	}
+
+	fd := -1
+	rawConn.Control(func(f uintptr) {
+		fd = int(f)
+	})
+	return fd
+}
+
+// addrURI makes a face URI from the address of a socket.
+func addrURI(addr net.Addr) *defn.URI {
+	var uri *defn.URI
+	switch addr := addr.(type) {
+	case *net.TCPAddr:
+		uri = defn.MakeTCPFaceURI(ipVersion(addr.IP), ipPath(addr.IP, addr.Zone), uint16(addr.Port))
+	case *net.UDPAddr:
+		uri = defn.MakeUDPFaceURI(ipVersion(addr.IP), ipPath(addr.IP, addr.Zone), uint16(addr.Port))
+	case *net.UnixAddr:
+		uri = defn.MakeUnixFaceURI(addr.Name)
+	default:
+		return nil
+	}
+
+	uri.Canonize()
+	if !uri.IsCanonical() {
+		return nil
+	}
+	return uri
+}
+
+func ipVersion(ip net.IP) int {
+	if ip.To4() != nil {
+		return 4
+	}
+	return 6
+}
+
+func ipPath(ip net.IP, zone string) string {
+	if zone != "" {
+		return ip.String() + "%" + zone
+	}
+	return ip.String()
+}
@@ -0,0 +1,89 @@
+//go:build unix
+
+package face
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// passFD returns the fd:// URI of a duplicate of a socket, as a supervisor would pass it.
+// The forwarder owns the duplicate once a transport is made from it.
+func passFD(t *testing.T, conn interface{ File() (*os.File, error) }) *defn.URI {
+	file, err := conn.File()
+	if err != nil {
+		t.Skip("sockets cannot be passed as file descriptors: ", err)
+	}
+	defer file.Close()
+
+	// The descriptor must outlive the file, whose finalizer would close it
+	fd, err := syscall.Dup(int(file.Fd()))
+	require.NoError(t, err)
+	uri := defn.DecodeURIString("fd://" + strconv.Itoa(fd))
+	require.NotNil(t, uri)
+	return uri
+}
+
+func TestFDTransport(t *testing.T) {
+	// Unix sockets make local faces
+	path := filepath.Join(t.TempDir(), "nfd.sock")
+	unixListener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer unixListener.Close()
+	unixClient, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	defer unixClient.Close()
+	unixConn, err := unixListener.Accept()
+	require.NoError(t, err)
+	unixURI := passFD(t, unixConn.(*net.UnixConn))
+	unixConn.Close()
+
+	transport, err := MakeFDTransport(unixURI)
+	require.NoError(t, err)
+	unixTransport, ok := transport.(*UnixStreamTransport)
+	require.True(t, ok)
+	defer unixTransport.Close()
+	assert.Equal(t, "fd", unixTransport.RemoteURI().Scheme())
+	assert.Equal(t, "unix://"+path, unixTransport.LocalURI().String())
+	assert.Equal(t, defn.Local, unixTransport.Scope())
+	assert.Equal(t, PersistencyPersistent, unixTransport.Persistency())
+
+	// TCP sockets make unicast TCP faces
+	tcpListener, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer tcpListener.Close()
+	tcpClient, err := net.Dial("tcp4", tcpListener.Addr().String())
+	require.NoError(t, err)
+	defer tcpClient.Close()
+	tcpURI := passFD(t, tcpClient.(*net.TCPConn))
+
+	transport, err = MakeFDTransport(tcpURI)
+	require.NoError(t, err)
+	tcpTransport, ok := transport.(*UnicastTCPTransport)
+	require.True(t, ok)
+	defer tcpTransport.Close()
+	assert.Equal(t, "tcp4://"+tcpListener.Addr().String(), tcpTransport.RemoteURI().String())
+	assert.Equal(t, "tcp4://"+tcpClient.LocalAddr().String(), tcpTransport.LocalURI().String())
+	assert.Equal(t, PersistencyPersistent, tcpTransport.Persistency())
+
+	// Datagram sockets are not supported
+	udpConn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer udpConn.Close()
+	_, err = MakeFDTransport(passFD(t, udpConn))
+	assert.ErrorContains(t, err, "unsupported socket type")
+
+	// Neither are standard streams nor other URIs
+	_, err = MakeFDTransport(defn.DecodeURIString("fd://1"))
+	assert.Error(t, err)
+	_, err = MakeFDTransport(defn.MakeUnixFaceURI(path))
+	assert.Error(t, err)
+}
@@ -10,6 +10,8 @@
 package impl
 
 import (
+	"errors"
+	"io"
 	"syscall"
 
 	"github.com/named-data/ndnd/fw/core"
@@ -29,3 +31,42 @@ func SyscallGetSocketSendQueueSize(c syscall.RawConn) uint64 {
 	})
 	return uint64(val)
 }
+
+// SyscallSetPathMTUDiscovery sets the Don't Fragment flag on packets sent on the specified socket.
+func SyscallSetPathMTUDiscovery(c syscall.RawConn, ipv6 bool) error {
+	// Unsupported at the moment
+	return errors.ErrUnsupported
+}
+
+// SyscallGetPathMTU returns the path MTU known by the kernel for the specified connected socket.
+func SyscallGetPathMTU(c syscall.RawConn, ipv6 bool) (int, error) {
+	// Unsupported at the moment
+	return 0, errors.ErrUnsupported
+}
+
+// SyscallSubscribeInterfaceChanges receives a message whenever a network interface changes.
+func SyscallSubscribeInterfaceChanges() (io.ReadCloser, error) {
+	// Unsupported at the moment
+	return nil, errors.ErrUnsupported
+}
+
+// SyscallGetPeerCredentials returns the process, user and group IDs of the peer
+// of the specified Unix socket.
+func SyscallGetPeerCredentials(c syscall.RawConn) (pid int32, uid uint32, gid uint32, err error) {
+	c.Control(func(fd uintptr) {
+		var cred *unix.Xucred
+		cred, err = unix.GetsockoptXucred(int(fd), unix.SOL_LOCAL, unix.LOCAL_PEERCRED)
+		if err != nil {
+			return
+		}
+		uid = cred.Uid
+		if cred.Ngroups > 0 {
+			gid = cred.Groups[0]
+		}
+
+		var peerPid int
+		peerPid, err = unix.GetsockoptInt(int(fd), unix.SOL_LOCAL, unix.LOCAL_PEERPID)
+		pid = int32(peerPid)
+	})
+	return
+}
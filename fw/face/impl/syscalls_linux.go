@@ -10,6 +10,8 @@
 package impl
 
 import (
+	"io"
+	"os"
 	"syscall"
 
 	"github.com/named-data/ndnd/fw/core"
@@ -29,3 +31,65 @@ func SyscallGetSocketSendQueueSize(c syscall.RawConn) uint64 {
 	})
 	return uint64(val)
 }
+
+// SyscallSetPathMTUDiscovery sets the Don't Fragment flag on packets sent on the specified
+// socket, so that the kernel discovers the path MTU instead of fragmenting packets.
+func SyscallSetPathMTUDiscovery(c syscall.RawConn, ipv6 bool) error {
+	var err error
+	c.Control(func(fd uintptr) {
+		if ipv6 {
+			err = unix.SetsockoptInt(int(fd), unix.IPPROTO_IPV6, unix.IPV6_MTU_DISCOVER, unix.IPV6_PMTUDISC_DO)
+		} else {
+			err = unix.SetsockoptInt(int(fd), unix.IPPROTO_IP, unix.IP_MTU_DISCOVER, unix.IP_PMTUDISC_DO)
+		}
+	})
+	return err
+}
+
+// SyscallGetPathMTU returns the path MTU known by the kernel for the specified connected socket.
+func SyscallGetPathMTU(c syscall.RawConn, ipv6 bool) (int, error) {
+	var mtu int
+	var err error
+	c.Control(func(fd uintptr) {
+		if ipv6 {
+			mtu, err = unix.GetsockoptInt(int(fd), unix.IPPROTO_IPV6, unix.IPV6_MTU)
+		} else {
+			mtu, err = unix.GetsockoptInt(int(fd), unix.IPPROTO_IP, unix.IP_MTU)
+		}
+	})
+	return mtu, err
+}
+
+// SyscallSubscribeInterfaceChanges opens a netlink socket that receives a message
+// whenever a network interface or one of its addresses changes.
+func SyscallSubscribeInterfaceChanges() (io.ReadCloser, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC|unix.SOCK_NONBLOCK, unix.NETLINK_ROUTE)
+	if err != nil {
+		return nil, err
+	}
+
+	addr := &unix.SockaddrNetlink{
+		Family: unix.AF_NETLINK,
+		Groups: unix.RTMGRP_LINK | unix.RTMGRP_IPV4_IFADDR | unix.RTMGRP_IPV6_IFADDR,
+	}
+	if err := unix.Bind(fd, addr); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+
+	// Non-blocking, so reads are interrupted when the file is closed
+	return os.NewFile(uintptr(fd), "netlink"), nil
+}
+
+// SyscallGetPeerCredentials returns the process, user and group IDs of the peer
+// of the specified Unix socket.
+func SyscallGetPeerCredentials(c syscall.RawConn) (pid int32, uid uint32, gid uint32, err error) {
+	c.Control(func(fd uintptr) {
+		var cred *unix.Ucred
+		cred, err = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+		if err == nil {
+			pid, uid, gid = cred.Pid, cred.Uid, cred.Gid
+		}
+	})
+	return
+}
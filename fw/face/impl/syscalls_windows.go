@@ -10,6 +10,8 @@
 package impl
 
 import (
+	"errors"
+	"io"
 	"syscall"
 
 	"golang.org/x/sys/windows"
@@ -30,3 +32,28 @@ func SyscallGetSocketSendQueueSize(c syscall.RawConn) uint64 {
 	// TODO: See if this is possible on windows
 	return 0
 }
+
+// SyscallSetPathMTUDiscovery sets the Don't Fragment flag on packets sent on the specified socket.
+func SyscallSetPathMTUDiscovery(c syscall.RawConn, ipv6 bool) error {
+	// Unsupported at the moment
+	return errors.ErrUnsupported
+}
+
+// SyscallGetPathMTU returns the path MTU known by the kernel for the specified connected socket.
+func SyscallGetPathMTU(c syscall.RawConn, ipv6 bool) (int, error) {
+	// Unsupported at the moment
+	return 0, errors.ErrUnsupported
+}
+
+// SyscallSubscribeInterfaceChanges receives a message whenever a network interface changes.
+func SyscallSubscribeInterfaceChanges() (io.ReadCloser, error) {
+	// Unsupported at the moment
+	return nil, errors.ErrUnsupported
+}
+
+// SyscallGetPeerCredentials returns the process, user and group IDs of the peer
+// of the specified Unix socket.
+func SyscallGetPeerCredentials(c syscall.RawConn) (pid int32, uid uint32, gid uint32, err error) {
+	// Unsupported at the moment
+	return 0, 0, 0, errors.ErrUnsupported
+}
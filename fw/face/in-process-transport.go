@@ -0,0 +1,141 @@
+package face
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/named-data/ndnd/fw/core"
+	defn "github.com/named-data/ndnd/fw/defn"
+)
+
+// ErrTransportClosed is returned when sending on a closed in-process transport.
+var ErrTransportClosed = errors.New("transport is closed")
+
+// InProcessTransport connects an application running in the same program as the
+// forwarder, exchanging frames over channels instead of a socket.
+type InProcessTransport struct {
+	transportBase
+	toApp   chan []byte // frames sent by the forwarder to the application
+	fromApp chan []byte // frames sent by the application to the forwarder
+}
+
+// MakeInProcessTransport makes an InProcessTransport.
+func MakeInProcessTransport() *InProcessTransport {
+	t := new(InProcessTransport)
+	t.makeTransportBase(
+		defn.MakeInternalFaceURI(),
+		defn.MakeInternalFaceURI(),
+		PersistencyPersistent,
+		defn.Local,
+		defn.PointToPoint,
+		defn.MaxNDNPacketSize)
+	t.toApp = make(chan []byte, faceQueueSize)
+	t.fromApp = make(chan []byte, faceQueueSize)
+	t.running.Store(true)
+	return t
+}
+
+// RegisterInProcessTransport creates, registers, and starts an InProcessTransport.
+func RegisterInProcessTransport() (LinkService, *InProcessTransport) {
+	transport := MakeInProcessTransport()
+
+	options := MakeNDNLPLinkServiceOptions()
+	options.IsFragmentationEnabled = false // frames are never lost or reordered
+	link := MakeNDNLPLinkService(transport, options)
+	link.Run(nil)
+
+	return link, transport
+}
+
+func (t *InProcessTransport) String() string {
+	return "InProcessTransport, FaceID=" + strconv.FormatUint(t.faceID, 10) +
+		", RemoteURI=" + t.remoteURI.String() + ", LocalURI=" + t.localURI.String()
+}
+
+// SetPersistency changes the persistency of the face.
+func (t *InProcessTransport) SetPersistency(persistency Persistency) bool {
+	if persistency == t.persistency {
+		return true
+	}
+
+	if persistency == PersistencyPersistent {
+		t.persistency = persistency
+		return true
+	}
+
+	return false
+}
+
+// GetSendQueueSize returns the current size of the send queue.
+func (t *InProcessTransport) GetSendQueueSize() uint64 {
+	return uint64(len(t.toApp))
+}
+
+// SendToForwarder passes a frame sent by the application to the forwarder.
+// The frame must not be modified afterwards.
+func (t *InProcessTransport) SendToForwarder(frame []byte) error {
+	select {
+	case <-t.closeCh:
+		return ErrTransportClosed
+	default:
+	}
+
+	select {
+	case t.fromApp <- frame:
+		return nil
+	case <-t.closeCh:
+		return ErrTransportClosed
+	}
+}
+
+// ReceiveFromForwarder returns the next frame sent by the forwarder to the application.
+// It returns false once the transport is closed.
+func (t *InProcessTransport) ReceiveFromForwarder() ([]byte, bool) {
+	select {
+	case frame := <-t.toApp:
+		return frame, true
+	case <-t.closeCh:
+		return nil, false
+	}
+}
+
+func (t *InProcessTransport) sendFrame(frame []byte) {
+	if len(frame) > t.MTU() {
+		core.LogWarn(t, "Attempted to send frame larger than MTU - DROP")
+		return
+	}
+
+	frameCopy := make([]byte, len(frame))
+	copy(frameCopy, frame)
+
+	// Wait for the application to keep up, as with a stream socket
+	select {
+	case t.toApp <- frameCopy:
+		t.nOutBytes += uint64(len(frame))
+	case <-t.closeCh:
+	}
+}
+
+func (t *InProcessTransport) runReceive() {
+	for {
+		select {
+		case frame := <-t.fromApp:
+			if len(frame) > defn.MaxNDNPacketSize {
+				core.LogWarn(t, "Application trying to send too much data - DROP")
+				continue
+			}
+
+			t.nInBytes += uint64(len(frame))
+			t.linkService.handleIncomingFrame(frame)
+		case <-t.closeCh:
+			return
+		}
+	}
+}
+
+// Close closes the transport, and the face with it.
+func (t *InProcessTransport) Close() {
+	if t.markClosed() {
+		t.setRunning(false)
+	}
+}
@@ -9,6 +9,7 @@ package face
 
 import (
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/named-data/ndnd/fw/core"
@@ -23,6 +24,15 @@ var congestionMarking bool
 // lockThreadsToCores determines whether face threads will be locked to logical cores.
 var lockThreadsToCores bool
 
+// reconnectInitialInterval is the delay before the first reconnection attempt of permanent faces.
+var reconnectInitialInterval time.Duration
+
+// reconnectMaxInterval is the maximum delay between reconnection attempts.
+var reconnectMaxInterval time.Duration
+
+// reconnectJitter is the random variation of reconnection delays, as a fraction of the delay.
+var reconnectJitter float64
+
 // UDPUnicastPort is the standard unicast UDP port for NDN.
 var UDPUnicastPort uint16
 
@@ -35,9 +45,21 @@ var udp4MulticastAddress string
 // udp6MulticastAddress is the standard multicast UDP6 address for NDN.
 var udp6MulticastAddress string
 
+// udpMulticastAdHoc determines whether multicast UDP faces have the ad hoc link type.
+var udpMulticastAdHoc bool
+
+// udpMulticastWhitelist contains the patterns of interfaces where multicast UDP faces are created.
+var udpMulticastWhitelist []string
+
+// udpMulticastBlacklist contains the patterns of interfaces where multicast UDP faces are not created.
+var udpMulticastBlacklist []string
+
 // udpLifetime is the lifetime of on-demand UDP faces after they become idle.
 var udpLifetime time.Duration
 
+// udpPathMTUDiscovery determines whether unicast UDP faces use path MTU discovery.
+var udpPathMTUDiscovery bool
+
 // TCPUnicastPort is the standard unicast TCP port for NDN.
 var TCPUnicastPort uint16
 
@@ -47,17 +69,45 @@ var tcpLifetime time.Duration
 // UnixSocketPath is the standard Unix socket file path for NDN.
 var UnixSocketPath string
 
+// unixSocketOwner is the owner of the Unix socket file (user name or UID), unchanged if empty.
+var unixSocketOwner string
+
+// unixSocketGroup is the group of the Unix socket file (group name or GID), unchanged if empty.
+var unixSocketGroup string
+
+// unixSocketMode is the permissions of the Unix socket file.
+var unixSocketMode os.FileMode
+
+// QUICInsecureSkipVerify disables verification of the certificates of remote QUIC endpoints.
+var QUICInsecureSkipVerify bool
+
 // Configure configures the face system.
 func Configure() {
 	faceQueueSize = core.GetConfig().Faces.QueueSize
 	congestionMarking = core.GetConfig().Faces.CongestionMarking
 	lockThreadsToCores = core.GetConfig().Faces.LockThreadsToCores
+	reconnectInitialInterval = time.Duration(core.GetConfig().Faces.Reconnect.InitialInterval) * time.Millisecond
+	reconnectMaxInterval = time.Duration(core.GetConfig().Faces.Reconnect.MaxInterval) * time.Millisecond
+	reconnectJitter = core.GetConfig().Faces.Reconnect.Jitter
 	UDPUnicastPort = core.GetConfig().Faces.Udp.PortUnicast
 	TCPUnicastPort = core.GetConfig().Faces.Tcp.PortUnicast
 	UDPMulticastPort = core.GetConfig().Faces.Udp.PortMulticast
 	udp4MulticastAddress = core.GetConfig().Faces.Udp.MulticastAddressIpv4
 	udp6MulticastAddress = core.GetConfig().Faces.Udp.MulticastAddressIpv6
+	udpMulticastAdHoc = core.GetConfig().Faces.Udp.MulticastAdHoc
+	udpMulticastWhitelist = core.GetConfig().Faces.Udp.Whitelist
+	udpMulticastBlacklist = core.GetConfig().Faces.Udp.Blacklist
 	udpLifetime = time.Duration(core.GetConfig().Faces.Udp.Lifetime) * time.Second
+	udpPathMTUDiscovery = core.GetConfig().Faces.Udp.PathMtuDiscovery
 	tcpLifetime = time.Duration(core.GetConfig().Faces.Tcp.Lifetime) * time.Second
 	UnixSocketPath = os.ExpandEnv(core.GetConfig().Faces.Unix.SocketPath)
+	unixSocketOwner = core.GetConfig().Faces.Unix.SocketOwner
+	unixSocketGroup = core.GetConfig().Faces.Unix.SocketGroup
+	if mode, err := strconv.ParseUint(core.GetConfig().Faces.Unix.SocketMode, 8, 32); err == nil {
+		unixSocketMode = os.FileMode(mode) & os.ModePerm
+	} else {
+		core.LogWarn("Face", "Invalid Unix socket mode ", core.GetConfig().Faces.Unix.SocketMode, " - using 0666")
+		unixSocketMode = 0666
+	}
+	QUICInsecureSkipVerify = core.GetConfig().Faces.Quic.InsecureSkipVerify
 }
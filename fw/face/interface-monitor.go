@@ -0,0 +1,152 @@
+package face
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/face/impl"
+)
+
+// interfaceSettleTime is how long the monitor waits for further changes before
+// notifying, since a change of interface usually comes with several messages.
+const interfaceSettleTime = 500 * time.Millisecond
+
+// interfacePollInterval is how often interfaces are polled if change notifications are unsupported.
+const interfacePollInterval = 10 * time.Second
+
+// InterfaceMonitor notifies changes of network interfaces and their addresses,
+// using netlink on Linux and polling on other platforms.
+type InterfaceMonitor struct {
+	onChange func()
+	events   io.ReadCloser
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+// NewInterfaceMonitor creates an interface monitor that calls onChange after changes.
+func NewInterfaceMonitor(onChange func()) *InterfaceMonitor {
+	m := &InterfaceMonitor{
+		onChange: onChange,
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+
+	var err error
+	m.events, err = impl.SyscallSubscribeInterfaceChanges()
+	if err != nil {
+		core.LogDebug(m, "Interface change notifications unavailable (", err, ") - polling instead")
+		m.events = nil
+	}
+	return m
+}
+
+func (m *InterfaceMonitor) String() string {
+	return "InterfaceMonitor"
+}
+
+// Run watches interfaces until the monitor is closed.
+func (m *InterfaceMonitor) Run() {
+	defer close(m.stopped)
+
+	if m.events == nil {
+		m.poll()
+		return
+	}
+
+	// Read change notifications
+	changed := make(chan struct{}, 1)
+	go func() {
+		buf := make([]byte, 1<<16)
+		for {
+			_, err := m.events.Read(buf)
+			if errors.Is(err, os.ErrClosed) {
+				return
+			} else if err != nil {
+				// e.g., ENOBUFS if messages were lost, so check anyway
+				core.LogDebug(m, "Unable to read change notification: ", err)
+			}
+
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-changed:
+		}
+
+		// Let the changes settle
+		select {
+		case <-m.stop:
+			return
+		case <-time.After(interfaceSettleTime):
+		}
+		select {
+		case <-changed:
+		default:
+		}
+
+		core.LogDebug(m, "Network interfaces changed")
+		m.onChange()
+	}
+}
+
+// poll compares snapshots of the interfaces periodically.
+func (m *InterfaceMonitor) poll() {
+	ticker := time.NewTicker(interfacePollInterval)
+	defer ticker.Stop()
+
+	last := interfaceSnapshot()
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+		}
+
+		if snapshot := interfaceSnapshot(); snapshot != last {
+			last = snapshot
+			core.LogDebug(m, "Network interfaces changed")
+			m.onChange()
+		}
+	}
+}
+
+// interfaceSnapshot describes the interfaces and their addresses.
+func interfaceSnapshot() string {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, iface := range ifaces {
+		fmt.Fprintf(&b, "%d %s %s", iface.Index, iface.Name, iface.Flags)
+		addrs, _ := iface.Addrs()
+		for _, addr := range addrs {
+			b.WriteString(" " + addr.String())
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Close stops the monitor.
+func (m *InterfaceMonitor) Close() {
+	close(m.stop)
+	if m.events != nil {
+		m.events.Close()
+	}
+	<-m.stopped
+}
@@ -10,6 +10,7 @@ package face
 import (
 	"bytes"
 	"net"
+	"path"
 )
 
 // InterfaceByMAC gets an interface by its MAC address.
@@ -51,3 +52,41 @@ func InterfaceByIP(ip net.IP) (*net.Interface, error) {
 
 	return nil, nil
 }
+
+// InterfaceMatches returns whether an address of an interface matches a pattern, which is
+// either an interface name (with * wildcards), a MAC address, or a subnet in CIDR notation.
+// Name and MAC address patterns match all addresses of the interface, while subnet patterns
+// only match the addresses in the subnet, so that e.g. the IPv6 addresses of an interface
+// are not selected by an IPv4 subnet.
+func InterfaceMatches(iface *net.Interface, ip net.IP, pattern string) bool {
+	if _, subnet, err := net.ParseCIDR(pattern); err == nil {
+		return subnet.Contains(ip)
+	}
+
+	if mac, err := net.ParseMAC(pattern); err == nil {
+		return bytes.Equal(iface.HardwareAddr, mac)
+	}
+
+	match, _ := path.Match(pattern, iface.Name)
+	return match
+}
+
+// MulticastAddressAllowed returns whether a multicast UDP face may be created on an address
+// of an interface, according to the configured whitelist and blacklist.
+func MulticastAddressAllowed(iface *net.Interface, ip net.IP) bool {
+	return addressAllowed(iface, ip, udpMulticastWhitelist, udpMulticastBlacklist)
+}
+
+// addressAllowed returns whether an address matches the whitelist and not the blacklist.
+func addressAllowed(iface *net.Interface, ip net.IP, whitelist []string, blacklist []string) bool {
+	return addressMatchesAny(iface, ip, whitelist) && !addressMatchesAny(iface, ip, blacklist)
+}
+
+func addressMatchesAny(iface *net.Interface, ip net.IP, patterns []string) bool {
+	for _, pattern := range patterns {
+		if InterfaceMatches(iface, ip, pattern) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package face
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterfaceMatches(t *testing.T) {
+	eth0 := &net.Interface{Name: "eth0", HardwareAddr: net.HardwareAddr{0x02, 0, 0, 0, 0, 0x01}}
+	ipv4 := net.ParseIP("192.168.1.10")
+	ipv6 := net.ParseIP("fe80::1")
+
+	tests := []struct {
+		pattern string
+		ip      net.IP
+		match   bool
+	}{
+		{"*", ipv4, true},
+		{"eth0", ipv6, true},
+		{"eth*", ipv4, true},
+		{"wlan*", ipv4, false},
+		{"02:00:00:00:00:01", ipv6, true},
+		{"02:00:00:00:00:02", ipv4, false},
+		{"192.168.1.0/24", ipv4, true},
+		{"192.168.2.0/24", ipv4, false},
+		// Subnets match the address, not the interface
+		{"192.168.1.0/24", ipv6, false},
+		{"fe80::/64", ipv6, true},
+		{"fe80::/64", ipv4, false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.match, InterfaceMatches(eth0, test.ip, test.pattern), "%s with %s", test.pattern, test.ip)
+	}
+}
+
+func TestAddressAllowed(t *testing.T) {
+	eth0 := &net.Interface{Name: "eth0", HardwareAddr: net.HardwareAddr{0x02, 0, 0, 0, 0, 0x01}}
+	wlan0 := &net.Interface{Name: "wlan0", HardwareAddr: net.HardwareAddr{0x02, 0, 0, 0, 0, 0x02}}
+	lanIP := net.ParseIP("192.168.1.10")
+	otherIP := net.ParseIP("10.0.0.10")
+	linkLocal := net.ParseIP("fe80::1")
+
+	tests := []struct {
+		name      string
+		whitelist []string
+		blacklist []string
+		iface     *net.Interface
+		ip        net.IP
+		allowed   bool
+	}{
+		{"default", []string{"*"}, nil, eth0, lanIP, true},
+		{"empty whitelist", nil, nil, eth0, lanIP, false},
+		{"whitelisted name", []string{"eth*"}, nil, eth0, lanIP, true},
+		{"not whitelisted name", []string{"eth*"}, nil, wlan0, lanIP, false},
+		{"blacklisted name", []string{"*"}, []string{"wlan0"}, wlan0, lanIP, false},
+		{"blacklisted MAC", []string{"*"}, []string{"02:00:00:00:00:01"}, eth0, linkLocal, false},
+		{"blacklist wins", []string{"eth0"}, []string{"eth0"}, eth0, lanIP, false},
+		{"whitelisted subnet", []string{"192.168.1.0/24"}, nil, eth0, lanIP, true},
+		{"other address of whitelisted subnet", []string{"192.168.1.0/24"}, nil, eth0, otherIP, false},
+		{"IPv6 address of whitelisted subnet", []string{"192.168.1.0/24"}, nil, eth0, linkLocal, false},
+		{"blacklisted subnet", []string{"*"}, []string{"fe80::/10"}, eth0, linkLocal, false},
+		{"other address of blacklisted subnet", []string{"*"}, []string{"fe80::/10"}, eth0, lanIP, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.allowed, addressAllowed(test.iface, test.ip, test.whitelist, test.blacklist))
+		})
+	}
+}
@@ -10,6 +10,7 @@ package face
 import (
 	"encoding/binary"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/named-data/ndnd/fw/core"
@@ -43,6 +44,10 @@ type LinkService interface {
 
 	// Add a packet to the send queue for this link service
 	SendPacket(out dispatch.OutPkt)
+	// Mark the next packet sent with a congestion mark
+	SignalCongestion()
+	// Number of packets waiting to be sent
+	queuedPackets() int
 	// Synchronously handle an incoming frame and dispatch to fw
 	handleIncomingFrame(frame []byte)
 
@@ -65,6 +70,9 @@ type linkServiceBase struct {
 	stopped   chan bool
 	sendQueue chan dispatch.OutPkt
 
+	// congestionSignaled is set when the next packet sent must be congestion marked
+	congestionSignaled atomic.Bool
+
 	// Counters
 	nInInterests  uint64
 	nInData       uint64
@@ -212,6 +220,7 @@ func (l *linkServiceBase) SendPacket(out dispatch.OutPkt) {
 	case l.sendQueue <- out:
 		// Packet queued successfully
 		core.LogTrace(l, "Queued packet for Link Service")
+		capturePacket(l, out.Pkt, false)
 	default:
 		// Drop packet due to congestion
 		core.LogDebug(l, "Dropped packet due to congestion")
@@ -220,16 +229,27 @@ func (l *linkServiceBase) SendPacket(out dispatch.OutPkt) {
 	}
 }
 
+func (l *linkServiceBase) queuedPackets() int {
+	return len(l.sendQueue)
+}
+
+// SignalCongestion marks the next packet sent on this link service with a congestion mark.
+func (l *linkServiceBase) SignalCongestion() {
+	l.congestionSignaled.Store(true)
+}
+
 func (l *linkServiceBase) dispatchInterest(pkt *defn.Pkt) {
 	if pkt.L3.Interest == nil {
 		panic("dispatchInterest called with packet that is not Interest")
 	}
 
-	// Store name for easy access
+	// Store name and its hash for easy access
 	pkt.Name = pkt.L3.Interest.NameV
+	pkt.NameHash = pkt.Name.Hash()
+	fw.StartTrace(pkt, "interest")
 
 	// Hash name to thread
-	thread := fw.HashNameToFwThread(pkt.Name)
+	thread := fw.HashToFwThread(pkt.Name, pkt.NameHash)
 	core.LogTrace(l, "Dispatched Interest to thread ", thread)
 	dispatch.GetFWThread(thread).QueueInterest(pkt)
 }
@@ -241,9 +261,11 @@ func (l *linkServiceBase) dispatchData(pkt *defn.Pkt) {
 
 	// Store name for easy access
 	pkt.Name = pkt.L3.Data.NameV
+	fw.StartTrace(pkt, "data")
 
 	// Decode PitToken. If it's for us, it's a uint16 + uint32.
 	if len(pkt.PitToken) == 6 {
+		pkt.NameHash = pkt.Name.Hash()
 		thread := binary.BigEndian.Uint16(pkt.PitToken)
 		fwThread := dispatch.GetFWThread(int(thread))
 		if fwThread == nil {
@@ -260,7 +282,9 @@ func (l *linkServiceBase) dispatchData(pkt *defn.Pkt) {
 	// threads matching every prefix. We need to do this because producers do
 	// not attach PIT tokens to their data packets.
 	if l.Scope() == defn.Local {
-		for i, match := range fw.HashNameToAllPrefixFwThreads(pkt.Name) {
+		prefixHash := pkt.Name.PrefixHash()
+		pkt.NameHash = prefixHash[len(prefixHash)-1]
+		for i, match := range fw.PrefixHashToAllFwThreads(pkt.Name, prefixHash) {
 			if match {
 				core.LogTrace(l, "Prefix dispatched local-origin Data packet to thread ", i)
 				dispatch.GetFWThread(i).QueueData(pkt)
@@ -270,7 +294,17 @@ func (l *linkServiceBase) dispatchData(pkt *defn.Pkt) {
 	}
 
 	// Only exact-match for now (no CanBePrefix)
-	thread := fw.HashNameToFwThread(pkt.Name)
+	pkt.NameHash = pkt.Name.Hash()
+	thread := fw.HashToFwThread(pkt.Name, pkt.NameHash)
 	core.LogTrace(l, "Dispatched Data to thread ", thread)
 	dispatch.GetFWThread(thread).QueueData(pkt)
 }
+
+// NumQueuedPackets returns the number of packets waiting in the send queues of all faces.
+func NumQueuedPackets() int {
+	count := 0
+	for _, linkService := range FaceTable.GetAll() {
+		count += linkService.queuedPackets()
+	}
+	return count
+}
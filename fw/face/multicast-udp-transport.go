@@ -44,12 +44,17 @@ func MakeMulticastUDPTransport(localURI *defn.URI) (*MulticastUDPTransport, erro
 		remote = fmt.Sprintf("udp6://[%s]:%d", udp6MulticastAddress, UDPMulticastPort)
 	}
 
+	linkType := defn.MultiAccess
+	if udpMulticastAdHoc {
+		linkType = defn.AdHoc
+	}
+
 	// Create transport
 	t := &MulticastUDPTransport{}
 	t.makeTransportBase(
 		defn.DecodeURIString(remote),
 		localURI, PersistencyPermanent,
-		defn.NonLocal, defn.MultiAccess,
+		defn.NonLocal, linkType,
 		defn.MaxNDNPacketSize)
 
 	// Format group and local addresses
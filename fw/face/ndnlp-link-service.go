@@ -177,13 +177,13 @@ func sendPacket(l *NDNLPLinkService, out dispatch.OutPkt) {
 	wire := pkt.Raw
 
 	// Counters
-	if pkt.L3.Interest != nil {
+	if pkt.L3.Interest != nil && pkt.NackReason == nil {
 		l.nOutInterests++
 	} else if pkt.L3.Data != nil {
 		l.nOutData++
 	}
 
-	now := time.Now()
+	now := core.Now()
 
 	effectiveMtu := l.transport.MTU() - l.headerOverhead
 	if pkt.PitToken != nil {
@@ -247,6 +247,9 @@ func sendPacket(l *NDNLPLinkService, out dispatch.OutPkt) {
 
 		l.congestionCheck += uint64(len(wire)) // approx
 	}
+	if l.congestionSignaled.Swap(false) {
+		congestionMark = utils.IdPtr[uint64](1) // signaled by forwarding
+	}
 
 	// Send fragment(s)
 	for _, fragment := range fragments {
@@ -265,6 +268,11 @@ func sendPacket(l *NDNLPLinkService, out dispatch.OutPkt) {
 			fragment.CongestionMark = congestionMark
 		}
 
+		// Network Nack
+		if pkt.NackReason != nil {
+			fragment.Nack = &spec.NetworkNack{Reason: *pkt.NackReason}
+		}
+
 		pkt := &spec.Packet{
 			LpPacket: fragment,
 		}
@@ -296,7 +304,7 @@ func (l *NDNLPLinkService) handleIncomingFrame(frame []byte) {
 		IncomingFaceID: utils.IdPtr(l.faceID),
 	}
 
-	L2, _, err := spec.ReadPacket(enc.NewBufferReader(wire))
+	L2, ctx, err := spec.ReadPacket(enc.NewBufferReader(wire))
 	if err != nil {
 		core.LogError(l, err)
 		return
@@ -306,6 +314,9 @@ func (l *NDNLPLinkService) handleIncomingFrame(frame []byte) {
 		// Bare Data or Interest packet
 		pkt.Raw = wire
 		pkt.L3 = L2
+		if L2.Data != nil {
+			pkt.SigCovered = ctx.Data_context.SigCovered()
+		}
 	} else {
 		// NDNLPv2 frame
 		LP := L2.LpPacket
@@ -344,6 +355,12 @@ func (l *NDNLPLinkService) handleIncomingFrame(frame []byte) {
 			return
 		}
 
+		// Network Nacks are not processed by forwarding
+		if LP.Nack != nil {
+			core.LogDebug(l, "Received Nack with reason ", LP.Nack.Reason, " - DROP")
+			return
+		}
+
 		// Congestion mark
 		pkt.CongestionMark = LP.CongestionMark
 
@@ -370,14 +387,19 @@ func (l *NDNLPLinkService) handleIncomingFrame(frame []byte) {
 		}
 
 		// Parse inner packet in place
-		L3, _, err := spec.ReadPacket(enc.NewBufferReader(wire))
+		L3, ctx, err := spec.ReadPacket(enc.NewBufferReader(wire))
 		if err != nil {
 			return
 		}
 		pkt.Raw = wire
 		pkt.L3 = L3
+		if L3.Data != nil {
+			pkt.SigCovered = ctx.Data_context.SigCovered()
+		}
 	}
 
+	capturePacket(&l.linkServiceBase, pkt, true)
+
 	// Dispatch and update counters
 	if pkt.L3.Interest != nil {
 		l.nInInterests++
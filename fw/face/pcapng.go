@@ -0,0 +1,127 @@
+package face
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+// pcapngLinkType is the link type of captured packets. There is no registered
+// link type for bare NDN TLV packets, so the first user-defined link type
+// (LINKTYPE_USER0) is used. Wireshark decodes it with the NDN dissector after
+// mapping User DLT 0 to the "ndn" protocol.
+const pcapngLinkType = 147
+
+// pcapng block types and options.
+const (
+	pcapngSectionHeader    = 0x0A0D0D0A
+	pcapngInterfaceDesc    = 0x00000001
+	pcapngEnhancedPacket   = 0x00000006
+	pcapngByteOrderMagic   = 0x1A2B3C4D
+	pcapngOptEndOfOpt      = 0
+	pcapngOptIfName        = 2
+	pcapngOptEpbFlags      = 2
+	pcapngFlagInbound      = 0x1
+	pcapngFlagOutbound     = 0x2
+	pcapngSectionHeaderLen = 28
+)
+
+// pcapngWriter writes packets to a pcapng stream, with one interface per face.
+// It is not safe for concurrent use.
+type pcapngWriter struct {
+	w          io.Writer
+	interfaces map[uint64]uint32 // face ID to interface ID
+	written    uint64
+}
+
+func newPcapngWriter(w io.Writer) (*pcapngWriter, error) {
+	p := &pcapngWriter{w: w, interfaces: make(map[uint64]uint32)}
+
+	block := make([]byte, pcapngSectionHeaderLen)
+	binary.LittleEndian.PutUint32(block[0:], pcapngSectionHeader)
+	binary.LittleEndian.PutUint32(block[4:], pcapngSectionHeaderLen)
+	binary.LittleEndian.PutUint32(block[8:], pcapngByteOrderMagic)
+	binary.LittleEndian.PutUint16(block[12:], 1) // major version
+	binary.LittleEndian.PutUint16(block[14:], 0) // minor version
+	binary.LittleEndian.PutUint64(block[16:], ^uint64(0))
+	binary.LittleEndian.PutUint32(block[24:], pcapngSectionHeaderLen)
+	return p, p.write(block)
+}
+
+// blockSize returns the number of bytes written by writePacket.
+func (p *pcapngWriter) blockSize(faceID uint64, ifName string, pkt []byte) uint64 {
+	size := packetBlockSize(pkt)
+	if _, ok := p.interfaces[faceID]; !ok {
+		size += interfaceBlockSize(ifName)
+	}
+	return uint64(size)
+}
+
+func packetBlockSize(pkt []byte) int {
+	return 28 + pad4(len(pkt)) + 16
+}
+
+func interfaceBlockSize(name string) int {
+	return 20 + pad4(len(name)) + 8
+}
+
+// writePacket writes a packet sent or received on a face.
+func (p *pcapngWriter) writePacket(faceID uint64, ifName string, t time.Time, pkt []byte, inbound bool) error {
+	ifID, ok := p.interfaces[faceID]
+	if !ok {
+		ifID = uint32(len(p.interfaces))
+		if err := p.writeInterface(ifName); err != nil {
+			return err
+		}
+		p.interfaces[faceID] = ifID
+	}
+
+	flags := uint32(pcapngFlagOutbound)
+	if inbound {
+		flags = pcapngFlagInbound
+	}
+
+	size := packetBlockSize(pkt)
+	block := make([]byte, size)
+	ts := uint64(t.UnixMicro())
+	binary.LittleEndian.PutUint32(block[0:], pcapngEnhancedPacket)
+	binary.LittleEndian.PutUint32(block[4:], uint32(size))
+	binary.LittleEndian.PutUint32(block[8:], ifID)
+	binary.LittleEndian.PutUint32(block[12:], uint32(ts>>32))
+	binary.LittleEndian.PutUint32(block[16:], uint32(ts))
+	binary.LittleEndian.PutUint32(block[20:], uint32(len(pkt)))
+	binary.LittleEndian.PutUint32(block[24:], uint32(len(pkt)))
+	copy(block[28:], pkt)
+	opts := block[28+pad4(len(pkt)):]
+	binary.LittleEndian.PutUint16(opts[0:], pcapngOptEpbFlags)
+	binary.LittleEndian.PutUint16(opts[2:], 4)
+	binary.LittleEndian.PutUint32(opts[4:], flags)
+	binary.LittleEndian.PutUint32(opts[8:], pcapngOptEndOfOpt)
+	binary.LittleEndian.PutUint32(block[size-4:], uint32(size))
+	return p.write(block)
+}
+
+func (p *pcapngWriter) writeInterface(name string) error {
+	size := interfaceBlockSize(name)
+	block := make([]byte, size)
+	binary.LittleEndian.PutUint32(block[0:], pcapngInterfaceDesc)
+	binary.LittleEndian.PutUint32(block[4:], uint32(size))
+	binary.LittleEndian.PutUint16(block[8:], pcapngLinkType)
+	binary.LittleEndian.PutUint32(block[12:], 0) // no snap length
+	binary.LittleEndian.PutUint16(block[16:], pcapngOptIfName)
+	binary.LittleEndian.PutUint16(block[18:], uint16(len(name)))
+	copy(block[20:], name)
+	// opt_endofopt is left zeroed
+	binary.LittleEndian.PutUint32(block[size-4:], uint32(size))
+	return p.write(block)
+}
+
+func (p *pcapngWriter) write(block []byte) error {
+	n, err := p.w.Write(block)
+	p.written += uint64(n)
+	return err
+}
+
+func pad4(n int) int {
+	return (n + 3) &^ 3
+}
@@ -0,0 +1,197 @@
+package face
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	defn "github.com/named-data/ndnd/fw/defn"
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/quic-go/webtransport-go"
+)
+
+// QUICListenerConfig contains QUICListener configuration.
+type QUICListenerConfig struct {
+	Bind             string
+	Port             uint16
+	TLSCert          string
+	TLSKey           string
+	WebTransportPath string
+}
+
+func (cfg QUICListenerConfig) String() string {
+	addr := net.JoinHostPort(cfg.Bind, strconv.FormatUint(uint64(cfg.Port), 10))
+	if cfg.TLSCert == "" {
+		return "QUIC listener at " + addr + " with self-signed certificate"
+	}
+	return fmt.Sprintf("QUIC listener at %s with TLS cert %s and key %s", addr, cfg.TLSCert, cfg.TLSKey)
+}
+
+// QUICListener listens for incoming QUIC connections carrying NDN packets,
+// and for WebTransport sessions over HTTP/3 on the same port.
+type QUICListener struct {
+	localURI  *defn.URI
+	listener  *quic.Listener
+	wtServer  webtransport.Server
+	tlsConfig *tls.Config
+}
+
+// NewQUICListener creates a QUIC listener. If no certificate is configured,
+// a self-signed certificate is generated, whose hash can be passed to browsers
+// in the serverCertificateHashes option of WebTransport.
+func NewQUICListener(cfg QUICListenerConfig) (*QUICListener, error) {
+	var cert tls.Certificate
+	var err error
+	if cfg.TLSCert != "" {
+		cert, err = tls.LoadX509KeyPair(cfg.TLSCert, cfg.TLSKey)
+		if err != nil {
+			return nil, fmt.Errorf("tls.LoadX509KeyPair(%s %s): %w", cfg.TLSCert, cfg.TLSKey, err)
+		}
+	} else {
+		cert, err = makeSelfSignedCertificate()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	l := &QUICListener{
+		localURI: defn.MakeQUICFaceURI(cfg.Bind, cfg.Port),
+		tlsConfig: &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			NextProtos:   []string{quicALPN, http3.NextProtoH3},
+			MinVersion:   tls.VersionTLS13,
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(cfg.WebTransportPath, l.handleWebTransport)
+	l.wtServer.H3.Handler = mux
+	l.wtServer.CheckOrigin = func(r *http.Request) bool { return true }
+
+	if cfg.TLSCert == "" {
+		hash := sha256.Sum256(cert.Leaf.Raw)
+		core.LogInfo(l, "Self-signed certificate SHA-256 hash is ", hex.EncodeToString(hash[:]))
+	}
+	return l, nil
+}
+
+func (l *QUICListener) String() string {
+	return "QUICListener, " + l.localURI.String()
+}
+
+// Run starts the QUIC listener.
+func (l *QUICListener) Run() {
+	addr := net.JoinHostPort(l.localURI.Path(), strconv.FormatUint(uint64(l.localURI.Port()), 10))
+	listener, err := quic.ListenAddr(addr, l.tlsConfig, quicConfig())
+	if err != nil {
+		core.LogError(l, "Unable to start QUIC listener: ", err)
+		return
+	}
+	l.listener = listener
+
+	// Run accept loop
+	for !core.ShouldQuit.Load() {
+		conn, err := listener.Accept(context.Background())
+		if err != nil {
+			if !errors.Is(err, quic.ErrServerClosed) {
+				core.LogWarn(l, "Unable to accept connection: ", err)
+			}
+			return
+		}
+
+		if conn.ConnectionState().TLS.NegotiatedProtocol == http3.NextProtoH3 {
+			go l.wtServer.ServeQUICConn(conn)
+			continue
+		}
+
+		newTransport, err := acceptQUICTransport(conn, func() { conn.CloseWithError(0, "") }, l.localURI)
+		if err != nil {
+			core.LogError(l, "Failed to create new QUIC transport: ", err)
+			conn.CloseWithError(0, "")
+			continue
+		}
+
+		core.LogInfo(l, "Accepting new QUIC face ", newTransport.RemoteURI())
+		MakeNDNLPLinkService(newTransport, MakeNDNLPLinkServiceOptions()).Run(nil)
+	}
+}
+
+func (l *QUICListener) handleWebTransport(w http.ResponseWriter, r *http.Request) {
+	session, err := l.wtServer.Upgrade(w, r)
+	if err != nil {
+		core.LogWarn(l, "Unable to upgrade WebTransport session from ", r.RemoteAddr, ": ", err)
+		return
+	}
+
+	newTransport, err := acceptQUICTransport(session, func() { session.CloseWithError(0, "") }, l.localURI)
+	if err != nil {
+		core.LogError(l, "Failed to create new WebTransport transport: ", err)
+		session.CloseWithError(0, "")
+		return
+	}
+
+	core.LogInfo(l, "Accepting new WebTransport face ", newTransport.RemoteURI())
+	MakeNDNLPLinkService(newTransport, MakeNDNLPLinkServiceOptions()).Run(nil)
+
+	// The session is closed when the handler returns
+	<-session.Context().Done()
+}
+
+// Close stops the QUIC listener.
+func (l *QUICListener) Close() {
+	core.LogInfo(l, "Stopping listener")
+	l.wtServer.Close()
+	if l.listener != nil {
+		l.listener.Close()
+	}
+}
+
+// makeSelfSignedCertificate generates a certificate acceptable for WebTransport
+// serverCertificateHashes (ECDSA, valid for less than 14 days).
+func makeSelfSignedCertificate() (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(now.UnixNano()),
+		Subject:      pkix.Name{CommonName: "yanfd"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(13 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}, nil
+}
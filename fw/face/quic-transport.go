@@ -0,0 +1,254 @@
+package face
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	defn "github.com/named-data/ndnd/fw/defn"
+	"github.com/quic-go/quic-go"
+)
+
+// quicALPN is the ALPN protocol of QUIC connections carrying NDN packets.
+const quicALPN = "ndn"
+
+// quicInitialMTU is the initial maximum datagram payload on QUIC faces.
+// It is lowered if the connection reports a smaller limit.
+const quicInitialMTU = 1200
+
+// quicConn is a QUIC connection or a WebTransport session.
+type quicConn interface {
+	SendDatagram(b []byte) error
+	ReceiveDatagram(ctx context.Context) ([]byte, error)
+	LocalAddr() net.Addr
+	RemoteAddr() net.Addr
+}
+
+// QUICTransport carries NDN packets as unreliable QUIC datagrams, which avoids
+// head-of-line blocking between packets. It is used both for raw QUIC connections
+// and for WebTransport sessions from browsers.
+type QUICTransport struct {
+	transportBase
+	conn      quicConn
+	closeConn func()
+
+	// Outgoing connection and permanent face reconnection
+	tlsConfig *tls.Config
+	rechan    chan bool
+}
+
+// quicConfig returns the QUIC configuration of NDN connections.
+func quicConfig() *quic.Config {
+	return &quic.Config{
+		EnableDatagrams: true,
+		KeepAlivePeriod: 10 * time.Second,
+	}
+}
+
+// MakeQUICTransport makes an outgoing QUIC transport.
+func MakeQUICTransport(remoteURI *defn.URI, persistency Persistency) (*QUICTransport, error) {
+	// Validate URI.
+	if !remoteURI.IsCanonical() || remoteURI.Scheme() != "quic" {
+		return nil, core.ErrNotCanonical
+	}
+
+	// Construct transport
+	t := &QUICTransport{
+		tlsConfig: &tls.Config{
+			ServerName:         remoteURI.Path(),
+			NextProtos:         []string{quicALPN},
+			InsecureSkipVerify: QUICInsecureSkipVerify,
+		},
+		rechan: make(chan bool, 1),
+	}
+	t.makeTransportBase(remoteURI, nil, persistency, remoteURI.Scope(), defn.PointToPoint, quicInitialMTU)
+
+	// Do not attempt to connect here, since it blocks the management thread.
+	// We will attempt to connect in the receive loop instead.
+	t.localURI = defn.MakeQUICFaceURI("127.0.0.1", 0)
+
+	return t, nil
+}
+
+// acceptQUICTransport makes a transport for an incoming QUIC connection or WebTransport session.
+func acceptQUICTransport(conn quicConn, closeConn func(), localURI *defn.URI) (*QUICTransport, error) {
+	remoteURI := quicAddrURI(conn.RemoteAddr())
+	if remoteURI == nil {
+		return nil, errors.New("unable to construct remote URI")
+	}
+
+	t := &QUICTransport{conn: conn, closeConn: closeConn}
+	t.makeTransportBase(remoteURI, localURI, PersistencyOnDemand, remoteURI.Scope(), defn.PointToPoint, quicInitialMTU)
+	t.running.Store(true)
+
+	return t, nil
+}
+
+// quicAddrURI makes a QUIC face URI from a UDP address.
+func quicAddrURI(addr net.Addr) *defn.URI {
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return nil
+	}
+	portInt, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return nil
+	}
+	return defn.MakeQUICFaceURI(host, uint16(portInt))
+}
+
+func (t *QUICTransport) String() string {
+	return fmt.Sprintf("QUICTransport, FaceID=%d, RemoteURI=%s, LocalURI=%s", t.faceID, t.remoteURI, t.localURI)
+}
+
+func (t *QUICTransport) SetPersistency(persistency Persistency) bool {
+	if t.tlsConfig == nil {
+		return persistency == PersistencyOnDemand
+	}
+	if persistency == PersistencyOnDemand {
+		return false
+	}
+	t.persistency = persistency
+	return true
+}
+
+func (t *QUICTransport) GetSendQueueSize() uint64 {
+	return 0
+}
+
+func (t *QUICTransport) sendFrame(frame []byte) {
+	if !t.running.Load() {
+		return
+	}
+
+	if len(frame) > t.MTU() {
+		core.LogWarn(t, "Attempted to send frame larger than MTU - DROP")
+		return
+	}
+
+	err := t.conn.SendDatagram(frame)
+	if tooLarge := (*quic.DatagramTooLargeError)(nil); errors.As(err, &tooLarge) {
+		// Following frames are fragmented by the link service
+		core.LogInfo(t, "Lowering MTU to ", tooLarge.MaxDatagramPayloadSize, " - DROP")
+		t.SetMTU(int(tooLarge.MaxDatagramPayloadSize))
+		return
+	} else if err != nil {
+		core.LogWarn(t, "Unable to send on connection (", err, ") - DROP and Face DOWN")
+		t.CloseConn() // receive might reconnect if needed
+		return
+	}
+
+	t.nOutBytes += uint64(len(frame))
+}
+
+// Attempt to (re)connect to the remote endpoint.
+func (t *QUICTransport) reconnect() {
+	first := t.conn == nil
+
+	// Shut down the existing connection
+	if !first {
+		t.closeConn()
+	}
+
+	connected := t.redial(first, func(attempt int) bool {
+		remote := net.JoinHostPort(t.remoteURI.Path(), strconv.Itoa(int(t.remoteURI.Port())))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		conn, err := quic.DialAddr(ctx, remote, t.tlsConfig, quicConfig())
+		cancel()
+		if err != nil {
+			core.LogWarn(t, "Unable to connect to remote endpoint [", attempt, "]: ", err)
+			return false
+		}
+
+		// If the transport was closed while we were trying to reconnect,
+		// close the new connection
+		if t.closed.Load() {
+			conn.CloseWithError(0, "")
+			return false
+		}
+
+		// Connected to remote again
+		t.conn = conn
+		t.closeConn = func() { conn.CloseWithError(0, "") }
+		t.localURI = quicAddrURI(conn.LocalAddr())
+		return true
+	})
+
+	// Do not block if the transport was closed meanwhile
+	select {
+	case t.rechan <- connected:
+	default:
+	}
+}
+
+func (t *QUICTransport) runReceive() {
+	defer t.Close()
+
+	for {
+		// The connection is nil before the first connection attempt
+		// of an outgoing transport.
+		if t.conn != nil {
+			t.receive()
+			if t.tlsConfig == nil || t.closed.Load() {
+				return
+			}
+		}
+
+		// Outgoing permanent faces will reconnect, otherwise close
+		go t.reconnect()
+		if !<-t.rechan || t.closed.Load() {
+			return // do not continue
+		}
+
+		core.LogInfo(t, "Connected QUIC - Face UP")
+		t.setRunning(true)
+	}
+}
+
+// receive reads datagrams from the connection until it fails.
+func (t *QUICTransport) receive() {
+	for {
+		message, err := t.conn.ReceiveDatagram(context.Background())
+		if err != nil {
+			if !t.closed.Load() {
+				core.LogInfo(t, "Unable to read from connection (", err, ") - Face DOWN")
+			}
+			t.setRunning(false)
+			return
+		}
+
+		if len(message) > defn.MaxNDNPacketSize {
+			core.LogWarn(t, "Received too much data without valid TLV block - DROP")
+			continue
+		}
+
+		t.nInBytes += uint64(len(message))
+		t.linkService.handleIncomingFrame(message)
+	}
+}
+
+// CloseConn closes the connection if running without closing the transport.
+func (t *QUICTransport) CloseConn() {
+	if t.setRunning(false) {
+		t.closeConn()
+	}
+}
+
+// Close the transport permanently - this will not attempt to reconnect.
+func (t *QUICTransport) Close() {
+	if t.markClosed() && t.rechan != nil {
+		select {
+		case t.rechan <- false:
+		default:
+		}
+	}
+	t.setRunning(false)
+	if t.conn != nil {
+		t.closeConn()
+	}
+}
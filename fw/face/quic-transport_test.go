@@ -0,0 +1,151 @@
+package face
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/dispatch"
+	"github.com/named-data/ndnd/fw/fw"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/quic-go/webtransport-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testFwThread is a forwarding thread that collects the packets received by faces.
+type testFwThread struct {
+	interests chan *defn.Pkt
+}
+
+func (t *testFwThread) String() string                 { return "TestFwThread" }
+func (t *testFwThread) QueueData(packet *defn.Pkt)     {}
+func (t *testFwThread) QueueInterest(packet *defn.Pkt) { t.interests <- packet }
+func (t *testFwThread) GetNumPitEntries() int          { return 0 }
+func (t *testFwThread) GetNumCsEntries() int           { return 0 }
+
+var testThread = &testFwThread{interests: make(chan *defn.Pkt, 16)}
+var testSetup sync.Once
+
+// setupTestFaces configures the face system once, with all received Interests
+// delivered to testThread. Faces of previous tests may still be running.
+func setupTestFaces() {
+	testSetup.Do(func() {
+		core.LoadConfig(core.DefaultConfig(), "")
+		core.SetLogLevel("ERROR")
+		Configure()
+		reconnectInitialInterval = 50 * time.Millisecond
+		QUICInsecureSkipVerify = true
+
+		fw.Threads = make([]*fw.Thread, 1)
+		dispatch.InitializeFWThreads([]dispatch.FWThread{testThread})
+	})
+}
+
+// startTestQUICListener starts a QUIC listener on a free loopback port, with all
+// received Interests delivered to the returned forwarding thread.
+func startTestQUICListener(t *testing.T) (*QUICListener, uint16, *testFwThread) {
+	setupTestFaces()
+
+	// Find a free UDP port
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := uint16(conn.LocalAddr().(*net.UDPAddr).Port)
+	conn.Close()
+
+	l, err := NewQUICListener(QUICListenerConfig{Bind: "127.0.0.1", Port: port, WebTransportPath: "/ndn"})
+	require.NoError(t, err)
+	go l.Run()
+	t.Cleanup(l.Close)
+
+	return l, port, testThread
+}
+
+// makeTestInterest encodes an Interest for the given name.
+func makeTestInterest(t *testing.T, name string) []byte {
+	n, err := enc.NameFromStr(name)
+	require.NoError(t, err)
+	interest, err := spec.Spec{}.MakeInterest(n, &ndn.InterestConfig{}, nil, nil)
+	require.NoError(t, err)
+	return interest.Wire.Join()
+}
+
+func TestQUICFaceLoopback(t *testing.T) {
+	_, port, thread := startTestQUICListener(t)
+
+	// The face reconnects until the listener is ready
+	transport, err := MakeQUICTransport(defn.MakeQUICFaceURI("127.0.0.1", port), PersistencyPermanent)
+	require.NoError(t, err)
+	linkService := MakeNDNLPLinkService(transport, MakeNDNLPLinkServiceOptions())
+	linkService.Run(nil)
+	defer linkService.Close()
+
+	require.Eventually(t, transport.IsRunning, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "quic", transport.LocalURI().Scheme())
+	assert.Equal(t, defn.Local, transport.Scope())
+
+	wire := makeTestInterest(t, "/test/quic")
+	pkt, _, err := spec.ReadPacket(enc.NewBufferReader(wire))
+	require.NoError(t, err)
+	linkService.SendPacket(dispatch.OutPkt{Pkt: &defn.Pkt{L3: pkt, Raw: wire}})
+
+	select {
+	case received := <-thread.interests:
+		assert.Equal(t, "/test/quic", received.Name.String())
+		inFace := FaceTable.Get(*received.IncomingFaceID)
+		require.NotNil(t, inFace)
+		assert.Equal(t, "quic", inFace.RemoteURI().Scheme())
+		assert.Equal(t, PersistencyOnDemand, inFace.Persistency())
+	case <-time.After(5 * time.Second):
+		t.Fatal("Interest not received over QUIC")
+	}
+}
+
+func TestWebTransportSession(t *testing.T) {
+	_, port, thread := startTestQUICListener(t)
+
+	dialer := webtransport.Dialer{
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: true,
+			NextProtos:         []string{http3.NextProtoH3},
+		},
+		QUICConfig: quicConfig(),
+	}
+	defer dialer.Close()
+
+	url := "https://" + net.JoinHostPort("127.0.0.1", strconv.Itoa(int(port))) + "/ndn"
+	var session *webtransport.Session
+	require.Eventually(t, func() bool {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		rsp, s, err := dialer.Dial(ctx, url, http.Header{})
+		if err != nil || rsp.StatusCode != http.StatusOK {
+			return false
+		}
+		session = s
+		return true
+	}, 5*time.Second, 50*time.Millisecond)
+	defer session.CloseWithError(0, "")
+
+	require.NoError(t, session.SendDatagram(makeTestInterest(t, "/test/webtransport")))
+
+	select {
+	case received := <-thread.interests:
+		assert.Equal(t, "/test/webtransport", received.Name.String())
+		inFace := FaceTable.Get(*received.IncomingFaceID)
+		require.NotNil(t, inFace)
+		assert.Equal(t, defn.Local, inFace.Scope())
+	case <-time.After(5 * time.Second):
+		t.Fatal("Interest not received over WebTransport")
+	}
+}
@@ -15,7 +15,6 @@ import (
 	"github.com/named-data/ndnd/fw/core"
 	defn "github.com/named-data/ndnd/fw/defn"
 	"github.com/named-data/ndnd/fw/dispatch"
-	"github.com/named-data/ndnd/fw/table"
 )
 
 // FaceTable is the global face table for this forwarder
@@ -44,6 +43,7 @@ func (t *Table) Add(face LinkService) {
 	t.faces.Store(faceID, face)
 	dispatch.AddFace(faceID, face)
 	core.LogDebug(t, "Registered FaceID=", faceID)
+	emitFaceEvent(FaceEventCreated, face)
 }
 
 // Get gets the face with the specified ID (if any) from the face table.
@@ -79,28 +79,35 @@ func (t *Table) GetAll() []LinkService {
 	return faces
 }
 
-// Remove removes a face from the face table.
+// Remove removes a face from the face table and stops its capture. Its routes are
+// removed from the RIB by the management thread, on the FaceEventDestroyed event.
 func (t *Table) Remove(id uint64) {
-	t.faces.Delete(id)
+	face, ok := t.faces.LoadAndDelete(id)
 	dispatch.RemoveFace(id)
-	table.Rib.CleanUpFace(id)
+	stopFaceCapture(id)
 	core.LogInfo(t, "Unregistered FaceID=", id)
+	if ok {
+		emitFaceEvent(FaceEventDestroyed, face.(LinkService))
+	}
 }
 
 // ExpirationHandler stops the faces that have expired
 func (t *Table) ExpirationHandler() {
 	for {
 		// Check for expired faces every 10 seconds
-		time.Sleep(10 * time.Second)
-
-		// Iterate the face table
-		t.faces.Range(func(_, face interface{}) bool {
-			transport := face.(LinkService).Transport()
-			if transport != nil && transport.ExpirationPeriod() < 0 {
-				core.LogInfo(transport, "Face expired")
-				transport.Close()
-			}
-			return true
-		})
+		<-core.After(10 * time.Second)
+		t.ExpireFaces()
 	}
 }
+
+// ExpireFaces stops the faces whose expiration time has passed on the clock.
+func (t *Table) ExpireFaces() {
+	t.faces.Range(func(_, face interface{}) bool {
+		transport := face.(LinkService).Transport()
+		if transport != nil && transport.ExpirationPeriod() < 0 {
+			core.LogInfo(transport, "Face expired")
+			transport.Close()
+		}
+		return true
+	})
+}
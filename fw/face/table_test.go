@@ -0,0 +1,51 @@
+package face
+
+import (
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/std/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpireFaces(t *testing.T) {
+	setupTestFaces()
+	clock := core.NewManualClock(time.Unix(1000, 0))
+	prevClock := core.SetClock(clock)
+	defer core.SetClock(prevClock)
+
+	makeFace := func(persistency Persistency, lifetime time.Duration) LinkService {
+		transport := MakeInProcessTransport()
+		transport.persistency = persistency
+		transport.expirationTime = utils.IdPtr(core.Now().Add(lifetime))
+		linkService := MakeNDNLPLinkService(transport, MakeNDNLPLinkServiceOptions())
+		linkService.Run(nil)
+		return linkService
+	}
+	onDemand := makeFace(PersistencyOnDemand, time.Minute)
+	onDemandLater := makeFace(PersistencyOnDemand, 3*time.Minute)
+	persistent := makeFace(PersistencyPersistent, time.Minute)
+	defer onDemandLater.Close()
+	defer persistent.Close()
+
+	FaceTable.ExpireFaces()
+	assert.NotNil(t, FaceTable.Get(onDemand.FaceID()))
+	assert.Equal(t, time.Minute, onDemand.ExpirationPeriod())
+	assert.Equal(t, time.Duration(0), persistent.ExpirationPeriod())
+
+	// Only the on-demand faces whose expiration time passed are closed
+	clock.Advance(2 * time.Minute)
+	FaceTable.ExpireFaces()
+	require.Eventually(t, func() bool { return FaceTable.Get(onDemand.FaceID()) == nil },
+		5*time.Second, 10*time.Millisecond)
+	assert.NotNil(t, FaceTable.Get(onDemandLater.FaceID()))
+	assert.NotNil(t, FaceTable.Get(persistent.FaceID()))
+
+	clock.Advance(2 * time.Minute)
+	FaceTable.ExpireFaces()
+	require.Eventually(t, func() bool { return FaceTable.Get(onDemandLater.FaceID()) == nil },
+		5*time.Second, 10*time.Millisecond)
+	assert.NotNil(t, FaceTable.Get(persistent.FaceID()))
+}
@@ -34,7 +34,23 @@ func MakeTCPListener(localURI *defn.URI) (*TCPListener, error) {
 
 	l := new(TCPListener)
 	l.localURI = localURI
-	l.stopped = make(chan bool, 1)
+	l.stopped = make(chan bool)
+	return l, nil
+}
+
+// MakeTCPListenerFromConn constructs a TCPListener on a socket that is already
+// listening, e.g., one passed by the init system with socket activation.
+func MakeTCPListenerFromConn(conn net.Listener) (*TCPListener, error) {
+	localURI := addrURI(conn.Addr())
+	if localURI == nil {
+		return nil, core.ErrNotCanonical
+	}
+
+	l, err := MakeTCPListener(localURI)
+	if err != nil {
+		return nil, err
+	}
+	l.conn = conn
 	return l, nil
 }
 
@@ -43,29 +59,18 @@ func (l *TCPListener) String() string {
 }
 
 func (l *TCPListener) Run() {
-	defer func() { l.stopped <- true }()
-
-	// Create dialer and set reuse address option
-	listenConfig := &net.ListenConfig{Control: impl.SyscallReuseAddr}
+	defer close(l.stopped)
 
-	// Create listener
-	var remote string
-	if l.localURI.Scheme() == "tcp4" {
-		remote = fmt.Sprintf("%s:%d", l.localURI.PathHost(), l.localURI.Port())
-	} else {
-		remote = fmt.Sprintf("[%s]:%d", l.localURI.Path(), l.localURI.Port())
-	}
-
-	// Start listening for incoming connections
-	var err error
-	l.conn, err = listenConfig.Listen(context.Background(), l.localURI.Scheme(), remote)
-	if err != nil {
-		core.LogError(l, "Unable to start TCP listener: ", err)
-		return
+	// Start listening unless the socket was passed by the init system
+	if l.conn == nil {
+		if err := l.listen(); err != nil {
+			core.LogError(l, "Unable to start TCP listener: ", err)
+			return
+		}
 	}
 
 	// Run accept loop
-	for !core.ShouldQuit {
+	for !core.ShouldQuit.Load() {
 		remoteConn, err := l.conn.Accept()
 		if err != nil {
 			if errors.Is(err, net.ErrClosed) {
@@ -88,9 +93,38 @@ func (l *TCPListener) Run() {
 	}
 }
 
+// listen opens the listening socket.
+func (l *TCPListener) listen() error {
+	// Create dialer and set reuse address option
+	listenConfig := &net.ListenConfig{Control: impl.SyscallReuseAddr}
+
+	// Create listener
+	var remote string
+	if l.localURI.Scheme() == "tcp4" {
+		remote = fmt.Sprintf("%s:%d", l.localURI.PathHost(), l.localURI.Port())
+	} else {
+		remote = fmt.Sprintf("[%s]:%d", l.localURI.Path(), l.localURI.Port())
+	}
+
+	// Start listening for incoming connections
+	var err error
+	l.conn, err = listenConfig.Listen(context.Background(), l.localURI.Scheme(), remote)
+	return err
+}
+
 func (l *TCPListener) Close() {
 	if l.conn != nil {
 		l.conn.Close()
 		<-l.stopped
 	}
 }
+
+// Stopped returns whether the listener has stopped, e.g., because its address disappeared.
+func (l *TCPListener) Stopped() bool {
+	select {
+	case <-l.stopped:
+		return true
+	default:
+		return false
+	}
+}
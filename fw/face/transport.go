@@ -8,9 +8,11 @@
 package face
 
 import (
+	"math/rand"
 	"sync/atomic"
 	"time"
 
+	"github.com/named-data/ndnd/fw/core"
 	defn "github.com/named-data/ndnd/fw/defn"
 )
 
@@ -51,6 +53,8 @@ type transport interface {
 type transportBase struct {
 	linkService LinkService
 	running     atomic.Bool
+	closed      atomic.Bool   // (permanently)
+	closeCh     chan struct{} // closed with the transport to interrupt reconnection
 
 	faceID         uint64
 	remoteURI      *defn.URI
@@ -75,6 +79,7 @@ func (t *transportBase) makeTransportBase(
 	mtu int,
 ) {
 	t.running = atomic.Bool{}
+	t.closeCh = make(chan struct{})
 	t.remoteURI = remoteURI
 	t.localURI = localURI
 	t.persistency = persistency
@@ -133,7 +138,7 @@ func (t *transportBase) ExpirationPeriod() time.Duration {
 	if t.expirationTime == nil || t.persistency != PersistencyOnDemand {
 		return 0
 	}
-	return time.Until(*t.expirationTime)
+	return core.Until(*t.expirationTime)
 }
 
 func (t *transportBase) FaceID() uint64 {
@@ -144,6 +149,72 @@ func (t *transportBase) IsRunning() bool {
 	return t.running.Load()
 }
 
+// setRunning marks the transport as up or down, and notifies a face event
+// if the state changed. It returns the previous state.
+func (t *transportBase) setRunning(running bool) bool {
+	prev := t.running.Swap(running)
+	if prev != running && t.linkService != nil && t.faceID != 0 {
+		if running {
+			emitFaceEvent(FaceEventUp, t.linkService)
+		} else {
+			emitFaceEvent(FaceEventDown, t.linkService)
+		}
+	}
+	return prev
+}
+
+// markClosed marks the transport as permanently closed, interrupting any pending
+// reconnection. It returns false if the transport was already closed.
+func (t *transportBase) markClosed() bool {
+	if t.closed.Swap(true) {
+		return false
+	}
+	close(t.closeCh)
+	return true
+}
+
+//
+// Reconnection
+//
+
+// redial calls connect until it succeeds, and returns whether the transport is connected.
+// The first connection of an outgoing transport is attempted once regardless of its
+// persistency. Afterwards, only permanent transports are reconnected, waiting with
+// exponential backoff and jitter between attempts.
+func (t *transportBase) redial(first bool, connect func(attempt int) bool) bool {
+	for attempt := 1; ; attempt++ {
+		// Check persistency on each attempt to account for changes
+		if !(first && attempt == 1) && (t.persistency != PersistencyPermanent || t.closed.Load()) {
+			return false
+		}
+
+		if connect(attempt) {
+			return true
+		}
+
+		if t.persistency == PersistencyPermanent {
+			select {
+			case <-core.After(reconnectDelay(attempt)):
+			case <-t.closeCh:
+				return false
+			}
+		}
+	}
+}
+
+// reconnectDelay returns the delay after the given failed reconnection attempt.
+func reconnectDelay(attempt int) time.Duration {
+	delay := reconnectInitialInterval
+	for i := 1; i < attempt && delay < reconnectMaxInterval; i++ {
+		delay *= 2
+	}
+	delay = min(delay, reconnectMaxInterval)
+	if reconnectJitter > 0 {
+		delay += time.Duration((2*rand.Float64() - 1) * reconnectJitter * float64(delay))
+	}
+	return delay
+}
+
 //
 // Counters
 //
@@ -0,0 +1,148 @@
+package face
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setReconnectIntervals changes the reconnection settings for the duration of a test.
+func setReconnectIntervals(t *testing.T, initial time.Duration, max time.Duration, jitter float64) {
+	prevInitial, prevMax, prevJitter := reconnectInitialInterval, reconnectMaxInterval, reconnectJitter
+	reconnectInitialInterval, reconnectMaxInterval, reconnectJitter = initial, max, jitter
+	t.Cleanup(func() {
+		reconnectInitialInterval, reconnectMaxInterval, reconnectJitter = prevInitial, prevMax, prevJitter
+	})
+}
+
+func TestReconnectDelay(t *testing.T) {
+	setReconnectIntervals(t, time.Second, 8*time.Second, 0)
+	expected := []time.Duration{1, 2, 4, 8, 8, 8}
+	for i, delay := range expected {
+		assert.Equal(t, delay*time.Second, reconnectDelay(i+1), "attempt %d", i+1)
+	}
+
+	reconnectJitter = 0.5
+	for attempt := 1; attempt <= 6; attempt++ {
+		delay := reconnectDelay(attempt)
+		assert.GreaterOrEqual(t, delay, expected[attempt-1]*time.Second/2)
+		assert.LessOrEqual(t, delay, expected[attempt-1]*time.Second*3/2)
+	}
+}
+
+// makeTestTransportBase returns a transport base that is not attached to a connection.
+func makeTestTransportBase(persistency Persistency) *transportBase {
+	t := &transportBase{}
+	t.makeTransportBase(defn.MakeNullFaceURI(), defn.MakeNullFaceURI(), persistency,
+		defn.NonLocal, defn.PointToPoint, defn.MaxNDNPacketSize)
+	return t
+}
+
+func TestRedialBackoff(t *testing.T) {
+	setReconnectIntervals(t, time.Second, 8*time.Second, 0)
+	clock := core.NewManualClock(time.Unix(1000, 0))
+	prevClock := core.SetClock(clock)
+	defer core.SetClock(prevClock)
+
+	// Waiters of other components on the clock
+	pending := clock.Pending()
+
+	// Permanent transports are reconnected after 1s, 2s and 4s
+	tb := makeTestTransportBase(PersistencyPermanent)
+	var attempts atomic.Int32
+	connected := make(chan bool, 1)
+	go func() {
+		connected <- tb.redial(false, func(attempt int) bool {
+			attempts.Store(int32(attempt))
+			return attempt == 4
+		})
+	}()
+	for i, delay := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		require.Eventually(t, func() bool {
+			return attempts.Load() == int32(i+1) && clock.Pending() > pending
+		}, 5*time.Second, time.Millisecond)
+		clock.Advance(delay - time.Millisecond)
+		assert.Equal(t, int32(i+1), attempts.Load())
+		clock.Advance(time.Millisecond)
+	}
+	select {
+	case ok := <-connected:
+		assert.True(t, ok)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "redial did not return")
+	}
+	assert.Equal(t, int32(4), attempts.Load())
+	assert.Equal(t, time.Unix(1007, 0), core.Now())
+
+	// Closing the transport interrupts the backoff
+	tb = makeTestTransportBase(PersistencyPermanent)
+	attempts.Store(0)
+	go func() {
+		connected <- tb.redial(false, func(attempt int) bool {
+			attempts.Store(int32(attempt))
+			return false
+		})
+	}()
+	require.Eventually(t, func() bool { return attempts.Load() == 1 && clock.Pending() > pending },
+		5*time.Second, time.Millisecond)
+	tb.markClosed()
+	select {
+	case ok := <-connected:
+		assert.False(t, ok)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "redial did not return")
+	}
+
+	// Other transports only get the first connection attempt
+	tb = makeTestTransportBase(PersistencyPersistent)
+	attempts.Store(0)
+	assert.False(t, tb.redial(true, func(attempt int) bool {
+		attempts.Store(int32(attempt))
+		return false
+	}))
+	assert.Equal(t, int32(1), attempts.Load())
+	assert.False(t, tb.redial(false, func(int) bool {
+		require.FailNow(t, "persistent transport reconnected")
+		return true
+	}))
+}
+
+func TestFaceEvents(t *testing.T) {
+	var mutex sync.Mutex
+	var events []FaceEventKind
+	transport := MakeInProcessTransport()
+	AddFaceEventHandler(func(kind FaceEventKind, face LinkService) {
+		if face.Transport() == transport {
+			mutex.Lock()
+			defer mutex.Unlock()
+			events = append(events, kind)
+		}
+	})
+	received := func() []FaceEventKind {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]FaceEventKind{}, events...)
+	}
+
+	linkService := MakeNDNLPLinkService(transport, MakeNDNLPLinkServiceOptions())
+	linkService.Run(nil)
+	require.Equal(t, []FaceEventKind{FaceEventCreated}, received())
+
+	// Only changes of state are notified
+	transport.setRunning(false)
+	transport.setRunning(false)
+	transport.setRunning(true)
+	assert.Equal(t, []FaceEventKind{FaceEventCreated, FaceEventDown, FaceEventUp}, received())
+
+	linkService.Close()
+	require.Eventually(t, func() bool { return len(received()) >= 4 && FaceTable.Get(linkService.FaceID()) == nil },
+		5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, FaceEventDestroyed, received()[len(received())-1])
+	assert.Equal(t, "Destroyed", FaceEventDestroyed.String())
+}
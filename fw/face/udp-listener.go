@@ -35,7 +35,23 @@ func MakeUDPListener(localURI *defn.URI) (*UDPListener, error) {
 
 	l := new(UDPListener)
 	l.localURI = localURI
-	l.stopped = make(chan bool, 1)
+	l.stopped = make(chan bool)
+	return l, nil
+}
+
+// MakeUDPListenerFromConn constructs a UDPListener on a socket that is already
+// listening, e.g., one passed by the init system with socket activation.
+func MakeUDPListenerFromConn(conn net.PacketConn) (*UDPListener, error) {
+	localURI := addrURI(conn.LocalAddr())
+	if localURI == nil {
+		return nil, core.ErrNotCanonical
+	}
+
+	l, err := MakeUDPListener(localURI)
+	if err != nil {
+		return nil, err
+	}
+	l.conn = conn
 	return l, nil
 }
 
@@ -45,30 +61,19 @@ func (l *UDPListener) String() string {
 
 // Run starts the UDP listener.
 func (l *UDPListener) Run() {
-	defer func() { l.stopped <- true }()
-
-	// Create dialer and set reuse address option
-	listenConfig := &net.ListenConfig{Control: impl.SyscallReuseAddr}
+	defer close(l.stopped)
 
-	// Create listener
-	var remote string
-	if l.localURI.Scheme() == "udp4" {
-		remote = fmt.Sprintf("%s:%d", l.localURI.PathHost(), l.localURI.Port())
-	} else {
-		remote = fmt.Sprintf("[%s]:%d", l.localURI.Path(), l.localURI.Port())
-	}
-
-	// Start listening for incoming connections
-	var err error
-	l.conn, err = listenConfig.ListenPacket(context.Background(), l.localURI.Scheme(), remote)
-	if err != nil {
-		core.LogError(l, "Unable to start UDP listener: ", err)
-		return
+	// Start listening unless the socket was passed by the init system
+	if l.conn == nil {
+		if err := l.listen(); err != nil {
+			core.LogError(l, "Unable to start UDP listener: ", err)
+			return
+		}
 	}
 
 	// Run accept loop
 	recvBuf := make([]byte, defn.MaxNDNPacketSize)
-	for !core.ShouldQuit {
+	for !core.ShouldQuit.Load() {
 		readSize, remoteAddr, err := l.conn.ReadFrom(recvBuf)
 		if err != nil {
 			if errors.Is(err, net.ErrClosed) {
@@ -109,9 +114,38 @@ func (l *UDPListener) Run() {
 	}
 }
 
+// listen opens the listening socket.
+func (l *UDPListener) listen() error {
+	// Create dialer and set reuse address option
+	listenConfig := &net.ListenConfig{Control: impl.SyscallReuseAddr}
+
+	// Create listener
+	var remote string
+	if l.localURI.Scheme() == "udp4" {
+		remote = fmt.Sprintf("%s:%d", l.localURI.PathHost(), l.localURI.Port())
+	} else {
+		remote = fmt.Sprintf("[%s]:%d", l.localURI.Path(), l.localURI.Port())
+	}
+
+	// Start listening for incoming connections
+	var err error
+	l.conn, err = listenConfig.ListenPacket(context.Background(), l.localURI.Scheme(), remote)
+	return err
+}
+
 func (l *UDPListener) Close() {
 	if l.conn != nil {
 		l.conn.Close()
 		<-l.stopped
 	}
 }
+
+// Stopped returns whether the listener has stopped, e.g., because its address disappeared.
+func (l *UDPListener) Stopped() bool {
+	select {
+	case <-l.stopped:
+		return true
+	default:
+		return false
+	}
+}
@@ -12,7 +12,6 @@ import (
 	"fmt"
 	"net"
 	"strconv"
-	"time"
 
 	"github.com/named-data/ndnd/fw/core"
 	defn "github.com/named-data/ndnd/fw/defn"
@@ -31,7 +30,6 @@ type UnicastTCPTransport struct {
 
 	// Permanent face reconnection
 	rechan chan bool
-	closed bool // (permanently)
 }
 
 // Makes an outgoing unicast TCP transport.
@@ -52,7 +50,7 @@ func MakeUnicastTCPTransport(
 	// Construct transport
 	t := new(UnicastTCPTransport)
 	t.makeTransportBase(remoteURI, localURI, persistency, defn.NonLocal, defn.PointToPoint, defn.MaxNDNPacketSize)
-	t.expirationTime = utils.IdPtr(time.Now().Add(tcpLifetime))
+	t.expirationTime = utils.IdPtr(core.Now().Add(tcpLifetime))
 	t.rechan = make(chan bool, 1)
 
 	// Set scope
@@ -112,7 +110,7 @@ func AcceptUnicastTCPTransport(
 	// Construct transport
 	t := new(UnicastTCPTransport)
 	t.makeTransportBase(remoteURI, localURI, persistency, defn.NonLocal, defn.PointToPoint, defn.MaxNDNPacketSize)
-	t.expirationTime = utils.IdPtr(time.Now().Add(tcpLifetime))
+	t.expirationTime = utils.IdPtr(core.Now().Add(tcpLifetime))
 	t.rechan = make(chan bool, 1)
 
 	var success bool
@@ -172,51 +170,39 @@ func (t *UnicastTCPTransport) setConn(conn *net.TCPConn) {
 
 // Attempt to reconnect to the remote transport.
 func (t *UnicastTCPTransport) reconnect() {
+	// If there is no connection, this is the initial attempt to
+	// connect for any face, so we will continue regardless
+	first := t.conn == nil
+
 	// Shut down the existing socket
-	if t.conn != nil {
+	if !first {
 		t.conn.Close()
 	}
 
-	// Number of attempts we have made so far
-	attempt := 0
-
-	// Keep trying to reconnect until successful
-	// If the transport is not permanent, do not attempt to restart
-	// Do this inside the loop to account for changes to persistency
-	for {
-		attempt++
-
-		// If there is no connection, this is the initial attempt to
-		// connect for any face, so we will continue regardless
-		// However, make only one attempt to connect for non-permanent faces
-		if !(t.conn == nil && attempt == 1) {
-			// Do not continue if the transport is not permanent or closed
-			if t.Persistency() != PersistencyPermanent || t.closed {
-				t.rechan <- false // do not continue
-				return
-			}
-		}
-
-		// Restart socket for permanent transport
+	connected := t.redial(first, func(attempt int) bool {
 		remote := net.JoinHostPort(t.remoteURI.Path(), strconv.Itoa(int(t.remoteURI.Port())))
 		conn, err := t.dialer.Dial(t.remoteURI.Scheme(), remote)
 		if err != nil {
 			core.LogWarn(t, "Unable to connect to remote endpoint [", attempt, "]: ", err)
-			time.Sleep(5 * time.Second) // TODO: configurable
-			continue
+			return false
 		}
 
 		// If the transport was closed while we were trying to reconnect,
-		// close the new connection and return without notifying
-		if t.closed {
+		// close the new connection
+		if t.closed.Load() {
 			conn.Close()
-			return
+			return false
 		}
 
 		// Connected to remote again
 		t.setConn(conn.(*net.TCPConn))
-		t.rechan <- true // continue
-		return
+		return true
+	})
+
+	// Do not block if the transport was closed meanwhile
+	select {
+	case t.rechan <- connected:
+	default:
 	}
 }
 
@@ -238,7 +224,7 @@ func (t *UnicastTCPTransport) sendFrame(frame []byte) {
 	}
 
 	t.nOutBytes += uint64(len(frame))
-	*t.expirationTime = time.Now().Add(tcpLifetime)
+	*t.expirationTime = core.Now().Add(tcpLifetime)
 }
 
 func (t *UnicastTCPTransport) runReceive() {
@@ -250,37 +236,49 @@ func (t *UnicastTCPTransport) runReceive() {
 		if t.conn != nil {
 			err := readTlvStream(t.conn, func(b []byte) {
 				t.nInBytes += uint64(len(b))
-				*t.expirationTime = time.Now().Add(tcpLifetime)
+				*t.expirationTime = core.Now().Add(tcpLifetime)
 				t.linkService.handleIncomingFrame(b)
 			}, nil)
-			if err == nil || t.closed {
-				break // EOF
+			if t.closed.Load() {
+				return
 			}
 
-			core.LogWarn(t, "Unable to read from socket (", err, ") - Face DOWN")
+			if err == nil {
+				core.LogInfo(t, "Connection closed by remote endpoint - Face DOWN")
+			} else {
+				core.LogWarn(t, "Unable to read from socket (", err, ") - Face DOWN")
+			}
+			t.setRunning(false)
 		}
 
-		// Persistent faces will reconnect, otherwise close
+		// Permanent faces will reconnect, otherwise close
 		go t.reconnect()
-		if !<-t.rechan {
+		if !<-t.rechan || t.closed.Load() {
 			return // do not continue
 		}
 
 		core.LogInfo(t, "Connected socket - Face UP")
-		t.running.Store(true)
+		t.setRunning(true)
 	}
 }
 
 // Close the inner connection if running without closing the transport.
 func (t *UnicastTCPTransport) CloseConn() {
-	if t.running.Swap(false) {
+	if t.setRunning(false) {
 		t.conn.Close()
 	}
 }
 
 // Close the connection permanently - this will not attempt to reconnect.
 func (t *UnicastTCPTransport) Close() {
-	t.closed = true
-	t.rechan <- false
-	t.CloseConn()
+	if t.markClosed() {
+		select {
+		case t.rechan <- false:
+		default:
+		}
+	}
+	t.setRunning(false)
+	if t.conn != nil {
+		t.conn.Close()
+	}
 }
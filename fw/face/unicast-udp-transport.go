@@ -13,6 +13,8 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/named-data/ndnd/fw/core"
@@ -20,6 +22,10 @@ import (
 	"github.com/named-data/ndnd/fw/face/impl"
 )
 
+// udpPathMTUCheckInterval is how often the path MTU is read again from the kernel,
+// which raises it again when the discovered value expires.
+const udpPathMTUCheckInterval = time.Minute
+
 // UnicastUDPTransport is a unicast UDP transport.
 type UnicastUDPTransport struct {
 	dialer     *net.Dialer
@@ -27,6 +33,10 @@ type UnicastUDPTransport struct {
 	localAddr  net.UDPAddr
 	remoteAddr net.UDPAddr
 	transportBase
+
+	// Path MTU discovery
+	pathMTU        atomic.Int64 // maximum UDP payload on the path, zero if unknown
+	pathMTUChecked atomic.Int64 // time of the last check in Unix nanoseconds, read by send and receive
 }
 
 // MakeUnicastUDPTransport creates a new unicast UDP transport.
@@ -45,7 +55,7 @@ func MakeUnicastUDPTransport(
 	t := new(UnicastUDPTransport)
 	t.makeTransportBase(remoteURI, localURI, persistency, defn.NonLocal, defn.PointToPoint, defn.MaxNDNPacketSize)
 	t.expirationTime = new(time.Time)
-	*t.expirationTime = time.Now().Add(udpLifetime)
+	*t.expirationTime = core.Now().Add(udpLifetime)
 
 	// Set scope
 	ip := net.ParseIP(remoteURI.Path())
@@ -69,13 +79,9 @@ func MakeUnicastUDPTransport(
 	// Unlike TCP, we don't need to do this in a separate goroutine because
 	// we don't need to wait for the connection to be established
 	t.dialer = &net.Dialer{LocalAddr: &t.localAddr, Control: impl.SyscallReuseAddr}
-	remote := net.JoinHostPort(t.remoteURI.Path(), strconv.Itoa(int(t.remoteURI.Port())))
-	conn, err := t.dialer.Dial(t.remoteURI.Scheme(), remote)
-	if err != nil {
+	if err := t.connect(); err != nil {
 		return nil, errors.New("Unable to connect to remote endpoint: " + err.Error())
 	}
-
-	t.conn = conn.(*net.UDPConn)
 	t.running.Store(true)
 
 	if localURI == nil {
@@ -86,6 +92,64 @@ func MakeUnicastUDPTransport(
 	return t, nil
 }
 
+// connect (re)creates the socket to the remote endpoint.
+func (t *UnicastUDPTransport) connect() error {
+	remote := net.JoinHostPort(t.remoteURI.Path(), strconv.Itoa(int(t.remoteURI.Port())))
+	conn, err := t.dialer.Dial(t.remoteURI.Scheme(), remote)
+	if err != nil {
+		return err
+	}
+	t.conn = conn.(*net.UDPConn)
+
+	if udpPathMTUDiscovery {
+		rawConn, err := t.conn.SyscallConn()
+		if err == nil {
+			err = impl.SyscallSetPathMTUDiscovery(rawConn, t.remoteURI.Scheme() == "udp6")
+		}
+		if err != nil {
+			core.LogDebug(t, "Unable to enable path MTU discovery: ", err)
+		} else {
+			t.updatePathMTU()
+		}
+	}
+	return nil
+}
+
+// updatePathMTU reads the path MTU discovered by the kernel.
+func (t *UnicastUDPTransport) updatePathMTU() {
+	t.pathMTUChecked.Store(core.Now().UnixNano())
+
+	rawConn, err := t.conn.SyscallConn()
+	if err != nil {
+		return
+	}
+	ipv6 := t.remoteURI.Scheme() == "udp6"
+	mtu, err := impl.SyscallGetPathMTU(rawConn, ipv6)
+	if err != nil || mtu <= 0 {
+		return
+	}
+
+	// Subtract IP and UDP headers
+	if ipv6 {
+		mtu -= 40 + 8
+	} else {
+		mtu -= 20 + 8
+	}
+	if old := t.pathMTU.Swap(int64(mtu)); old == 0 {
+		core.LogDebug(t, "Path MTU is ", mtu, " bytes of payload")
+	} else if old != int64(mtu) {
+		core.LogInfo(t, "Path MTU changed from ", old, " to ", mtu, " bytes of payload")
+	}
+}
+
+// MTU returns the MTU of the face, lowered to the path MTU if known.
+func (t *UnicastUDPTransport) MTU() int {
+	if pathMTU := int(t.pathMTU.Load()); pathMTU > 0 && pathMTU < t.mtu {
+		return pathMTU
+	}
+	return t.mtu
+}
+
 func (t *UnicastUDPTransport) String() string {
 	return fmt.Sprintf("UnicastUDPTransport, FaceID=%d, RemoteURI=%s, LocalURI=%s", t.faceID, t.remoteURI, t.localURI)
 }
@@ -113,36 +177,91 @@ func (t *UnicastUDPTransport) sendFrame(frame []byte) {
 		return
 	}
 
+	if udpPathMTUDiscovery && core.Since(time.Unix(0, t.pathMTUChecked.Load())) > udpPathMTUCheckInterval {
+		t.updatePathMTU()
+	}
+
 	_, err := t.conn.Write(frame)
-	if err != nil {
-		core.LogWarn(t, "Unable to send on socket - DROP and Face DOWN")
-		t.Close()
+	if errors.Is(err, syscall.EMSGSIZE) {
+		// Following frames are fragmented by the link service
+		t.updatePathMTU()
+		core.LogDebug(t, "Frame larger than path MTU - DROP")
+		return
+	} else if isConnRefused(err) {
+		// The remote endpoint is not listening (ICMP), which is not
+		// an error for connectionless UDP
+		core.LogDebug(t, "Remote endpoint refused packet - DROP")
+		return
+	} else if err != nil {
+		core.LogWarn(t, "Unable to send on socket (", err, ") - DROP and Face DOWN")
+		t.CloseConn() // receive might reconnect if needed
 		return
 	}
 
 	t.nOutBytes += uint64(len(frame))
-	*t.expirationTime = time.Now().Add(udpLifetime)
+	*t.expirationTime = core.Now().Add(udpLifetime)
 }
 
 func (t *UnicastUDPTransport) runReceive() {
 	defer t.Close()
 
-	err := readTlvStream(t.conn, func(b []byte) {
-		t.nInBytes += uint64(len(b))
-		*t.expirationTime = time.Now().Add(udpLifetime)
-		t.linkService.handleIncomingFrame(b)
-	}, func(err error) bool {
-		// Ignore since UDP is a connectionless protocol
-		// This happens if the other side is not listening (ICMP)
-		return strings.Contains(err.Error(), "connection refused")
-	})
-	if err != nil && t.running.Load() {
-		core.LogWarn(t, "Unable to read from socket (", err, ") - Face DOWN")
+	for {
+		err := readTlvStream(t.conn, func(b []byte) {
+			t.nInBytes += uint64(len(b))
+			*t.expirationTime = core.Now().Add(udpLifetime)
+			t.linkService.handleIncomingFrame(b)
+		}, func(err error) bool {
+			// Path MTU lowered by an ICMP Fragmentation Needed message
+			if errors.Is(err, syscall.EMSGSIZE) {
+				t.updatePathMTU()
+				return true
+			}
+
+			// Ignore since UDP is a connectionless protocol
+			// This happens if the other side is not listening (ICMP)
+			return isConnRefused(err)
+		})
+		if t.closed.Load() {
+			return
+		}
+		if err != nil && t.running.Load() {
+			core.LogWarn(t, "Unable to read from socket (", err, ") - Face DOWN")
+		}
+		t.CloseConn()
+
+		// Permanent faces will reconnect, otherwise close
+		if !t.redial(false, func(attempt int) bool {
+			if err := t.connect(); err != nil {
+				core.LogWarn(t, "Unable to connect to remote endpoint [", attempt, "]: ", err)
+				return false
+			}
+			return true
+		}) {
+			return // do not continue
+		}
+		if t.closed.Load() {
+			t.conn.Close()
+			return
+		}
+
+		core.LogInfo(t, "Recreated socket - Face UP")
+		t.setRunning(true)
 	}
 }
 
-func (t *UnicastUDPTransport) Close() {
-	if t.running.Swap(false) {
+// CloseConn closes the socket if running without closing the transport.
+func (t *UnicastUDPTransport) CloseConn() {
+	if t.setRunning(false) {
 		t.conn.Close()
 	}
 }
+
+// Close the transport permanently - this will not attempt to reconnect.
+func (t *UnicastUDPTransport) Close() {
+	t.markClosed()
+	t.CloseConn()
+}
+
+func isConnRefused(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "connection refused")
+}
@@ -0,0 +1,81 @@
+package face
+
+import (
+	"net"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// makeTestUDPTransport creates a UDP transport from an ephemeral port to a loopback socket.
+func makeTestUDPTransport(t *testing.T, pathMTUDiscovery bool) *UnicastUDPTransport {
+	prevPort, prevDiscovery := UDPUnicastPort, udpPathMTUDiscovery
+	UDPUnicastPort, udpPathMTUDiscovery = 0, pathMTUDiscovery
+	t.Cleanup(func() { UDPUnicastPort, udpPathMTUDiscovery = prevPort, prevDiscovery })
+
+	remote, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	t.Cleanup(func() { remote.Close() })
+
+	transport, err := MakeUnicastUDPTransport(
+		defn.DecodeURIString("udp4://"+remote.LocalAddr().String()), nil, PersistencyPersistent)
+	require.NoError(t, err)
+	t.Cleanup(func() { transport.conn.Close() })
+	return transport
+}
+
+func TestPathMTUDiscoveryDisabled(t *testing.T) {
+	assert.False(t, core.DefaultConfig().Faces.Udp.PathMtuDiscovery)
+
+	transport := makeTestUDPTransport(t, false)
+	assert.Zero(t, transport.pathMTUChecked.Load())
+	assert.Zero(t, transport.pathMTU.Load())
+	assert.Equal(t, defn.MaxNDNPacketSize, transport.MTU())
+
+	transport.sendFrame([]byte{0x05, 0x00})
+	assert.Zero(t, transport.pathMTUChecked.Load())
+}
+
+func TestPathMTUDiscovery(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("path MTU discovery is only supported on Linux")
+	}
+
+	before := time.Now()
+	transport := makeTestUDPTransport(t, true)
+	checked := transport.pathMTUChecked.Load()
+	assert.GreaterOrEqual(t, checked, before.UnixNano())
+
+	// The loopback MTU is larger than NDN packets
+	assert.Greater(t, transport.pathMTU.Load(), int64(defn.MaxNDNPacketSize))
+	assert.Equal(t, defn.MaxNDNPacketSize, transport.MTU())
+
+	// Lowered to the path MTU when it is smaller
+	transport.pathMTU.Store(1000)
+	assert.Equal(t, 1000, transport.MTU())
+
+	// The path MTU is checked again once the interval has elapsed. The receive
+	// goroutine also updates it on ICMP errors, concurrently with sending.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		transport.updatePathMTU()
+	}()
+	transport.sendFrame([]byte{0x05, 0x00})
+	wg.Wait()
+	checked = transport.pathMTUChecked.Load()
+	transport.sendFrame([]byte{0x05, 0x00})
+	assert.Equal(t, checked, transport.pathMTUChecked.Load())
+
+	transport.pathMTUChecked.Store(time.Now().Add(-udpPathMTUCheckInterval - time.Second).UnixNano())
+	transport.sendFrame([]byte{0x05, 0x00})
+	assert.GreaterOrEqual(t, transport.pathMTUChecked.Load(), checked)
+	assert.Greater(t, transport.pathMTU.Load(), int64(defn.MaxNDNPacketSize))
+}
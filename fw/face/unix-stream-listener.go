@@ -11,7 +11,9 @@ import (
 	"errors"
 	"net"
 	"os"
+	"os/user"
 	"path"
+	"strconv"
 
 	"github.com/named-data/ndnd/fw/core"
 	defn "github.com/named-data/ndnd/fw/defn"
@@ -21,7 +23,7 @@ import (
 type UnixStreamListener struct {
 	conn     net.Listener
 	localURI *defn.URI
-	nextFD   int // We can't (at least easily) access the actual FD through net.Conn, so we'll make our own
+	ready    chan struct{} // closed once conn is set
 	stopped  chan bool
 }
 
@@ -34,11 +36,22 @@ func MakeUnixStreamListener(localURI *defn.URI) (*UnixStreamListener, error) {
 
 	return &UnixStreamListener{
 		localURI: localURI,
-		nextFD:   1,
+		ready:    make(chan struct{}),
 		stopped:  make(chan bool, 1),
 	}, nil
 }
 
+// MakeUnixStreamListenerFromConn constructs a UnixStreamListener on a socket that is
+// already listening, e.g., one passed by the init system with socket activation.
+func MakeUnixStreamListenerFromConn(conn net.Listener) (*UnixStreamListener, error) {
+	l, err := MakeUnixStreamListener(defn.MakeUnixFaceURI(conn.Addr().String()))
+	if err != nil {
+		return nil, err
+	}
+	l.conn = conn
+	return l, nil
+}
+
 func (l *UnixStreamListener) String() string {
 	return "UnixStreamListener, " + l.localURI.String()
 }
@@ -46,29 +59,16 @@ func (l *UnixStreamListener) String() string {
 func (l *UnixStreamListener) Run() {
 	defer func() { l.stopped <- true }()
 
-	// Delete any existing socket
-	os.Remove(l.localURI.Path())
-
-	// Create inside folder if not existing
-	sockPath := l.localURI.Path()
-	dirPath := path.Dir(sockPath)
-	os.MkdirAll(dirPath, os.ModePerm)
-
-	// Create listener
-	var err error
-	if l.conn, err = net.Listen(l.localURI.Scheme(), sockPath); err != nil {
-		core.LogFatal(l, "Unable to start Unix stream listener: ", err)
-	}
-
-	// Set permissions to allow all local apps to communicate with us
-	if err := os.Chmod(sockPath, os.ModePerm); err != nil {
-		core.LogFatal(l, "Unable to change permissions on Unix stream listener: ", err)
+	// Create the socket unless it was passed by the init system
+	if l.conn == nil {
+		l.listen()
 	}
+	close(l.ready)
 
 	core.LogInfo(l, "Listening")
 
 	// Run accept loop
-	for !core.ShouldQuit {
+	for !core.ShouldQuit.Load() {
 		newConn, err := l.conn.Accept()
 		if err != nil {
 			if errors.Is(err, net.ErrClosed) {
@@ -78,8 +78,7 @@ func (l *UnixStreamListener) Run() {
 			return
 		}
 
-		remoteURI := defn.MakeFDFaceURI(l.nextFD)
-		l.nextFD++
+		remoteURI := defn.MakeFDFaceURI(connFD(newConn))
 		if !remoteURI.IsCanonical() {
 			core.LogWarn(l, "Unable to create face from ", remoteURI, " as remote URI is not canonical")
 			continue
@@ -91,16 +90,73 @@ func (l *UnixStreamListener) Run() {
 			continue
 		}
 
-		core.LogInfo(l, "Accepting new Unix stream face ", remoteURI)
+		if peer := newTransport.PeerCredentials(); peer != nil {
+			core.LogInfo(l, "Accepting new Unix stream face ", remoteURI, " from ", peer)
+		} else {
+			core.LogInfo(l, "Accepting new Unix stream face ", remoteURI)
+		}
 		options := MakeNDNLPLinkServiceOptions()
 		options.IsFragmentationEnabled = false // reliable stream
 		MakeNDNLPLinkService(newTransport, options).Run(nil)
 	}
 }
 
+// listen creates the socket file and sets its permissions.
+func (l *UnixStreamListener) listen() {
+	// Delete any existing socket
+	os.Remove(l.localURI.Path())
+
+	// Create inside folder if not existing
+	sockPath := l.localURI.Path()
+	dirPath := path.Dir(sockPath)
+	os.MkdirAll(dirPath, os.ModePerm)
+
+	// Create listener
+	var err error
+	if l.conn, err = net.Listen(l.localURI.Scheme(), sockPath); err != nil {
+		core.LogFatal(l, "Unable to start Unix stream listener: ", err)
+	}
+
+	// Set permissions to control which local apps can communicate with us
+	if err := os.Chmod(sockPath, unixSocketMode); err != nil {
+		core.LogFatal(l, "Unable to change permissions on Unix stream listener: ", err)
+	}
+	if unixSocketOwner != "" || unixSocketGroup != "" {
+		if err := chownSocket(sockPath, unixSocketOwner, unixSocketGroup); err != nil {
+			core.LogFatal(l, "Unable to change owner of Unix stream listener: ", err)
+		}
+	}
+}
+
+// Close stops the listener. Run must have been called.
 func (l *UnixStreamListener) Close() {
-	if l.conn != nil {
-		l.conn.Close()
-		<-l.stopped
+	// The socket is created by Run
+	<-l.ready
+	l.conn.Close()
+	<-l.stopped
+}
+
+// chownSocket changes the owner and group of the socket file. The owner and group
+// are names or numeric IDs, and are left unchanged if empty.
+func chownSocket(sockPath string, owner string, group string) error {
+	uid, gid := -1, -1
+	if owner != "" {
+		if id, err := strconv.Atoi(owner); err == nil {
+			uid = id
+		} else if u, err := user.Lookup(owner); err == nil {
+			uid, _ = strconv.Atoi(u.Uid)
+		} else {
+			return err
+		}
+	}
+	if group != "" {
+		if id, err := strconv.Atoi(group); err == nil {
+			gid = id
+		} else if g, err := user.LookupGroup(group); err == nil {
+			gid, _ = strconv.Atoi(g.Gid)
+		} else {
+			return err
+		}
 	}
+	return os.Chown(sockPath, uid, gid)
 }
@@ -19,9 +19,21 @@ import (
 // UnixStreamTransport is a Unix stream transport for communicating with local applications.
 type UnixStreamTransport struct {
 	conn *net.UnixConn
+	peer *PeerCredentials
 	transportBase
 }
 
+// PeerCredentials identifies the local process connected to a Unix stream face.
+type PeerCredentials struct {
+	PID int32
+	UID uint32
+	GID uint32
+}
+
+func (c *PeerCredentials) String() string {
+	return fmt.Sprintf("pid=%d uid=%d gid=%d", c.PID, c.UID, c.GID)
+}
+
 // MakeUnixStreamTransport creates a Unix stream transport.
 func MakeUnixStreamTransport(remoteURI *defn.URI, localURI *defn.URI, conn net.Conn) (*UnixStreamTransport, error) {
 	// Validate URIs
@@ -36,9 +48,36 @@ func MakeUnixStreamTransport(remoteURI *defn.URI, localURI *defn.URI, conn net.C
 	t.conn = conn.(*net.UnixConn)
 	t.running.Store(true)
 
+	// Identify the local process
+	if rawConn, err := t.conn.SyscallConn(); err == nil {
+		if pid, uid, gid, err := impl.SyscallGetPeerCredentials(rawConn); err == nil {
+			t.peer = &PeerCredentials{PID: pid, UID: uid, GID: gid}
+		} else {
+			core.LogDebug(t, "Unable to get peer credentials: ", err)
+		}
+	}
+
 	return t, nil
 }
 
+// PeerCredentials returns the credentials of the local process, or nil if unknown.
+func (t *UnixStreamTransport) PeerCredentials() *PeerCredentials {
+	return t.peer
+}
+
+// GetPeerCredentials returns the credentials of the local process connected to a face,
+// or nil if the face is not a Unix stream face or the credentials are unknown.
+func GetPeerCredentials(faceID uint64) *PeerCredentials {
+	linkService := FaceTable.Get(faceID)
+	if linkService == nil {
+		return nil
+	}
+	if t, ok := linkService.Transport().(*UnixStreamTransport); ok {
+		return t.peer
+	}
+	return nil
+}
+
 func (t *UnixStreamTransport) String() string {
 	return fmt.Sprintf("UnixStreamTransport, FaceID=%d, RemoteURI=%s, LocalURI=%s", t.faceID, t.remoteURI, t.localURI)
 }
@@ -10,6 +10,8 @@ package face
 import (
 	"fmt"
 	"net"
+	"sync/atomic"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/named-data/ndnd/fw/core"
@@ -17,9 +19,16 @@ import (
 )
 
 // WebSocketTransport communicates with web applications via WebSocket.
+// It is either accepted by a WebSocketListener or connected to a remote
+// WebSocket server (e.g., a gateway forwarder behind an HTTP-only firewall).
 type WebSocketTransport struct {
 	transportBase
 	c *websocket.Conn
+
+	// Outgoing connection and permanent face reconnection
+	dialer       *websocket.Dialer
+	rechan       chan bool
+	connLocalURI atomic.Pointer[defn.URI] // local address of the current outgoing connection
 }
 
 func NewWebSocketTransport(localURI *defn.URI, c *websocket.Conn) (t *WebSocketTransport) {
@@ -38,12 +47,50 @@ func NewWebSocketTransport(localURI *defn.URI, c *websocket.Conn) (t *WebSocketT
 	return t
 }
 
+// MakeWebSocketTransport makes an outgoing WebSocket transport to a ws:// or wss:// server.
+func MakeWebSocketTransport(remoteURI *defn.URI, persistency Persistency) (*WebSocketTransport, error) {
+	// Validate URI.
+	if !remoteURI.IsCanonical() || (remoteURI.Scheme() != "ws" && remoteURI.Scheme() != "wss") {
+		return nil, core.ErrNotCanonical
+	}
+
+	// Construct transport
+	t := &WebSocketTransport{
+		dialer: &websocket.Dialer{HandshakeTimeout: 10 * time.Second},
+		rechan: make(chan bool, 1),
+	}
+	t.makeTransportBase(remoteURI, nil, persistency, remoteURI.Scope(), defn.PointToPoint, defn.MaxNDNPacketSize)
+
+	// Do not attempt to connect here, since it blocks the management thread.
+	// We will attempt to connect in the receive loop instead, so the local
+	// address is unspecified until the first connection.
+	t.localURI = defn.MakeWebSocketClientFaceURI(&net.TCPAddr{IP: net.IPv4zero})
+
+	return t, nil
+}
+
+// LocalURI returns the local address of the current connection of an outgoing
+// transport, or the listener URI of an accepted transport.
+func (t *WebSocketTransport) LocalURI() *defn.URI {
+	if uri := t.connLocalURI.Load(); uri != nil {
+		return uri
+	}
+	return t.localURI
+}
+
 func (t *WebSocketTransport) String() string {
-	return fmt.Sprintf("WebSocketTransport, FaceID=%d, RemoteURI=%s, LocalURI=%s", t.faceID, t.remoteURI, t.localURI)
+	return fmt.Sprintf("WebSocketTransport, FaceID=%d, RemoteURI=%s, LocalURI=%s", t.faceID, t.remoteURI, t.LocalURI())
 }
 
 func (t *WebSocketTransport) SetPersistency(persistency Persistency) bool {
-	return persistency == PersistencyOnDemand
+	if t.dialer == nil {
+		return persistency == PersistencyOnDemand
+	}
+	if persistency == PersistencyOnDemand {
+		return false
+	}
+	t.persistency = persistency
+	return true
 }
 
 func (t *WebSocketTransport) GetSendQueueSize() uint64 {
@@ -63,16 +110,75 @@ func (t *WebSocketTransport) sendFrame(frame []byte) {
 	e := t.c.WriteMessage(websocket.BinaryMessage, frame)
 	if e != nil {
 		core.LogWarn(t, "Unable to send on socket - DROP and Face DOWN")
-		t.Close()
+		t.CloseConn() // receive might reconnect if needed
 		return
 	}
 
 	t.nOutBytes += uint64(len(frame))
 }
 
+// Attempt to (re)connect to the remote server.
+func (t *WebSocketTransport) reconnect() {
+	first := t.c == nil
+
+	// Shut down the existing connection
+	if !first {
+		t.c.Close()
+	}
+
+	connected := t.redial(first, func(attempt int) bool {
+		c, _, err := t.dialer.Dial(t.remoteURI.String(), nil)
+		if err != nil {
+			core.LogWarn(t, "Unable to connect to remote endpoint [", attempt, "]: ", err)
+			return false
+		}
+
+		// If the transport was closed while we were trying to reconnect,
+		// close the new connection
+		if t.closed.Load() {
+			c.Close()
+			return false
+		}
+
+		// Connected to remote again
+		t.c = c
+		t.connLocalURI.Store(defn.MakeWebSocketClientFaceURI(c.LocalAddr()))
+		return true
+	})
+
+	// Do not block if the transport was closed meanwhile
+	select {
+	case t.rechan <- connected:
+	default:
+	}
+}
+
 func (t *WebSocketTransport) runReceive() {
 	defer t.Close()
 
+	for {
+		// The connection is nil before the first connection attempt
+		// of an outgoing transport.
+		if t.c != nil {
+			t.receive()
+			if t.dialer == nil || t.closed.Load() {
+				return
+			}
+		}
+
+		// Outgoing permanent faces will reconnect, otherwise close
+		go t.reconnect()
+		if !<-t.rechan || t.closed.Load() {
+			return // do not continue
+		}
+
+		core.LogInfo(t, "Connected WebSocket - Face UP")
+		t.setRunning(true)
+	}
+}
+
+// receive reads messages from the connection until it fails.
+func (t *WebSocketTransport) receive() {
 	for {
 		mt, message, e := t.c.ReadMessage()
 		if e != nil {
@@ -83,6 +189,7 @@ func (t *WebSocketTransport) runReceive() {
 			} else {
 				core.LogWarn(t, "Unable to read from WebSocket (", e, ") - DROP and Face DOWN")
 			}
+			t.setRunning(false)
 			return
 		}
 
@@ -101,7 +208,23 @@ func (t *WebSocketTransport) runReceive() {
 	}
 }
 
+// CloseConn closes the connection if running without closing the transport.
+func (t *WebSocketTransport) CloseConn() {
+	if t.setRunning(false) {
+		t.c.Close()
+	}
+}
+
+// Close the transport permanently - this will not attempt to reconnect.
 func (t *WebSocketTransport) Close() {
-	t.running.Store(false)
-	t.c.Close()
+	if t.markClosed() && t.rechan != nil {
+		select {
+		case t.rechan <- false:
+		default:
+		}
+	}
+	t.setRunning(false)
+	if t.c != nil {
+		t.c.Close()
+	}
 }
@@ -67,6 +67,7 @@ func (s *BestRoute) AfterReceiveInterest(
 ) {
 	if len(nexthops) == 0 {
 		core.LogDebug(s, "AfterReceiveInterest: No nexthop for Interest=", packet.Name, " - DROP")
+		s.thread.trace(packet, TraceNoNexthop, 0, "")
 		return
 	}
 
@@ -74,8 +75,9 @@ func (s *BestRoute) AfterReceiveInterest(
 	// retransmission to suppress it (only if the nonce is different)
 	for _, outRecord := range pitEntry.OutRecords() {
 		if outRecord.LatestNonce != *packet.L3.Interest.NonceV &&
-			outRecord.LatestTimestamp.Add(BestRouteSuppressionTime).After(time.Now()) {
+			outRecord.LatestTimestamp.Add(BestRouteSuppressionTime).After(core.Now()) {
 			core.LogDebug(s, "AfterReceiveInterest: Suppressed Interest=", packet.Name, " - DROP")
+			s.thread.trace(packet, TraceSuppressed, 0, "")
 			return
 		}
 	}
@@ -90,6 +92,7 @@ func (s *BestRoute) AfterReceiveInterest(
 	}
 
 	core.LogDebug(s, "AfterReceiveInterest: No usable nexthop for Interest=", packet.Name, " - DROP")
+	s.thread.trace(packet, TraceNoNexthop, 0, "no usable nexthop")
 }
 
 func (s *BestRoute) BeforeSatisfyInterest(pitEntry table.PitEntry, inFace uint64) {
@@ -0,0 +1,116 @@
+package fw
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/dispatch"
+	"github.com/named-data/ndnd/fw/table"
+	enc "github.com/named-data/ndnd/std/encoding"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dropTestInFace = 3
+
+// dropTestFace records the congestion signals sent to the downstream.
+type dropTestFace struct {
+	marked int
+	nacks  []dispatch.OutPkt
+}
+
+func (f *dropTestFace) String() string                 { return "DropTestFace" }
+func (f *dropTestFace) SetFaceID(uint64)               {}
+func (f *dropTestFace) FaceID() uint64                 { return dropTestInFace }
+func (f *dropTestFace) LocalURI() *defn.URI            { return defn.MakeNullFaceURI() }
+func (f *dropTestFace) RemoteURI() *defn.URI           { return defn.MakeNullFaceURI() }
+func (f *dropTestFace) Scope() defn.Scope              { return defn.Local }
+func (f *dropTestFace) LinkType() defn.LinkType        { return defn.PointToPoint }
+func (f *dropTestFace) MTU() int                       { return defn.MaxNDNPacketSize }
+func (f *dropTestFace) State() defn.State              { return defn.Up }
+func (f *dropTestFace) SignalCongestion()              { f.marked++ }
+func (f *dropTestFace) SendPacket(out dispatch.OutPkt) { f.nacks = append(f.nacks, out) }
+
+func makeDropTestInterest(t *testing.T, name string) *defn.Pkt {
+	n, err := enc.NameFromStr(name)
+	require.NoError(t, err)
+	return &defn.Pkt{
+		Name:           n,
+		L3:             &spec.Packet{Interest: &spec.Interest{NameV: n}},
+		IncomingFaceID: utils.IdPtr(uint64(dropTestInFace)),
+		PitToken:       []byte(name),
+	}
+}
+
+func TestDropPolicyAndSignal(t *testing.T) {
+	core.LoadConfig(core.DefaultConfig(), "")
+	core.SetLogLevel("ERROR")
+	table.Configure()
+	defer Configure()
+
+	tests := []struct {
+		policy  string
+		signal  string
+		dropped string
+	}{
+		{"tail", "none", "/drop/3"},
+		{"tail", "mark", "/drop/3"},
+		{"tail", "nack", "/drop/3"},
+		{"oldest", "none", "/drop/1"},
+		{"oldest", "mark", "/drop/1"},
+		{"oldest", "nack", "/drop/1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy+"-"+tt.signal, func(t *testing.T) {
+			config := core.DefaultConfig()
+			config.Fw.QueueSize = 2
+			config.Fw.DropPolicy = tt.policy
+			config.Fw.DropSignal = tt.signal
+			core.LoadConfig(config, "")
+			defer core.LoadConfig(core.DefaultConfig(), "")
+			Configure()
+
+			face := &dropTestFace{}
+			dispatch.AddFace(dropTestInFace, face)
+			defer dispatch.RemoveFace(dropTestInFace)
+
+			// The thread is not running, so the third Interest does not fit
+			thread := NewThread(0)
+			for i := 1; i <= 3; i++ {
+				thread.QueueInterest(makeDropTestInterest(t, "/drop/"+strconv.Itoa(i)))
+			}
+			assert.Equal(t, uint64(1), thread.NDroppedInterests.Load())
+
+			switch tt.signal {
+			case "none":
+				assert.Zero(t, face.marked)
+				assert.Empty(t, face.nacks)
+			case "mark":
+				assert.Equal(t, 1, face.marked)
+				assert.Empty(t, face.nacks)
+			case "nack":
+				assert.Zero(t, face.marked)
+				require.Len(t, face.nacks, 1)
+				nack := face.nacks[0]
+				assert.Equal(t, tt.dropped, nack.Pkt.Name.String())
+				require.NotNil(t, nack.Pkt.NackReason)
+				assert.Equal(t, spec.NackReasonCongestion, *nack.Pkt.NackReason)
+				assert.Equal(t, []byte(tt.dropped), nack.PitToken)
+			}
+
+			// Data is dropped with the same policy, and never signaled
+			n, err := enc.NameFromStr("/drop/data")
+			require.NoError(t, err)
+			for i := 0; i < 3; i++ {
+				thread.QueueData(&defn.Pkt{Name: n, L3: &spec.Packet{Data: &spec.Data{NameV: n}}})
+			}
+			assert.Equal(t, uint64(1), thread.NDroppedData.Load())
+			assert.LessOrEqual(t, face.marked, 1)
+			assert.LessOrEqual(t, len(face.nacks), 1)
+		})
+	}
+}
@@ -7,20 +7,62 @@
 
 package fw
 
-import "github.com/named-data/ndnd/fw/core"
+import (
+	"strings"
+
+	"github.com/named-data/ndnd/fw/core"
+)
 
 // fwQueueSize is the maxmimum number of packets that can be buffered to be processed by a forwarding thread.
 var fwQueueSize int
 
+// fwBatchSize is the maximum number of packets of each type processed by a forwarding thread per wakeup.
+var fwBatchSize int
+
 // NumFwThreads indicates the number of forwarding threads in the forwarder.
 var NumFwThreads int
 
 // lockThreadsToCores indicates whether forwarding threads will be locked to cores.
 var lockThreadsToCores bool
 
+// dropOldest indicates whether the oldest queued packet is dropped when a queue is full,
+// instead of the incoming packet.
+var dropOldest bool
+
+// dropSignal indicates how dropped Interests are signaled to the downstream.
+var dropSignal int
+
+const (
+	dropSignalNone = iota
+	dropSignalMark
+	dropSignalNack
+)
+
 // Configure configures the forwarding system.
 func Configure() {
 	fwQueueSize = core.GetConfig().Fw.QueueSize
+	fwBatchSize = max(core.GetConfig().Fw.BatchSize, 1)
 	NumFwThreads = core.GetConfig().Fw.Threads
 	lockThreadsToCores = core.GetConfig().Fw.LockThreadsToCores
+	ConfigureTrace()
+
+	switch strings.ToLower(core.GetConfig().Fw.DropPolicy) {
+	case "tail":
+		dropOldest = false
+	case "oldest":
+		dropOldest = true
+	default:
+		core.LogFatal("Fw", "Unknown drop policy: ", core.GetConfig().Fw.DropPolicy)
+	}
+
+	switch strings.ToLower(core.GetConfig().Fw.DropSignal) {
+	case "none":
+		dropSignal = dropSignalNone
+	case "mark":
+		dropSignal = dropSignalMark
+	case "nack":
+		dropSignal = dropSignalNack
+	default:
+		core.LogFatal("Fw", "Unknown drop signal: ", core.GetConfig().Fw.DropSignal)
+	}
 }
@@ -65,6 +65,7 @@ func (s *Multicast) AfterReceiveInterest(
 ) {
 	if len(nexthops) == 0 {
 		core.LogDebug(s, "AfterReceiveInterest: No nexthop for Interest=", packet.Name, " - DROP")
+		s.thread.trace(packet, TraceNoNexthop, 0, "")
 		return
 	}
 
@@ -72,8 +73,9 @@ func (s *Multicast) AfterReceiveInterest(
 	// retransmission to suppress it (only if the nonce is different)
 	for _, outRecord := range pitEntry.OutRecords() {
 		if outRecord.LatestNonce != *packet.L3.Interest.NonceV &&
-			outRecord.LatestTimestamp.Add(MulticastSuppressionTime).After(time.Now()) {
+			outRecord.LatestTimestamp.Add(MulticastSuppressionTime).After(core.Now()) {
 			core.LogDebug(s, "AfterReceiveInterest: Suppressed Interest=", packet.Name, " - DROP")
+			s.thread.trace(packet, TraceSuppressed, 0, "")
 			return
 		}
 	}
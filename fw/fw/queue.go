@@ -0,0 +1,111 @@
+package fw
+
+import (
+	"sync/atomic"
+
+	"github.com/named-data/ndnd/fw/defn"
+)
+
+// pktQueue is a bounded lock-free multi-producer multi-consumer ring buffer of packets.
+// Each slot carries a sequence number that tells producers and consumers whether
+// it is free or filled for the current lap around the ring (Vyukov's algorithm).
+// Producers may also pop packets to implement the oldest drop policy.
+type pktQueue struct {
+	mask  uint64
+	slots []pktQueueSlot
+
+	_       [56]byte // keep the heads on separate cache lines
+	enqueue atomic.Uint64
+	_       [56]byte
+	dequeue atomic.Uint64
+	_       [56]byte
+}
+
+type pktQueueSlot struct {
+	seq atomic.Uint64
+	pkt *defn.Pkt
+}
+
+// newPktQueue creates a queue holding at least size packets.
+// The capacity is rounded up to a power of two.
+func newPktQueue(size int) *pktQueue {
+	capacity := uint64(1)
+	for capacity < uint64(max(size, 1)) {
+		capacity <<= 1
+	}
+
+	q := &pktQueue{
+		mask:  capacity - 1,
+		slots: make([]pktQueueSlot, capacity),
+	}
+	for i := range q.slots {
+		q.slots[i].seq.Store(uint64(i))
+	}
+	return q
+}
+
+// push adds a packet to the tail of the queue. It returns false if the queue is full.
+func (q *pktQueue) push(pkt *defn.Pkt) bool {
+	pos := q.enqueue.Load()
+	for {
+		slot := &q.slots[pos&q.mask]
+		seq := slot.seq.Load()
+		switch diff := int64(seq - pos); {
+		case diff == 0:
+			if q.enqueue.CompareAndSwap(pos, pos+1) {
+				slot.pkt = pkt
+				slot.seq.Store(pos + 1)
+				return true
+			}
+			pos = q.enqueue.Load()
+		case diff < 0:
+			// The slot still holds a packet from the previous lap
+			return false
+		default:
+			pos = q.enqueue.Load()
+		}
+	}
+}
+
+// pop removes the packet at the head of the queue. It returns nil if the queue is empty.
+func (q *pktQueue) pop() *defn.Pkt {
+	pos := q.dequeue.Load()
+	for {
+		slot := &q.slots[pos&q.mask]
+		seq := slot.seq.Load()
+		switch diff := int64(seq - (pos + 1)); {
+		case diff == 0:
+			if q.dequeue.CompareAndSwap(pos, pos+1) {
+				pkt := slot.pkt
+				slot.pkt = nil
+				slot.seq.Store(pos + q.mask + 1)
+				return pkt
+			}
+			pos = q.dequeue.Load()
+		case diff < 0:
+			// The slot has not been filled yet
+			return nil
+		default:
+			pos = q.dequeue.Load()
+		}
+	}
+}
+
+// popBatch appends packets from the head of the queue to batch,
+// until the capacity of batch is reached or the queue is empty.
+func (q *pktQueue) popBatch(batch []*defn.Pkt) []*defn.Pkt {
+	for len(batch) < cap(batch) {
+		pkt := q.pop()
+		if pkt == nil {
+			break
+		}
+		batch = append(batch, pkt)
+	}
+	return batch
+}
+
+// len returns the approximate number of packets in the queue.
+func (q *pktQueue) len() int {
+	n := int64(q.enqueue.Load() - q.dequeue.Load())
+	return int(max(n, 0))
+}
@@ -0,0 +1,119 @@
+package fw
+
+import (
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/dispatch"
+	"github.com/named-data/ndnd/fw/table"
+	enc "github.com/named-data/ndnd/std/encoding"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	strategyInFace   = 11
+	strategyOutFace1 = 12
+	strategyOutFace2 = 13
+)
+
+// setupStrategyThread creates a forwarding thread on a manual clock, whose pipelines are
+// called directly by the test, with Interests from strategyInFace routed to both out faces.
+func setupStrategyThread(t *testing.T, strategy string) (*Thread, *core.ManualClock, []*benchFace) {
+	clock := core.NewManualClock(time.Unix(1000, 0))
+	prevClock := core.SetClock(clock)
+	t.Cleanup(func() { core.SetClock(prevClock) })
+
+	core.LoadConfig(core.DefaultConfig(), "")
+	core.SetLogLevel("ERROR")
+	Configure()
+	table.Configure()
+	table.CreateFIBTable("nametree")
+
+	out := []*benchFace{{id: strategyOutFace1}, {id: strategyOutFace2}}
+	dispatch.AddFace(strategyInFace, &benchFace{id: strategyInFace})
+	for _, f := range out {
+		dispatch.AddFace(f.id, f)
+	}
+	t.Cleanup(func() {
+		dispatch.RemoveFace(strategyInFace)
+		for _, f := range out {
+			dispatch.RemoveFace(f.id)
+		}
+	})
+
+	prefix := enc.Name{enc.NewStringComponent(enc.TypeGenericNameComponent, "strategy")}
+	table.FibStrategyTable.InsertNextHopEnc(prefix, strategyOutFace1, 10)
+	table.FibStrategyTable.InsertNextHopEnc(prefix, strategyOutFace2, 20)
+	strategyName, err := enc.NameFromStr(StrategyPrefix + "/" + strategy + "/v=1")
+	assert.NoError(t, err)
+	table.FibStrategyTable.SetStrategyEnc(prefix, strategyName)
+
+	thread := NewThread(0)
+	Threads = []*Thread{thread}
+	dispatch.InitializeFWThreads([]dispatch.FWThread{thread})
+	return thread, clock, out
+}
+
+// makeStrategyInterest makes an Interest for /strategy/1, as received from strategyInFace.
+func makeStrategyInterest(nonce uint32) *defn.Pkt {
+	name := enc.Name{
+		enc.NewStringComponent(enc.TypeGenericNameComponent, "strategy"),
+		enc.NewSequenceNumComponent(1),
+	}
+	return &defn.Pkt{
+		Name:           name,
+		NameHash:       name.Hash(),
+		L3:             &spec.Packet{Interest: &spec.Interest{NameV: name, NonceV: utils.IdPtr(nonce)}},
+		IncomingFaceID: utils.IdPtr(uint64(strategyInFace)),
+	}
+}
+
+func TestBestRouteSuppression(t *testing.T) {
+	thread, clock, out := setupStrategyThread(t, "best-route")
+
+	// Only the cheapest nexthop is used
+	thread.processIncomingInterest(makeStrategyInterest(1))
+	assert.Equal(t, int64(1), out[0].sent.Load())
+	assert.Equal(t, int64(0), out[1].sent.Load())
+
+	// Retransmissions are suppressed during the suppression interval
+	clock.Advance(BestRouteSuppressionTime - time.Millisecond)
+	thread.processIncomingInterest(makeStrategyInterest(2))
+	assert.Equal(t, int64(1), out[0].sent.Load())
+
+	// and forwarded again after it
+	clock.Advance(2 * time.Millisecond)
+	thread.processIncomingInterest(makeStrategyInterest(3))
+	assert.Equal(t, int64(2), out[0].sent.Load())
+	assert.Equal(t, int64(0), out[1].sent.Load())
+
+	// The interval restarts from the last forwarded Interest
+	clock.Advance(BestRouteSuppressionTime / 2)
+	thread.processIncomingInterest(makeStrategyInterest(4))
+	assert.Equal(t, int64(2), out[0].sent.Load())
+}
+
+func TestMulticastSuppression(t *testing.T) {
+	thread, clock, out := setupStrategyThread(t, "multicast")
+
+	// All nexthops are used
+	thread.processIncomingInterest(makeStrategyInterest(1))
+	assert.Equal(t, int64(1), out[0].sent.Load())
+	assert.Equal(t, int64(1), out[1].sent.Load())
+
+	// Retransmissions are suppressed during the suppression interval
+	clock.Advance(MulticastSuppressionTime - time.Millisecond)
+	thread.processIncomingInterest(makeStrategyInterest(2))
+	assert.Equal(t, int64(1), out[0].sent.Load())
+	assert.Equal(t, int64(1), out[1].sent.Load())
+
+	// and forwarded again to all nexthops after it
+	clock.Advance(2 * time.Millisecond)
+	thread.processIncomingInterest(makeStrategyInterest(3))
+	assert.Equal(t, int64(2), out[0].sent.Load())
+	assert.Equal(t, int64(2), out[1].sent.Load())
+}
@@ -12,12 +12,14 @@ import (
 	"encoding/binary"
 	"runtime"
 	"strconv"
+	"sync/atomic"
 
 	"github.com/named-data/ndnd/fw/core"
 	"github.com/named-data/ndnd/fw/defn"
 	"github.com/named-data/ndnd/fw/dispatch"
 	"github.com/named-data/ndnd/fw/table"
 	enc "github.com/named-data/ndnd/std/encoding"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
 	"github.com/named-data/ndnd/std/utils"
 )
 
@@ -30,6 +32,11 @@ var LOCALHOST = []byte{0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x68, 0x6f, 0x73, 0x74}
 
 // HashNameToFwThread hashes an NDN name to a forwarding thread.
 func HashNameToFwThread(name enc.Name) int {
+	return HashToFwThread(name, name.Hash())
+}
+
+// HashToFwThread maps an NDN name to a forwarding thread, given the precomputed hash of the name.
+func HashToFwThread(name enc.Name, hash uint64) int {
 	// Dispatch all management requests to thread 0
 	// this is fine, all it does is make sure the pitcs table in thread 0 has the management stuff.
 	// This is not actually touching management.
@@ -37,12 +44,19 @@ func HashNameToFwThread(name enc.Name) int {
 		return 0
 	}
 	// to prevent negative modulos because we converted from uint to int
-	return int(name.Hash() % uint64(len(Threads)))
+	return int(hash % uint64(len(Threads)))
 }
 
 // HashNameToAllPrefixFwThreads hashes an NDN name to all forwarding threads for all prefixes of the name.
 // The return value is a boolean map of which threads match the name
 func HashNameToAllPrefixFwThreads(name enc.Name) []bool {
+	return PrefixHashToAllFwThreads(name, name.PrefixHash())
+}
+
+// PrefixHashToAllFwThreads maps all prefixes of an NDN name to forwarding threads,
+// given the precomputed hashes of the prefixes (see enc.Name.PrefixHash).
+// The return value is a boolean map of which threads match the name
+func PrefixHashToAllFwThreads(name enc.Name, prefixHash []uint64) []bool {
 	threads := make([]bool, len(Threads))
 
 	// Dispatch all management requests to thread 0
@@ -51,7 +65,6 @@ func HashNameToAllPrefixFwThreads(name enc.Name) []bool {
 		return threads
 	}
 
-	prefixHash := name.PrefixHash()
 	for i := 1; i < len(prefixHash); i++ {
 		thread := int(prefixHash[i] % uint64(len(Threads)))
 		threads[thread] = true
@@ -62,34 +75,72 @@ func HashNameToAllPrefixFwThreads(name enc.Name) []bool {
 // Thread Represents a forwarding thread
 type Thread struct {
 	threadID         int
-	pendingInterests chan *defn.Pkt
-	pendingDatas     chan *defn.Pkt
+	pendingInterests *pktQueue
+	pendingDatas     *pktQueue
+	wake             chan struct{}
+	wakeSignaled     atomic.Bool
 	pitCS            table.PitCsTable
 	strategies       map[uint64]Strategy
 	deadNonceList    *table.DeadNonceList
+	csErase          chan csEraseRequest
 	shouldQuit       chan interface{}
 	HasQuit          chan interface{}
 
 	// Counters
-	NInInterests          uint64
-	NInData               uint64
-	NOutInterests         uint64
-	NOutData              uint64
-	NSatisfiedInterests   uint64
-	NUnsatisfiedInterests uint64
+	NInInterests          atomic.Uint64
+	NInData               atomic.Uint64
+	NOutInterests         atomic.Uint64
+	NOutData              atomic.Uint64
+	NSatisfiedInterests   atomic.Uint64
+	NUnsatisfiedInterests atomic.Uint64
+	NDroppedInterests     atomic.Uint64
+	NDroppedData          atomic.Uint64
+
+	// Sizes of the tables, published by the thread for other goroutines
+	nPitEntries atomic.Int64
+	nCsEntries  atomic.Int64
+
+	// PitLifetime records the time (in seconds) between the creation and the
+	// removal of each PIT entry of this thread.
+	PitLifetime *core.Histogram
+
+	// InterestDropBursts and DataDropBursts record the number of packets dropped
+	// in a row from each queue, observed once the queue accepts a packet again.
+	InterestDropBursts *core.Histogram
+	DataDropBursts     *core.Histogram
+	interestDropBurst  atomic.Uint64
+	dataDropBurst      atomic.Uint64
 }
 
+// csEraseRequest is a request from management to erase Content Store entries in a forwarding thread.
+type csEraseRequest struct {
+	prefix enc.Name
+	limit  int
+	erased chan int
+}
+
+// pitLifetimeBuckets are the upper bounds (in seconds) of the PIT lifetime histogram.
+var pitLifetimeBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// dropBurstBuckets are the upper bounds (in packets) of the queue drop burst histograms.
+var dropBurstBuckets = []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 5000}
+
 // NewThread creates a new forwarding thread
 func NewThread(id int) *Thread {
 	t := new(Thread)
 	t.threadID = id
-	t.pendingInterests = make(chan *defn.Pkt, fwQueueSize)
-	t.pendingDatas = make(chan *defn.Pkt, fwQueueSize)
+	t.pendingInterests = newPktQueue(fwQueueSize)
+	t.pendingDatas = newPktQueue(fwQueueSize)
+	t.wake = make(chan struct{}, 1)
 	t.pitCS = table.NewPitCS(t.finalizeInterest)
 	t.strategies = InstantiateStrategies(t)
 	t.deadNonceList = table.NewDeadNonceList()
+	t.csErase = make(chan csEraseRequest)
 	t.shouldQuit = make(chan interface{}, 1)
 	t.HasQuit = make(chan interface{})
+	t.PitLifetime = core.NewHistogram(pitLifetimeBuckets)
+	t.InterestDropBursts = core.NewHistogram(dropBurstBuckets)
+	t.DataDropBursts = core.NewHistogram(dropBurstBuckets)
 	return t
 }
 
@@ -103,13 +154,35 @@ func (t *Thread) GetID() int {
 }
 
 // GetNumPitEntries returns the number of entries in this thread's PIT.
+// It may be called from any goroutine.
 func (t *Thread) GetNumPitEntries() int {
-	return t.pitCS.PitSize()
+	return int(t.nPitEntries.Load())
 }
 
 // GetNumCsEntries returns the number of entries in this thread's ContentStore.
+// It may be called from any goroutine.
 func (t *Thread) GetNumCsEntries() int {
-	return t.pitCS.CsSize()
+	return int(t.nCsEntries.Load())
+}
+
+// publishTableSizes publishes the sizes of the PIT and the CS for GetNumPitEntries
+// and GetNumCsEntries, since the tables may only be accessed by this thread.
+func (t *Thread) publishTableSizes() {
+	t.nPitEntries.Store(int64(t.pitCS.PitSize()))
+	t.nCsEntries.Store(int64(t.pitCS.CsSize()))
+}
+
+// EraseCs erases up to limit entries under a prefix from this thread's Content Store,
+// and returns the number of erased entries. It may be called from any goroutine, and
+// waits for the forwarding thread to erase the entries.
+func (t *Thread) EraseCs(prefix enc.Name, limit int) int {
+	req := csEraseRequest{prefix: prefix, limit: limit, erased: make(chan int, 1)}
+	select {
+	case t.csErase <- req:
+		return <-req.erased
+	case <-t.HasQuit:
+		return 0
+	}
 }
 
 // TellToQuit tells the forwarding thread to quit
@@ -124,43 +197,164 @@ func (t *Thread) Run() {
 		runtime.LockOSThread()
 	}
 
+	interests := make([]*defn.Pkt, 0, fwBatchSize)
+	datas := make([]*defn.Pkt, 0, fwBatchSize)
+
 	pitUpdateTimer := t.pitCS.UpdateTimer()
-	for !core.ShouldQuit {
+loop:
+	for !core.ShouldQuit.Load() {
 		select {
-		case pendingPacket := <-t.pendingInterests:
-			t.processIncomingInterest(pendingPacket)
-		case pendingPacket := <-t.pendingDatas:
-			t.processIncomingData(pendingPacket)
-		case <-t.deadNonceList.Ticker.C:
+		case <-t.wake:
+			t.wakeSignaled.Store(false)
+			interests, datas = t.processBatch(interests, datas)
+		case <-t.deadNonceList.Ticker.C():
 			t.deadNonceList.RemoveExpiredEntries()
 		case <-pitUpdateTimer:
 			t.pitCS.Update()
+			t.publishTableSizes()
+		case req := <-t.csErase:
+			req.erased <- t.pitCS.EraseCsDataByPrefix(req.prefix, req.limit)
+			t.publishTableSizes()
 		case <-t.shouldQuit:
-			continue
+			break loop
 		}
 	}
 
 	t.deadNonceList.Ticker.Stop()
 
 	core.LogInfo(t, "Stopping thread")
-	t.HasQuit <- true
+	close(t.HasQuit) // also releases EraseCs callers
+}
+
+// processBatch processes up to one batch of pending Data packets and one batch of
+// pending Interests. The buffers are returned emptied for reuse by the next wakeup.
+func (t *Thread) processBatch(interests []*defn.Pkt, datas []*defn.Pkt) ([]*defn.Pkt, []*defn.Pkt) {
+	// Data is processed first, since it may satisfy PIT entries
+	// that the Interests of the batch would otherwise aggregate into
+	datas = t.pendingDatas.popBatch(datas)
+	for _, pkt := range datas {
+		t.processIncomingData(pkt)
+	}
+	clear(datas)
+
+	interests = t.pendingInterests.popBatch(interests)
+	for _, pkt := range interests {
+		t.processIncomingInterest(pkt)
+	}
+	clear(interests)
+
+	t.publishTableSizes()
+
+	// Continue with the next batch after checking timers
+	if t.pendingDatas.len() > 0 || t.pendingInterests.len() > 0 {
+		t.signal()
+	}
+
+	return interests[:0], datas[:0]
+}
+
+// signal wakes up the forwarding thread to process queued packets.
+// Only the first producer after each wakeup writes to the channel.
+func (t *Thread) signal() {
+	if t.wakeSignaled.CompareAndSwap(false, true) {
+		t.wake <- struct{}{}
+	}
 }
 
 // QueueInterest queues an Interest for processing by this forwarding thread.
 func (t *Thread) QueueInterest(interest *defn.Pkt) {
-	select {
-	case t.pendingInterests <- interest:
-	default:
+	dropped, lost := enqueue(t.pendingInterests, interest)
+	t.signal()
+	if dropped == nil {
+		endDropBurst(&t.interestDropBurst, t.InterestDropBursts)
+		return
+	}
+
+	for _, pkt := range [...]*defn.Pkt{dropped, lost} {
+		if pkt == nil {
+			continue
+		}
+		t.interestDropBurst.Add(1)
+		t.NDroppedInterests.Add(1)
 		core.LogError(t, "Interest dropped due to full queue")
+		signalDroppedInterest(pkt)
 	}
 }
 
 // QueueData queues a Data packet for processing by this forwarding thread.
 func (t *Thread) QueueData(data *defn.Pkt) {
-	select {
-	case t.pendingDatas <- data:
-	default:
-		core.LogError(t, "Data dropped due to full queue")
+	dropped, lost := enqueue(t.pendingDatas, data)
+	t.signal()
+	if dropped == nil {
+		endDropBurst(&t.dataDropBurst, t.DataDropBursts)
+		return
+	}
+
+	n := uint64(1)
+	if lost != nil {
+		n++
+	}
+	t.dataDropBurst.Add(n)
+	t.NDroppedData.Add(n)
+	core.LogError(t, "Data dropped due to full queue")
+}
+
+// endDropBurst records the length of the current drop burst, if any, and resets it.
+func endDropBurst(burst *atomic.Uint64, hist *core.Histogram) {
+	// Avoid writing to the shared counter when nothing was dropped
+	if burst.Load() == 0 {
+		return
+	}
+	if n := burst.Swap(0); n > 0 {
+		hist.Observe(float64(n))
+	}
+}
+
+// enqueue adds a packet to a queue. If the queue is full, a packet is dropped
+// according to the drop policy and returned. When dropping the oldest packet,
+// other producers may fill the queue again before the packet is added, in which
+// case both packets are dropped, and the oldest one is returned as lost.
+func enqueue(queue *pktQueue, pkt *defn.Pkt) (dropped *defn.Pkt, lost *defn.Pkt) {
+	if queue.push(pkt) {
+		return nil, nil
+	}
+
+	if !dropOldest {
+		return pkt, nil
+	}
+
+	// Make room by dropping the packet at the head of the queue
+	oldest := queue.pop()
+	if queue.push(pkt) {
+		return oldest, nil
+	}
+
+	// Other producers filled the queue again
+	return pkt, oldest
+}
+
+// signalDroppedInterest signals a dropped Interest to its incoming face,
+// according to the drop signal setting.
+func signalDroppedInterest(interest *defn.Pkt) {
+	if dropSignal == dropSignalNone || interest == nil || interest.IncomingFaceID == nil {
+		return
+	}
+
+	incomingFace := dispatch.GetFace(*interest.IncomingFaceID)
+	if incomingFace == nil {
+		return
+	}
+
+	switch dropSignal {
+	case dropSignalMark:
+		incomingFace.SignalCongestion()
+	case dropSignalNack:
+		nack := *interest
+		nack.NackReason = utils.IdPtr(spec.NackReasonCongestion)
+		incomingFace.SendPacket(dispatch.OutPkt{
+			Pkt:      &nack,
+			PitToken: interest.PitToken,
+		})
 	}
 }
 
@@ -184,9 +378,12 @@ func (t *Thread) processIncomingInterest(packet *defn.Pkt) {
 		return
 	}
 
+	t.trace(packet, TraceReceived, incomingFace.FaceID(), "")
+
 	if interest.HopLimitV != nil {
 		core.LogTrace(t, "Interest ", packet.Name, " has HopLimit=", *interest.HopLimitV)
 		if *interest.HopLimitV == 0 {
+			t.trace(packet, TraceHopLimit, 0, "")
 			return
 		}
 		*interest.HopLimitV -= 1
@@ -200,10 +397,28 @@ func (t *Thread) processIncomingInterest(packet *defn.Pkt) {
 		len(interest.NameV) > 0 &&
 		bytes.Equal(interest.NameV[0].Val, LOCALHOST) {
 		core.LogWarn(t, "Interest ", packet.Name, " from non-local face=", incomingFace.FaceID(), " violates /localhost scope - DROP")
+		t.trace(packet, TraceScopeViolation, 0, "")
 		return
 	}
 
-	t.NInInterests++
+	// Check packet filter
+	if !table.Filter.Allows(table.FilterIn, table.FilterInterest, interest.NameV, incomingFace) {
+		core.LogDebug(t, "Interest ", packet.Name, " from FaceID=", incomingFace.FaceID(), " is denied by filter - DROP")
+		t.trace(packet, TraceFiltered, 0, "")
+		return
+	}
+
+	t.NInInterests.Add(1)
+
+	// Only finish the pending Interests when shutting down, but keep management
+	// reachable to follow the shutdown
+	if core.Draining.Load() &&
+		!(len(interest.NameV) > 0 && bytes.Equal(interest.NameV[0].Val, LOCALHOST)) &&
+		t.pitCS.FindInterestExactMatchEnc(interest) == nil {
+		core.LogDebug(t, "Interest ", packet.Name, " received while shutting down - DROP")
+		t.trace(packet, TraceDraining, 0, "")
+		return
+	}
 
 	// Check for forwarding hint and, if present, determine if reaching producer region (and then strip forwarding hint)
 	isReachingProducerRegion := true
@@ -212,7 +427,7 @@ func (t *Thread) processIncomingInterest(packet *defn.Pkt) {
 	if hint != nil && len(hint.Names) > 0 {
 		isReachingProducerRegion = false
 		for _, fh := range hint.Names {
-			if table.NetworkRegion.IsProducer(fh) {
+			if table.NetworkRegion().IsProducer(fh) {
 				isReachingProducerRegion = true
 				break
 			} else if fhName == nil {
@@ -229,12 +444,14 @@ func (t *Thread) processIncomingInterest(packet *defn.Pkt) {
 	// Drop packet if no nonce is found
 	if interest.NonceV == nil {
 		core.LogDebug(t, "Interest ", packet.Name, " is missing Nonce - DROP")
+		t.trace(packet, TraceMissingNonce, 0, "")
 		return
 	}
 
 	// Check if packet is in dead nonce list
 	if exists := t.deadNonceList.Find(interest.NameV, *interest.NonceV); exists {
 		core.LogDebug(t, "Interest ", packet.Name, " is dropped by DeadNonce: ", *interest.NonceV)
+		t.trace(packet, TraceDeadNonce, 0, "")
 		return
 	}
 
@@ -244,12 +461,14 @@ func (t *Thread) processIncomingInterest(packet *defn.Pkt) {
 	if isDuplicate {
 		// Interest loop - since we don't use Nacks, just drop
 		core.LogDebug(t, "Interest ", packet.Name, " is looping - DROP")
+		t.trace(packet, TraceLoop, 0, "")
 		return
 	}
 
 	// Get strategy for name
 	strategyName := table.FibStrategyTable.FindStrategyEnc(interest.NameV)
 	strategy := t.strategies[strategyName.Hash()]
+	t.trace(packet, TraceStrategy, 0, strategyName.String())
 
 	// Add in-record and determine if already pending
 	// this looks like custom interest again, but again can be changed without much issue?
@@ -272,7 +491,12 @@ func (t *Thread) processIncomingInterest(packet *defn.Pkt) {
 					packet.L3.Interest = nil
 					packet.Raw = csWire
 					packet.Name = csData.NameV
+					t.trace(packet, TraceCsHit, 0, "")
 					strategy.AfterContentStoreHit(packet, pitEntry, incomingFace.FaceID())
+
+					// The entry was only created to look up the CS
+					pitEntry.SetSatisfied(true)
+					table.SetExpirationTimerToNow(pitEntry)
 					return
 				} else if err != nil {
 					core.LogError(t, "Error copying CS entry: ", err)
@@ -284,6 +508,7 @@ func (t *Thread) processIncomingInterest(packet *defn.Pkt) {
 		}
 	} else {
 		core.LogTrace(t, "Interest ", packet.Name, " is already pending")
+		t.trace(packet, TraceAggregated, 0, "")
 
 		// Add the previous nonce to the dead nonce list to prevent further looping
 		// TODO: review this design, not specified in NFD dev guide
@@ -302,8 +527,10 @@ func (t *Thread) processIncomingInterest(packet *defn.Pkt) {
 				PitToken: packet.PitToken, // TODO: ??
 				InFace:   packet.IncomingFaceID,
 			})
+			t.trace(packet, TraceForwarded, *packet.NextHopFaceID, "NextHopFaceId")
 		} else {
 			core.LogInfo(t, "Non-existent face specified in NextHopFaceId for Interest ", packet.Name, " - DROP")
+			t.trace(packet, TraceNoFace, *packet.NextHopFaceID, "NextHopFaceId")
 		}
 		return
 	}
@@ -348,6 +575,7 @@ func (t *Thread) processOutgoingInterest(
 	outgoingFace := dispatch.GetFace(nexthop)
 	if outgoingFace == nil {
 		core.LogError(t, "Non-existent nexthop FaceID=", nexthop, " for Interest=", packet.Name, " - DROP")
+		t.trace(packet, TraceNoFace, nexthop, "")
 		return false
 	}
 	if outgoingFace.FaceID() == inFace && outgoingFace.LinkType() != defn.AdHoc {
@@ -359,13 +587,21 @@ func (t *Thread) processOutgoingInterest(
 	if interest.HopLimitV != nil && int(*interest.HopLimitV) == 0 &&
 		outgoingFace.Scope() == defn.NonLocal {
 		core.LogDebug(t, "Attempting to send Interest=", packet.Name, " with HopLimit=0 to non-local face - DROP")
+		t.trace(packet, TraceHopLimit, nexthop, "")
+		return false
+	}
+
+	// Check packet filter
+	if !table.Filter.Allows(table.FilterOut, table.FilterInterest, interest.NameV, outgoingFace) {
+		core.LogDebug(t, "Interest ", packet.Name, " to FaceID=", nexthop, " is denied by filter - DROP")
+		t.trace(packet, TraceFiltered, nexthop, "")
 		return false
 	}
 
 	// Create or update out-record
 	pitEntry.InsertOutRecord(interest, nexthop)
 
-	t.NOutInterests++
+	t.NOutInterests.Add(1)
 
 	// Make new PIT token if needed
 	pitToken := make([]byte, 6)
@@ -378,6 +614,7 @@ func (t *Thread) processOutgoingInterest(
 		PitToken: pitToken,
 		InFace:   utils.IdPtr(inFace),
 	})
+	t.trace(packet, TraceForwarded, nexthop, "")
 
 	return true
 }
@@ -390,8 +627,9 @@ func (t *Thread) finalizeInterest(pitEntry table.PitEntry) {
 
 	// Counters
 	if !pitEntry.Satisfied() {
-		t.NUnsatisfiedInterests += uint64(len(pitEntry.InRecords()))
+		t.NUnsatisfiedInterests.Add(uint64(len(pitEntry.InRecords())))
 	}
+	t.PitLifetime.Observe(core.Since(pitEntry.CreationTime()).Seconds())
 }
 
 func (t *Thread) processIncomingData(packet *defn.Pkt) {
@@ -420,18 +658,41 @@ func (t *Thread) processIncomingData(packet *defn.Pkt) {
 		return
 	}
 
-	t.NInData++
+	t.NInData.Add(1)
+	t.trace(packet, TraceReceived, incomingFace.FaceID(), "")
 
 	// Check if violates /localhost
 	if incomingFace.Scope() == defn.NonLocal && len(packet.Name) > 0 &&
 		bytes.Equal(data.NameV[0].Val, LOCALHOST) {
 		core.LogWarn(t, "Data ", packet.Name, " from non-local FaceID=", *packet.IncomingFaceID, " violates /localhost scope - DROP")
+		t.trace(packet, TraceScopeViolation, 0, "")
+		return
+	}
+
+	// Check packet filter
+	if !table.Filter.Allows(table.FilterIn, table.FilterData, data.NameV, incomingFace) {
+		core.LogDebug(t, "Data ", packet.Name, " from FaceID=", *packet.IncomingFaceID, " is denied by filter - DROP")
+		t.trace(packet, TraceFiltered, 0, "")
+		return
+	}
+
+	// Verify signature if required by the verification policy
+	admit := true
+	switch table.Verifier.Verify(data, packet.SigCovered) {
+	case table.VerifyInvalid:
+		core.LogInfo(t, "Data ", packet.Name, " from FaceID=", *packet.IncomingFaceID, " has an invalid signature - DROP")
+		t.trace(packet, TraceInvalidSig, 0, "")
 		return
+	case table.VerifyUnknownKey:
+		core.LogDebug(t, "Data ", packet.Name, " is signed by an unknown key - not caching")
+		t.trace(packet, TraceUnverified, 0, "")
+		admit = false
 	}
 
 	// Add to Content Store
-	if t.pitCS.IsCsAdmitting() {
-		t.pitCS.InsertData(data, packet.Raw)
+	if admit && t.pitCS.IsCsAdmitting() {
+		t.pitCS.InsertData(data, packet.NameHash, packet.Raw)
+		t.trace(packet, TraceCached, 0, "")
 	}
 
 	// Check for matching PIT entries
@@ -439,12 +700,15 @@ func (t *Thread) processIncomingData(packet *defn.Pkt) {
 	if len(pitEntries) == 0 {
 		// Unsolicited Data - nothing more to do
 		core.LogDebug(t, "Unsolicited data ", packet.Name, " FaceID=", *packet.IncomingFaceID, " - DROP")
+		t.trace(packet, TraceUnsolicited, 0, "")
 		return
 	}
 
 	// Get strategy for name
 	strategyName := table.FibStrategyTable.FindStrategyEnc(data.NameV)
 	strategy := t.strategies[strategyName.Hash()]
+	t.trace(packet, TraceSatisfied, 0, strconv.Itoa(len(pitEntries))+" PIT entries")
+	t.trace(packet, TraceStrategy, 0, strategyName.String())
 
 	if len(pitEntries) == 1 {
 		// When a single PIT entry matches, we pass the data to the strategy.
@@ -529,17 +793,26 @@ func (t *Thread) processOutgoingData(
 	outgoingFace := dispatch.GetFace(nexthop)
 	if outgoingFace == nil {
 		core.LogError(t, "Non-existent nexthop FaceID=", nexthop, " for Data=", packet.Name, " - DROP")
+		t.trace(packet, TraceNoFace, nexthop, "")
 		return
 	}
 
 	// Check if violates /localhost
 	if outgoingFace.Scope() == defn.NonLocal && len(data.NameV) > 0 && bytes.Equal(data.NameV[0].Val, LOCALHOST) {
 		core.LogWarn(t, "Data ", packet.Name, " cannot be sent to non-local FaceID=", nexthop, " since violates /localhost scope - DROP")
+		t.trace(packet, TraceScopeViolation, nexthop, "")
+		return
+	}
+
+	// Check packet filter
+	if !table.Filter.Allows(table.FilterOut, table.FilterData, data.NameV, outgoingFace) {
+		core.LogDebug(t, "Data ", packet.Name, " to FaceID=", nexthop, " is denied by filter - DROP")
+		t.trace(packet, TraceFiltered, nexthop, "")
 		return
 	}
 
-	t.NOutData++
-	t.NSatisfiedInterests++
+	t.NOutData.Add(1)
+	t.NSatisfiedInterests.Add(1)
 
 	// Send on outgoing face
 	outgoingFace.SendPacket(dispatch.OutPkt{
@@ -547,4 +820,5 @@ func (t *Thread) processOutgoingData(
 		PitToken: pitToken,
 		InFace:   utils.IdPtr(inFace),
 	})
+	t.trace(packet, TraceForwarded, nexthop, "")
 }
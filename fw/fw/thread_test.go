@@ -0,0 +1,367 @@
+package fw
+
+import (
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/dispatch"
+	"github.com/named-data/ndnd/fw/table"
+	enc "github.com/named-data/ndnd/std/encoding"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	benchInFace  = 1
+	benchOutFace = 2
+)
+
+// benchFace is a face that counts the packets sent on it.
+type benchFace struct {
+	id     uint64
+	sent   atomic.Int64
+	target int64
+	done   chan struct{}
+}
+
+func (f *benchFace) String() string             { return "BenchFace" }
+func (f *benchFace) SetFaceID(faceID uint64)    { f.id = faceID }
+func (f *benchFace) FaceID() uint64             { return f.id }
+func (f *benchFace) LocalURI() *defn.URI        { return defn.MakeNullFaceURI() }
+func (f *benchFace) RemoteURI() *defn.URI       { return defn.MakeNullFaceURI() }
+func (f *benchFace) Scope() defn.Scope          { return defn.Local }
+func (f *benchFace) LinkType() defn.LinkType    { return defn.PointToPoint }
+func (f *benchFace) MTU() int                   { return defn.MaxNDNPacketSize }
+func (f *benchFace) State() defn.State          { return defn.Up }
+func (f *benchFace) SignalCongestion()          {}
+func (f *benchFace) SendPacket(dispatch.OutPkt) { f.onSent() }
+
+func (f *benchFace) onSent() {
+	if f.sent.Add(1) == f.target {
+		close(f.done)
+	}
+}
+
+// startBenchThreads starts forwarding threads that forward everything under /bench to benchOutFace.
+func startBenchThreads(nThreads int, target int) (*benchFace, func()) {
+	core.LoadConfig(core.DefaultConfig(), "")
+	core.SetLogLevel("ERROR")
+	Configure()
+	table.Configure()
+	table.CreateFIBTable("nametree")
+
+	out := &benchFace{id: benchOutFace, target: int64(target), done: make(chan struct{})}
+	dispatch.AddFace(benchInFace, &benchFace{id: benchInFace})
+	dispatch.AddFace(benchOutFace, out)
+	table.FibStrategyTable.InsertNextHopEnc(enc.Name{enc.NewStringComponent(enc.TypeGenericNameComponent, "bench")}, benchOutFace, 0)
+
+	Threads = make([]*Thread, nThreads)
+	fwThreads := make([]dispatch.FWThread, nThreads)
+	for i := range Threads {
+		Threads[i] = NewThread(i)
+		fwThreads[i] = Threads[i]
+		go Threads[i].Run()
+	}
+	dispatch.InitializeFWThreads(fwThreads)
+
+	return out, func() {
+		for _, t := range Threads {
+			t.TellToQuit()
+			<-t.HasQuit
+		}
+		dispatch.RemoveFace(benchInFace)
+		dispatch.RemoveFace(benchOutFace)
+	}
+}
+
+// makeBenchInterest makes an Interest with a unique name, as received from benchInFace.
+func makeBenchInterest(i int) *defn.Pkt {
+	name := enc.Name{enc.NewStringComponent(enc.TypeGenericNameComponent, "bench"), enc.NewSequenceNumComponent(uint64(i))}
+	return &defn.Pkt{
+		Name:           name,
+		NameHash:       name.Hash(),
+		L3:             &spec.Packet{Interest: &spec.Interest{NameV: name, NonceV: utils.IdPtr(uint32(i))}},
+		IncomingFaceID: utils.IdPtr(uint64(benchInFace)),
+	}
+}
+
+func TestPktQueue(t *testing.T) {
+	q := newPktQueue(3)
+	assert.Equal(t, 4, len(q.slots))
+
+	pkts := make([]*defn.Pkt, 5)
+	for i := range pkts {
+		pkts[i] = &defn.Pkt{}
+	}
+	for i := 0; i < 4; i++ {
+		assert.True(t, q.push(pkts[i]))
+	}
+	assert.False(t, q.push(pkts[4]))
+	assert.Equal(t, 4, q.len())
+
+	assert.Same(t, pkts[0], q.pop())
+	assert.True(t, q.push(pkts[4]))
+
+	batch := q.popBatch(make([]*defn.Pkt, 0, 3))
+	assert.Equal(t, pkts[1:4], batch)
+	assert.Same(t, pkts[4], q.pop())
+	assert.Nil(t, q.pop())
+	assert.Equal(t, 0, q.len())
+}
+
+func TestPktQueueConcurrent(t *testing.T) {
+	const producers = 4
+	const perProducer = 10000
+
+	q := newPktQueue(64)
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				for !q.push(&defn.Pkt{}) {
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+
+	received := 0
+	batch := make([]*defn.Pkt, 0, 16)
+	for received < producers*perProducer {
+		batch = q.popBatch(batch[:0])
+		received += len(batch)
+		if len(batch) == 0 {
+			runtime.Gosched()
+		}
+	}
+	wg.Wait()
+	assert.Nil(t, q.pop())
+}
+
+func TestEnqueueDropOldest(t *testing.T) {
+	const producers = 4
+	const perProducer = 10000
+
+	dropOldest = true
+	defer func() { dropOldest = false }()
+
+	// Every packet is either queued or returned as dropped or lost
+	q := newPktQueue(16)
+	var nDropped atomic.Int64
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				dropped, lost := enqueue(q, &defn.Pkt{})
+				if dropped != nil {
+					nDropped.Add(1)
+				}
+				if lost != nil {
+					nDropped.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(producers*perProducer), nDropped.Load()+int64(q.len()))
+	assert.Equal(t, 16, q.len())
+}
+
+func TestThreadForwardsBatches(t *testing.T) {
+	const count = 1000
+	out, stop := startBenchThreads(2, count)
+	defer stop()
+
+	for i := 0; i < count; i++ {
+		pkt := makeBenchInterest(i)
+		Threads[HashToFwThread(pkt.Name, pkt.NameHash)].QueueInterest(pkt)
+	}
+	<-out.done
+	assert.Equal(t, int64(count), out.sent.Load())
+}
+
+func BenchmarkHashNameToFwThread(b *testing.B) {
+	Threads = make([]*Thread, 8)
+	name, _ := enc.NameFromStr("/ndn/edu/ucla/ping/123/seg=4")
+	for i := 0; i < b.N; i++ {
+		HashNameToFwThread(name)
+	}
+}
+
+func BenchmarkHashToFwThread(b *testing.B) {
+	Threads = make([]*Thread, 8)
+	name, _ := enc.NameFromStr("/ndn/edu/ucla/ping/123/seg=4")
+	hash := name.Hash()
+	for i := 0; i < b.N; i++ {
+		HashToFwThread(name, hash)
+	}
+}
+
+func BenchmarkPktQueue(b *testing.B) {
+	q := newPktQueue(1024)
+	pkt := &defn.Pkt{}
+
+	done := make(chan struct{})
+	var received atomic.Int64
+	go func() {
+		batch := make([]*defn.Pkt, 0, 64)
+		for {
+			batch = q.popBatch(batch[:0])
+			received.Add(int64(len(batch)))
+			if len(batch) == 0 {
+				select {
+				case <-done:
+					return
+				default:
+					runtime.Gosched()
+				}
+			}
+		}
+	}()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for !q.push(pkt) {
+				runtime.Gosched()
+			}
+		}
+	})
+	close(done)
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "pkt/s")
+}
+
+// BenchmarkThreadInterests measures the Interest throughput of the forwarding threads,
+// from the dispatch of Interests by the faces to their transmission on the outgoing face.
+func BenchmarkThreadInterests(b *testing.B) {
+	for _, nThreads := range []int{1, 2, 4} {
+		if nThreads > runtime.NumCPU() {
+			break
+		}
+		b.Run(strconv.Itoa(nThreads)+"-threads", func(b *testing.B) {
+			out, stop := startBenchThreads(nThreads, b.N)
+			defer stop()
+
+			var next atomic.Int64
+			b.ResetTimer()
+			var wg sync.WaitGroup
+			for p := 0; p < nThreads; p++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := int(next.Add(1)) - 1; i < b.N; i = int(next.Add(1)) - 1 {
+						pkt := makeBenchInterest(i)
+						thread := Threads[HashToFwThread(pkt.Name, pkt.NameHash)]
+						for !thread.pendingInterests.push(pkt) {
+							runtime.Gosched()
+						}
+						thread.signal()
+					}
+				}()
+			}
+			wg.Wait()
+			<-out.done
+			b.StopTimer()
+
+			rate := float64(b.N) / b.Elapsed().Seconds()
+			b.ReportMetric(rate, "pkt/s")
+			b.ReportMetric(rate/float64(nThreads), "pkt/s/core")
+		})
+	}
+}
+
+func traceEvents(id uint64) []string {
+	for _, trace := range Tracer.Traces() {
+		if trace.ID == id {
+			events := make([]string, 0, len(trace.Events))
+			for _, event := range trace.Events {
+				events = append(events, event.Event)
+			}
+			return events
+		}
+	}
+	return nil
+}
+
+func TestThreadTrace(t *testing.T) {
+	out, stop := startBenchThreads(1, 1)
+	defer stop()
+
+	core.GetConfig().Fw.Trace.SampleRate = 1
+	ConfigureTrace()
+	defer func() {
+		core.GetConfig().Fw.Trace.SampleRate = 0
+		ConfigureTrace()
+	}()
+
+	// Forwarded Interest
+	pkt := makeBenchInterest(0)
+	StartTrace(pkt, "interest")
+	assert.NotZero(t, pkt.TraceID)
+	Threads[0].QueueInterest(pkt)
+	<-out.done
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{TraceReceived, TraceStrategy, TraceForwarded}, traceEvents(pkt.TraceID))
+	}, time.Second, time.Millisecond)
+
+	// Interest without route
+	name := enc.Name{enc.NewStringComponent(enc.TypeGenericNameComponent, "no-route")}
+	noRoute := &defn.Pkt{
+		Name:           name,
+		NameHash:       name.Hash(),
+		L3:             &spec.Packet{Interest: &spec.Interest{NameV: name, NonceV: utils.IdPtr(uint32(1))}},
+		IncomingFaceID: utils.IdPtr(uint64(benchInFace)),
+	}
+	StartTrace(noRoute, "interest")
+	Threads[0].QueueInterest(noRoute)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{TraceReceived, TraceStrategy, TraceNoNexthop}, traceEvents(noRoute.TraceID))
+	}, time.Second, time.Millisecond)
+
+	// Not sampled when disabled
+	core.GetConfig().Fw.Trace.SampleRate = 0
+	ConfigureTrace()
+	nTraces := len(Tracer.Traces())
+	unsampled := makeBenchInterest(1)
+	StartTrace(unsampled, "interest")
+	assert.Zero(t, unsampled.TraceID)
+	assert.Equal(t, nTraces, len(Tracer.Traces()))
+}
+
+func TestThreadEraseCs(t *testing.T) {
+	core.LoadConfig(core.DefaultConfig(), "")
+	core.SetLogLevel("ERROR")
+	Configure()
+	table.Configure()
+
+	thread := NewThread(0)
+	for i := 0; i < 3; i++ {
+		name := enc.Name{enc.NewStringComponent(enc.TypeGenericNameComponent, "cs"), enc.NewSequenceNumComponent(uint64(i))}
+		data := &spec.Data{NameV: name, MetaInfo: &spec.MetaInfo{FreshnessPeriod: utils.IdPtr(time.Minute)}}
+		thread.pitCS.InsertData(data, name.Hash(), []byte{0x06, 0x00})
+	}
+	go thread.Run()
+
+	// The entries are erased by the forwarding thread, which publishes the new size
+	prefix := enc.Name{enc.NewStringComponent(enc.TypeGenericNameComponent, "cs")}
+	assert.Equal(t, 2, thread.EraseCs(prefix, 2))
+	assert.Equal(t, 1, thread.GetNumCsEntries())
+	assert.Equal(t, 1, thread.EraseCs(prefix, 2))
+	assert.Equal(t, 0, thread.GetNumCsEntries())
+
+	// Requests do not block after the thread has quit
+	thread.TellToQuit()
+	<-thread.HasQuit
+	assert.Equal(t, 0, thread.EraseCs(prefix, 2))
+}
@@ -0,0 +1,171 @@
+package fw
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	enc "github.com/named-data/ndnd/std/encoding"
+)
+
+// Pipeline decisions recorded on traced packets.
+const (
+	TraceReceived       = "received"
+	TraceHopLimit       = "hop-limit"
+	TraceScopeViolation = "scope-violation"
+	TraceFiltered       = "filtered"
+	TraceDraining       = "draining"
+	TraceInvalidSig     = "invalid-signature"
+	TraceUnverified     = "unverified"
+	TraceMissingNonce   = "missing-nonce"
+	TraceDeadNonce      = "dead-nonce"
+	TraceLoop           = "loop"
+	TraceAggregated     = "aggregated"
+	TraceCsHit          = "cs-hit"
+	TraceStrategy       = "strategy"
+	TraceSuppressed     = "suppressed"
+	TraceNoNexthop      = "no-nexthop"
+	TraceNoFace         = "no-face"
+	TraceForwarded      = "forwarded"
+	TraceCached         = "cached"
+	TraceUnsolicited    = "unsolicited"
+	TraceSatisfied      = "satisfied"
+)
+
+// Trace is the record of the pipeline decisions on a sampled packet.
+type Trace struct {
+	ID         uint64
+	Name       enc.Name
+	PacketType string
+	InFace     uint64
+	Events     []TraceEvent
+}
+
+// TraceEvent is a pipeline decision on a traced packet.
+type TraceEvent struct {
+	Time   time.Time
+	Event  string
+	Thread int
+	FaceID uint64 // zero if no face is involved
+	Detail string
+}
+
+type tracer struct {
+	sampleRate atomic.Uint64 // float64 bits
+
+	mutex  sync.Mutex
+	nextID uint64
+	traces []*Trace // ring of the most recent traces
+	head   int
+	byID   map[uint64]*Trace
+}
+
+// Tracer keeps the most recent packet traces.
+var Tracer = &tracer{nextID: 1, byID: make(map[uint64]*Trace)}
+
+// ConfigureTrace applies the tracing configuration. Existing traces are kept
+// if they fit in the new capacity.
+func ConfigureTrace() {
+	cfg := core.GetConfig().Fw.Trace
+	rate := min(max(cfg.SampleRate, 0), 1)
+	Tracer.sampleRate.Store(math.Float64bits(rate))
+
+	Tracer.mutex.Lock()
+	defer Tracer.mutex.Unlock()
+	traces := Tracer.recent()
+	capacity := max(cfg.Capacity, 1)
+	if len(traces) > capacity {
+		for _, trace := range traces[:len(traces)-capacity] {
+			delete(Tracer.byID, trace.ID)
+		}
+		traces = traces[len(traces)-capacity:]
+	}
+	Tracer.traces = make([]*Trace, capacity)
+	copy(Tracer.traces, traces)
+	Tracer.head = len(traces) % capacity
+}
+
+// StartTrace tags an incoming packet with a trace ID if it is sampled.
+func StartTrace(pkt *defn.Pkt, packetType string) {
+	rate := math.Float64frombits(Tracer.sampleRate.Load())
+	if rate == 0 || (rate < 1 && rand.Float64() >= rate) {
+		return
+	}
+
+	trace := &Trace{
+		Name:       pkt.Name.Clone(),
+		PacketType: packetType,
+		Events:     make([]TraceEvent, 0, 8),
+	}
+	if pkt.IncomingFaceID != nil {
+		trace.InFace = *pkt.IncomingFaceID
+	}
+
+	t := Tracer
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if len(t.traces) == 0 {
+		return // not configured
+	}
+
+	trace.ID = t.nextID
+	t.nextID++
+	if evicted := t.traces[t.head]; evicted != nil {
+		delete(t.byID, evicted.ID)
+	}
+	t.traces[t.head] = trace
+	t.head = (t.head + 1) % len(t.traces)
+	t.byID[trace.ID] = trace
+	pkt.TraceID = trace.ID
+}
+
+// record appends an event to a trace, unless the trace was evicted.
+func (t *tracer) record(id uint64, event TraceEvent) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if trace := t.byID[id]; trace != nil {
+		trace.Events = append(trace.Events, event)
+	}
+}
+
+// Traces returns a copy of the most recent traces, oldest first.
+func (t *tracer) Traces() []Trace {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	traces := make([]Trace, 0, len(t.byID))
+	for _, trace := range t.recent() {
+		copied := *trace
+		copied.Events = append([]TraceEvent(nil), trace.Events...)
+		traces = append(traces, copied)
+	}
+	return traces
+}
+
+// recent returns the traces in the ring, oldest first. The mutex must be held.
+func (t *tracer) recent() []*Trace {
+	traces := make([]*Trace, 0, len(t.traces))
+	for i := range t.traces {
+		if trace := t.traces[(t.head+i)%len(t.traces)]; trace != nil {
+			traces = append(traces, trace)
+		}
+	}
+	return traces
+}
+
+// trace records a pipeline decision of the thread on a packet, if it is traced.
+func (t *Thread) trace(pkt *defn.Pkt, event string, faceID uint64, detail string) {
+	if pkt.TraceID == 0 {
+		return
+	}
+	Tracer.record(pkt.TraceID, TraceEvent{
+		Time:   core.Now(),
+		Event:  event,
+		Thread: t.threadID,
+		FaceID: faceID,
+		Detail: detail,
+	})
+}
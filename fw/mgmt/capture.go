@@ -0,0 +1,147 @@
+package mgmt
+
+import (
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/face"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+)
+
+// CaptureModule is the module that handles packet captures on faces.
+type CaptureModule struct {
+	manager *Thread
+}
+
+func (c *CaptureModule) String() string {
+	return "CaptureMgmt"
+}
+
+func (c *CaptureModule) registerManager(manager *Thread) {
+	c.manager = manager
+}
+
+func (c *CaptureModule) getManager() *Thread {
+	return c.manager
+}
+
+func (c *CaptureModule) handleIncomingInterest(interest *spec.Interest, pitToken []byte, inFace uint64) {
+	// Only allow from /localhost
+	if !c.manager.localPrefix.IsPrefix(interest.NameV) {
+		core.LogWarn(c, "Received capture management Interest from non-local source - DROP")
+		return
+	}
+
+	// Dispatch by verb
+	verb := interest.NameV[c.manager.prefixLength()+1].String()
+	switch verb {
+	case "start":
+		c.start(interest, pitToken, inFace)
+	case "stop":
+		c.stop(interest, pitToken, inFace)
+	default:
+		core.LogWarn(c, "Received Interest for non-existent verb '", verb, "'")
+		response := makeControlResponse(501, "Unknown verb", nil)
+		c.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+}
+
+// start starts a capture on the face in FaceId (all faces if absent), filtered by the
+// prefix in Name. Capacity limits the size of the file in bytes, Count the number of packets.
+func (c *CaptureModule) start(interest *spec.Interest, pitToken []byte, inFace uint64) {
+	var response *mgmt.ControlResponse
+
+	if len(interest.NameV) < c.manager.prefixLength()+3 {
+		// Name not long enough to contain ControlParameters
+		core.LogWarn(c, "Missing ControlParameters in ", interest.Name())
+		response = makeControlResponse(400, "ControlParameters is incorrect", nil)
+		c.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+
+	params := decodeControlParameters(c, interest)
+	if params == nil {
+		response = makeControlResponse(400, "ControlParameters is incorrect", nil)
+		c.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+
+	faceID := uint64(0)
+	if params.FaceId != nil {
+		faceID = *params.FaceId
+		if face.FaceTable.Get(faceID) == nil {
+			response = makeControlResponse(410, "Face does not exist", nil)
+			c.manager.sendResponse(response, interest, pitToken, inFace)
+			return
+		}
+	}
+
+	maxSize := uint64(0)
+	if params.Capacity != nil {
+		maxSize = *params.Capacity
+	}
+	maxPackets := uint64(0)
+	if params.Count != nil {
+		maxPackets = *params.Count
+	}
+
+	capture, err := face.StartCapture(faceID, params.Name, maxSize, maxPackets)
+	if err == face.ErrCaptureExists {
+		response = makeControlResponse(409, "Capture with different limits exists", nil)
+		c.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	} else if err != nil {
+		core.LogWarn(c, "Unable to start capture: ", err)
+		response = makeControlResponse(500, "Unable to start capture", nil)
+		c.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+
+	responseParams := map[string]any{
+		"FaceId":   faceID,
+		"Uri":      "file://" + capture.Path,
+		"Capacity": capture.MaxSize,
+	}
+	if len(params.Name) > 0 {
+		responseParams["Name"] = params.Name
+	}
+	if capture.MaxPackets > 0 {
+		responseParams["Count"] = capture.MaxPackets
+	}
+	response = makeControlResponse(200, "OK", responseParams)
+	c.manager.sendResponse(response, interest, pitToken, inFace)
+}
+
+// stop stops the capture on the face in FaceId (the capture on all faces if absent).
+func (c *CaptureModule) stop(interest *spec.Interest, pitToken []byte, inFace uint64) {
+	var response *mgmt.ControlResponse
+
+	if len(interest.NameV) < c.manager.prefixLength()+3 {
+		// Name not long enough to contain ControlParameters
+		core.LogWarn(c, "Missing ControlParameters in ", interest.Name())
+		response = makeControlResponse(400, "ControlParameters is incorrect", nil)
+		c.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+
+	params := decodeControlParameters(c, interest)
+	if params == nil {
+		response = makeControlResponse(400, "ControlParameters is incorrect", nil)
+		c.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+
+	faceID := uint64(0)
+	if params.FaceId != nil {
+		faceID = *params.FaceId
+	}
+
+	if !face.StopCapture(faceID) {
+		response = makeControlResponse(404, "No capture on this face", nil)
+		c.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+
+	response = makeControlResponse(200, "OK", map[string]any{"FaceId": faceID})
+	c.manager.sendResponse(response, interest, pitToken, inFace)
+}
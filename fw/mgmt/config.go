@@ -0,0 +1,68 @@
+package mgmt
+
+import (
+	"strings"
+
+	"github.com/named-data/ndnd/fw/core"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+)
+
+// ConfigModule is the module that handles forwarder configuration.
+type ConfigModule struct {
+	manager *Thread
+}
+
+func (c *ConfigModule) String() string {
+	return "ConfigMgmt"
+}
+
+func (c *ConfigModule) registerManager(manager *Thread) {
+	c.manager = manager
+}
+
+func (c *ConfigModule) getManager() *Thread {
+	return c.manager
+}
+
+func (c *ConfigModule) handleIncomingInterest(interest *spec.Interest, pitToken []byte, inFace uint64) {
+	// Only allow from /localhost
+	if !c.manager.localPrefix.IsPrefix(interest.NameV) {
+		core.LogWarn(c, "Received config management Interest from non-local source - DROP")
+		return
+	}
+
+	// Dispatch by verb
+	verb := interest.NameV[c.manager.prefixLength()+1].String()
+	switch verb {
+	case "reload":
+		c.reload(interest, pitToken, inFace)
+	default:
+		core.LogWarn(c, "Received Interest for non-existent verb '", verb, "'")
+		response := makeControlResponse(501, "Unknown verb", nil)
+		c.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+}
+
+func (c *ConfigModule) reload(interest *spec.Interest, pitToken []byte, inFace uint64) {
+	if c.manager.reloadConfig == nil {
+		response := makeControlResponse(501, "Configuration reload is not supported", nil)
+		c.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+
+	restart, err := c.manager.reloadConfig()
+	if err != nil {
+		core.LogWarn(c, "Unable to reload configuration: ", err)
+		response := makeControlResponse(400, "Unable to reload configuration: "+err.Error(), nil)
+		c.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+
+	statusText := "OK"
+	if len(restart) > 0 {
+		statusText = "OK, restart required for " + strings.Join(restart, ", ")
+	}
+	response := makeControlResponse(200, statusText, nil)
+	c.manager.sendResponse(response, interest, pitToken, inFace)
+}
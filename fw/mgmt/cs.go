@@ -17,6 +17,9 @@ import (
 	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
 )
 
+// csEraseLimit is the maximum number of entries erased by a single cs/erase command, as in NFD.
+const csEraseLimit = 256
+
 // ContentStoreModule is the module that handles Content Store Management.
 type ContentStoreModule struct {
 	manager            *Thread
@@ -48,8 +51,7 @@ func (c *ContentStoreModule) handleIncomingInterest(interest *spec.Interest, pit
 	case "config":
 		c.config(interest, pitToken, inFace)
 	case "erase":
-		// TODO
-		//c.erase(interest, pitToken, inFace)
+		c.erase(interest, pitToken, inFace)
 	case "info":
 		c.info(interest, pitToken, inFace)
 	case "query":
@@ -93,20 +95,22 @@ func (c *ContentStoreModule) config(interest *spec.Interest, pitToken []byte, in
 		table.SetCsCapacity(int(*params.Capacity))
 	}
 
-	/*if params.Flags != nil {
-		if *params.Mask&0x01 > 0 {
-			// CS_ENABLE_ADMIT
-			// TODO
+	if params.Flags != nil {
+		if *params.Mask&CsFlagEnableAdmit > 0 {
+			admit := *params.Flags&CsFlagEnableAdmit > 0
+			core.LogInfo(c, "Setting CS admit to ", admit)
+			table.SetCsAdmit(admit)
 		}
 
-		if *params.Mask&0x02 > 0 {
-			// CS_ENABLE_SERVE
-			// TODO
+		if *params.Mask&CsFlagEnableServe > 0 {
+			serve := *params.Flags&CsFlagEnableServe > 0
+			core.LogInfo(c, "Setting CS serve to ", serve)
+			table.SetCsServe(serve)
 		}
-	}*/
+	}
 
 	responseParams := map[string]any{
-		"Flags": uint64(0),
+		"Flags": csFlags(),
 	}
 	if params.Capacity != nil {
 		responseParams["Capacity"] = *params.Capacity
@@ -115,6 +119,66 @@ func (c *ContentStoreModule) config(interest *spec.Interest, pitToken []byte, in
 	c.manager.sendResponse(response, interest, pitToken, inFace)
 }
 
+func (c *ContentStoreModule) erase(interest *spec.Interest, pitToken []byte, inFace uint64) {
+	var response *mgmt.ControlResponse
+
+	if len(interest.NameV) < c.manager.prefixLength()+3 {
+		// Name not long enough to contain ControlParameters
+		core.LogWarn(c, "Missing ControlParameters in ", interest.Name())
+		response = makeControlResponse(400, "ControlParameters is incorrect", nil)
+		c.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+
+	params := decodeControlParameters(c, interest)
+	if params == nil {
+		response = makeControlResponse(400, "ControlParameters is incorrect", nil)
+		c.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+
+	if params.Name == nil {
+		core.LogWarn(c, "Missing Name in ControlParameters for ", interest.Name())
+		response = makeControlResponse(400, "ControlParameters is incorrect", nil)
+		c.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+
+	limit := csEraseLimit
+	if params.Count != nil {
+		if *params.Count == 0 {
+			core.LogWarn(c, "Count must be positive in ControlParameters for ", interest.Name())
+			response = makeControlResponse(400, "ControlParameters is incorrect", nil)
+			c.manager.sendResponse(response, interest, pitToken, inFace)
+			return
+		}
+		if *params.Count < csEraseLimit {
+			limit = int(*params.Count)
+		}
+	}
+
+	// The Data under a prefix may be cached by any forwarding thread
+	erased := 0
+	for _, thread := range fw.Threads {
+		if erased >= limit {
+			break
+		}
+		erased += thread.EraseCs(params.Name, limit-erased)
+	}
+
+	core.LogInfo(c, "Erased ", erased, " entries under Name=", params.Name, " from the Content Store")
+	responseParams := map[string]any{
+		"Name":  params.Name,
+		"Count": uint64(erased),
+	}
+	if erased == limit {
+		// More entries may remain under the prefix
+		responseParams["Capacity"] = uint64(limit)
+	}
+	response = makeControlResponse(200, "OK", responseParams)
+	c.manager.sendResponse(response, interest, pitToken, inFace)
+}
+
 func (c *ContentStoreModule) info(interest *spec.Interest, pitToken []byte, _ uint64) {
 	if len(interest.NameV) > c.manager.prefixLength()+2 {
 		// Ignore because contains version and/or segment components
@@ -125,7 +189,7 @@ func (c *ContentStoreModule) info(interest *spec.Interest, pitToken []byte, _ ui
 	status := mgmt.CsInfoMsg{
 		CsInfo: &mgmt.CsInfo{
 			Capacity:   uint64(table.CsCapacity()),
-			Flags:      CsFlagEnableAdmit | CsFlagEnableServe,
+			Flags:      csFlags(),
 			NCsEntries: 0,
 		},
 	}
@@ -137,10 +201,21 @@ func (c *ContentStoreModule) info(interest *spec.Interest, pitToken []byte, _ ui
 
 	wire := status.Encode()
 	name, _ := enc.NameFromStr(c.manager.localPrefix.String() + "/cs/info")
-	segments := makeStatusDataset(name, c.nextDatasetVersion, wire)
-	c.manager.transport.Send(segments, pitToken, nil)
+	segments := c.manager.sendStatusDataset(name, c.nextDatasetVersion, wire, pitToken)
 
 	core.LogTrace(c, "Published forwarder status dataset version=", c.nextDatasetVersion,
-		", containing ", len(segments), " segments")
+		", containing ", segments, " segments")
 	c.nextDatasetVersion++
 }
+
+// csFlags returns the flags of the Content Store settings.
+func csFlags() uint64 {
+	flags := uint64(0)
+	if table.CsAdmit() {
+		flags |= CsFlagEnableAdmit
+	}
+	if table.CsServe() {
+		flags |= CsFlagEnableServe
+	}
+	return flags
+}
@@ -0,0 +1,87 @@
+package mgmt
+
+import (
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	enc "github.com/named-data/ndnd/std/encoding"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+)
+
+// statusDatasetSegmentSize is the maximum content size of a status dataset segment.
+const statusDatasetSegmentSize = 8000
+
+// statusDatasetLifetime is how long the segments of a published status dataset can be
+// fetched. Clients fetching a dataset for longer must start over with a new version.
+const statusDatasetLifetime = 10 * time.Second
+
+// maxStatusDatasets is the maximum number of segmented status datasets kept at once.
+const maxStatusDatasets = 32
+
+// statusDataset is the snapshot of a published status dataset, kept to serve the
+// segments following the first one.
+type statusDataset struct {
+	segments []enc.Wire
+	expiry   time.Time
+}
+
+// sendStatusDataset publishes a new version of a status dataset in reply to the Interest
+// for it, which receives the first segment. It returns the number of segments.
+func (m *Thread) sendStatusDataset(name enc.Name, version uint64, dataset enc.Wire, pitToken []byte) int {
+	name = append(name[:len(name):len(name)], enc.NewVersionComponent(version))
+	segments := makeStatusDataset(name, dataset)
+	if len(segments) == 0 {
+		return 0
+	}
+
+	// Keep a snapshot of the other segments, so that they are consistent with the first
+	if len(segments) > 1 {
+		m.pruneStatusDatasets()
+		m.datasets[name.String()] = &statusDataset{
+			segments: segments,
+			expiry:   core.Now().Add(statusDatasetLifetime),
+		}
+	}
+
+	m.transport.Send(segments[0], pitToken, nil)
+	return len(segments)
+}
+
+// serveStatusDataset replies to an Interest for a segment of a published status dataset.
+// It returns false if the Interest is not for a status dataset segment.
+func (m *Thread) serveStatusDataset(interest *spec.Interest, pitToken []byte) bool {
+	name := interest.NameV
+	if len(name) < len(m.localPrefix)+4 || // Module + Verb + Version + Segment
+		name[len(name)-1].Typ != enc.TypeSegmentNameComponent ||
+		name[len(name)-2].Typ != enc.TypeVersionNameComponent {
+		return false
+	}
+
+	seg := name[len(name)-1].NumberVal()
+	dataset, ok := m.datasets[name[:len(name)-1].String()]
+	if !ok || core.Now().After(dataset.expiry) || seg >= uint64(len(dataset.segments)) {
+		core.LogDebug(m, "Status dataset segment ", interest.Name(), " is not available - DROP")
+		return true
+	}
+
+	m.transport.Send(dataset.segments[seg], pitToken, nil)
+	core.LogTrace(m, "Sent status dataset segment ", interest.Name())
+	return true
+}
+
+// pruneStatusDatasets removes the expired status datasets, and the oldest one if
+// there is no room for another.
+func (m *Thread) pruneStatusDatasets() {
+	now := core.Now()
+	var oldest string
+	for name, dataset := range m.datasets {
+		if now.After(dataset.expiry) {
+			delete(m.datasets, name)
+		} else if oldest == "" || dataset.expiry.Before(m.datasets[oldest].expiry) {
+			oldest = name
+		}
+	}
+	if len(m.datasets) >= maxStatusDatasets {
+		delete(m.datasets, oldest)
+	}
+}
@@ -0,0 +1,92 @@
+package mgmt
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	enc "github.com/named-data/ndnd/std/encoding"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeStatusDataset(t *testing.T) {
+	name, err := enc.NameFromStr("/localhost/nfd/rib/list/v=1")
+	require.NoError(t, err)
+
+	// Larger than two segments
+	dataset := bytes.Repeat([]byte("0123456789"), 2*statusDatasetSegmentSize/10+100)
+	segments := makeStatusDataset(name, enc.Wire{dataset})
+	require.Len(t, segments, 3)
+
+	content := make([]byte, 0, len(dataset))
+	for seg, wire := range segments {
+		pkt, _, err := spec.ReadPacket(enc.NewWireReader(wire))
+		require.NoError(t, err)
+		data := pkt.Data
+		require.NotNil(t, data)
+
+		assert.Equal(t, name.String()+"/seg="+strconv.Itoa(seg), data.Name().String())
+		require.NotNil(t, data.FinalBlockID())
+		assert.Equal(t, uint64(2), data.FinalBlockID().NumberVal())
+		assert.LessOrEqual(t, len(data.Content().Join()), statusDatasetSegmentSize)
+		content = append(content, data.Content().Join()...)
+	}
+	assert.Equal(t, dataset, content)
+
+	// An empty dataset has a single empty segment
+	segments = makeStatusDataset(name, enc.Wire{})
+	require.Len(t, segments, 1)
+	pkt, _, err := spec.ReadPacket(enc.NewWireReader(segments[0]))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), pkt.Data.FinalBlockID().NumberVal())
+	assert.Empty(t, pkt.Data.Content().Join())
+}
+
+func TestPruneStatusDatasets(t *testing.T) {
+	clock := core.NewManualClock(time.Unix(1000, 0))
+	prevClock := core.SetClock(clock)
+	defer core.SetClock(prevClock)
+
+	localPrefix, err := enc.NameFromStr("/localhost/nfd")
+	require.NoError(t, err)
+	m := &Thread{localPrefix: localPrefix, datasets: make(map[string]*statusDataset)}
+	addDataset := func(name string) {
+		m.pruneStatusDatasets()
+		m.datasets[name] = &statusDataset{
+			segments: []enc.Wire{{}, {}},
+			expiry:   core.Now().Add(statusDatasetLifetime),
+		}
+	}
+
+	// The oldest dataset is evicted when there is no room for another
+	for i := 0; i < maxStatusDatasets; i++ {
+		addDataset("/localhost/nfd/rib/list/v=" + strconv.Itoa(i))
+		clock.Advance(time.Millisecond)
+	}
+	assert.Len(t, m.datasets, maxStatusDatasets)
+	addDataset("/localhost/nfd/rib/list/v=" + strconv.Itoa(maxStatusDatasets))
+	assert.Len(t, m.datasets, maxStatusDatasets)
+	assert.NotContains(t, m.datasets, "/localhost/nfd/rib/list/v=0")
+	assert.Contains(t, m.datasets, "/localhost/nfd/rib/list/v=1")
+
+	// Expired datasets are no longer served, and removed with the next one
+	clock.Advance(statusDatasetLifetime + time.Second)
+	interest := &spec.Interest{NameV: append(localPrefix.Clone(),
+		enc.NewStringComponent(enc.TypeGenericNameComponent, "rib"),
+		enc.NewStringComponent(enc.TypeGenericNameComponent, "list"),
+		enc.NewVersionComponent(1),
+		enc.NewSegmentComponent(1))}
+	assert.True(t, m.serveStatusDataset(interest, nil))
+
+	addDataset("/localhost/nfd/faces/list/v=0")
+	assert.Len(t, m.datasets, 1)
+	assert.Contains(t, m.datasets, "/localhost/nfd/faces/list/v=0")
+
+	// Interests without version and segment are not for a snapshot
+	interest.NameV = interest.NameV[:len(interest.NameV)-2]
+	assert.False(t, m.serveStatusDataset(interest, nil))
+}
@@ -0,0 +1,171 @@
+package mgmt
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/face"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	sec "github.com/named-data/ndnd/std/security"
+	"github.com/named-data/ndnd/std/utils"
+)
+
+// faceEventHistory is the number of recent notifications kept to answer subscribers.
+const faceEventHistory = 16
+
+// faceEventQueueSize is the number of face events waiting to be published by management.
+const faceEventQueueSize = 256
+
+// faceEventPruneInterval is the interval at which expired subscriber Interests are removed.
+const faceEventPruneInterval = time.Second
+
+// faceEventStream publishes face event notifications following the NFD notification
+// stream protocol: each notification is a Data packet named with a sequence number,
+// and subscribers express Interests for the next sequence number.
+//
+// Face events are queued by the face goroutines and published by the management
+// thread, which is the only one to access the stream state.
+type faceEventStream struct {
+	queue    chan *mgmt.FaceEventNotificationValue
+	enabled  atomic.Bool // management is running
+	nDropped atomic.Uint64
+
+	nextSeq uint64
+	recent  []enc.Wire // recent[i] has sequence number nextSeq-len(recent)+i
+	pending []pendingEventInterest
+}
+
+// pendingEventInterest is an Interest waiting for a future notification.
+type pendingEventInterest struct {
+	seq      *uint64 // nil for any notification
+	pitToken []byte
+	inFace   uint64
+	expiry   time.Time
+}
+
+// events handles an Interest for /localhost/nfd/faces/events[/<seq>].
+func (f *FaceModule) events(interest *spec.Interest, pitToken []byte, inFace uint64) {
+	s := &f.eventStream
+
+	p := pendingEventInterest{
+		pitToken: append([]byte{}, pitToken...),
+		inFace:   inFace,
+		expiry:   core.Now().Add(4 * time.Second),
+	}
+	if lifetime := interest.Lifetime(); lifetime != nil {
+		p.expiry = core.Now().Add(*lifetime)
+	}
+
+	if len(interest.NameV) > f.manager.prefixLength()+2 {
+		comp := interest.NameV[f.manager.prefixLength()+2]
+		if comp.Typ != enc.TypeSequenceNumNameComponent {
+			core.LogDebug(f, "Invalid face event sequence number in ", interest.Name(), " - DROP")
+			return
+		}
+		seq := comp.NumberVal()
+
+		// Answer from the recent notifications if possible
+		first := s.nextSeq - uint64(len(s.recent))
+		if seq >= first && seq < s.nextSeq {
+			f.manager.transport.Send(s.recent[seq-first], pitToken, &inFace)
+			return
+		} else if seq < first {
+			core.LogDebug(f, "Face event ", seq, " is no longer available - DROP")
+			return
+		}
+		p.seq = &seq
+	}
+
+	s.pending = append(s.pending, p)
+}
+
+// onFaceEvent queues a face event for publication by the management thread.
+// It is called from face goroutines and from the management thread, so it must not block.
+func (f *FaceModule) onFaceEvent(kind face.FaceEventKind, selectedFace face.LinkService) {
+	s := &f.eventStream
+	if !s.enabled.Load() {
+		// Management is not running yet or is shutting down
+		return
+	}
+
+	event := &mgmt.FaceEventNotificationValue{
+		FaceEventKind:   uint64(kind),
+		FaceId:          selectedFace.FaceID(),
+		Uri:             selectedFace.RemoteURI().String(),
+		LocalUri:        selectedFace.LocalURI().String(),
+		FaceScope:       uint64(selectedFace.Scope()),
+		FacePersistency: uint64(selectedFace.Persistency()),
+		LinkType:        uint64(selectedFace.LinkType()),
+		Flags:           faceFlags(selectedFace),
+	}
+
+	select {
+	case s.queue <- event:
+	default:
+		// Subscribers will not see this event, but the face list remains accurate
+		s.nDropped.Add(1)
+		core.LogWarn(f, "Face event queue full, dropped ", kind, " event of FaceID=", event.FaceId)
+	}
+}
+
+// publishFaceEvent publishes a queued face event notification to the subscribers.
+func (f *FaceModule) publishFaceEvent(event *mgmt.FaceEventNotificationValue) {
+	s := &f.eventStream
+	notification := &mgmt.FaceEventNotification{Val: event}
+
+	seq := s.nextSeq
+	name := append(f.manager.localPrefix.Clone(),
+		enc.NewStringComponent(enc.TypeGenericNameComponent, "faces"),
+		enc.NewStringComponent(enc.TypeGenericNameComponent, "events"),
+		enc.NewSequenceNumComponent(seq))
+	data, err := spec.Spec{}.MakeData(name,
+		&ndn.DataConfig{
+			ContentType: utils.IdPtr(ndn.ContentTypeBlob),
+			Freshness:   utils.IdPtr(time.Second),
+		},
+		notification.Encode(),
+		sec.NewSha256Signer(),
+	)
+	if err != nil {
+		core.LogWarn(f, "Unable to encode face event notification: ", err)
+		return
+	}
+	core.LogTrace(f, "Published face event ", seq, ": FaceID=", event.FaceId, " ", face.FaceEventKind(event.FaceEventKind))
+
+	s.nextSeq++
+	s.recent = append(s.recent, data.Wire)
+	if len(s.recent) > faceEventHistory {
+		s.recent = s.recent[1:]
+	}
+
+	// Satisfy the subscribers waiting for this notification
+	f.prunePendingEvents()
+	pending := s.pending[:0]
+	for _, p := range s.pending {
+		if p.seq != nil && *p.seq != seq {
+			pending = append(pending, p)
+			continue
+		}
+		f.manager.transport.Send(data.Wire, p.pitToken, &p.inFace)
+	}
+	clear(s.pending[len(pending):])
+	s.pending = pending
+}
+
+// prunePendingEvents removes the subscriber Interests that have expired.
+func (f *FaceModule) prunePendingEvents() {
+	s := &f.eventStream
+	now := core.Now()
+	pending := s.pending[:0]
+	for _, p := range s.pending {
+		if p.expiry.After(now) {
+			pending = append(pending, p)
+		}
+	}
+	clear(s.pending[len(pending):])
+	s.pending = pending
+}
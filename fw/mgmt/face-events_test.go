@@ -0,0 +1,111 @@
+package mgmt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/face"
+	enc "github.com/named-data/ndnd/std/encoding"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// makeTestFaceModule returns a face module whose notifications are queued on an internal
+// transport that is not read.
+func makeTestFaceModule(t *testing.T) *FaceModule {
+	core.LoadConfig(core.DefaultConfig(), "")
+	face.Configure()
+
+	localPrefix, err := enc.NameFromStr("/localhost/nfd")
+	require.NoError(t, err)
+	f := &FaceModule{manager: &Thread{localPrefix: localPrefix, transport: face.MakeInternalTransport()}}
+	f.eventStream.queue = make(chan *mgmt.FaceEventNotificationValue, faceEventQueueSize)
+	return f
+}
+
+// subscribeFaceEvents expresses an Interest for the next face event, or for the event with seq.
+func subscribeFaceEvents(t *testing.T, f *FaceModule, seq *uint64, lifetime time.Duration) {
+	name, err := enc.NameFromStr("/localhost/nfd/faces/events")
+	require.NoError(t, err)
+	if seq != nil {
+		name = append(name, enc.NewSequenceNumComponent(*seq))
+	}
+	f.events(&spec.Interest{NameV: name, InterestLifetimeV: utils.IdPtr(lifetime)}, []byte{1}, 1)
+}
+
+func TestFaceEventSubscriptions(t *testing.T) {
+	clock := core.NewManualClock(time.Unix(1000, 0))
+	prevClock := core.SetClock(clock)
+	defer core.SetClock(prevClock)
+	f := makeTestFaceModule(t)
+	s := &f.eventStream
+	event := &mgmt.FaceEventNotificationValue{FaceEventKind: uint64(face.FaceEventCreated), FaceId: 1}
+
+	subscribeFaceEvents(t, f, nil, time.Second)
+	subscribeFaceEvents(t, f, utils.IdPtr(uint64(0)), 10*time.Second)
+	subscribeFaceEvents(t, f, utils.IdPtr(uint64(2)), 10*time.Second)
+	invalid, err := enc.NameFromStr("/localhost/nfd/faces/events/invalid")
+	require.NoError(t, err)
+	f.events(&spec.Interest{NameV: invalid}, nil, 1)
+	require.Len(t, s.pending, 3)
+
+	// Expired subscriptions are pruned
+	clock.Advance(2 * time.Second)
+	f.prunePendingEvents()
+	require.Len(t, s.pending, 2)
+	assert.Equal(t, uint64(0), *s.pending[0].seq)
+	assert.Equal(t, uint64(2), *s.pending[1].seq)
+
+	// A notification satisfies the subscriptions waiting for it
+	f.publishFaceEvent(event)
+	assert.Equal(t, uint64(1), s.nextSeq)
+	assert.Len(t, s.recent, 1)
+	require.Len(t, s.pending, 1)
+	assert.Equal(t, uint64(2), *s.pending[0].seq)
+
+	// Only recent notifications are kept, and older ones are no longer served
+	for i := 0; i < faceEventHistory+1; i++ {
+		f.publishFaceEvent(event)
+	}
+	assert.Equal(t, uint64(faceEventHistory+2), s.nextSeq)
+	assert.Len(t, s.recent, faceEventHistory)
+	assert.Empty(t, s.pending)
+	subscribeFaceEvents(t, f, utils.IdPtr(uint64(0)), 10*time.Second)
+	subscribeFaceEvents(t, f, utils.IdPtr(uint64(faceEventHistory)), 10*time.Second)
+	assert.Empty(t, s.pending)
+
+	// Pending subscriptions expire with their lifetime, or 4s by default
+	subscribeFaceEvents(t, f, utils.IdPtr(s.nextSeq), 10*time.Second)
+	f.events(&spec.Interest{NameV: invalid[:len(invalid)-1]}, nil, 1)
+	require.Len(t, s.pending, 2)
+	clock.Advance(4 * time.Second)
+	f.prunePendingEvents()
+	require.Len(t, s.pending, 1)
+	clock.Advance(6 * time.Second)
+	f.prunePendingEvents()
+	assert.Empty(t, s.pending)
+}
+
+func TestFaceEventQueue(t *testing.T) {
+	f := makeTestFaceModule(t)
+	s := &f.eventStream
+	linkService := face.MakeNDNLPLinkService(face.MakeInternalTransport(), face.MakeNDNLPLinkServiceOptions())
+
+	// Events are ignored until management is running
+	f.onFaceEvent(face.FaceEventCreated, linkService)
+	assert.Empty(t, s.queue)
+
+	s.enabled.Store(true)
+	for i := 0; i < faceEventQueueSize+3; i++ {
+		f.onFaceEvent(face.FaceEventUp, linkService)
+	}
+	assert.Len(t, s.queue, faceEventQueueSize)
+	assert.Equal(t, uint64(3), s.nDropped.Load())
+	event := <-s.queue
+	assert.Equal(t, uint64(face.FaceEventUp), event.FaceEventKind)
+	assert.Equal(t, "internal://", event.Uri)
+}
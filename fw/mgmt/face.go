@@ -26,6 +26,7 @@ import (
 type FaceModule struct {
 	manager                *Thread
 	nextFaceDatasetVersion uint64
+	eventStream            faceEventStream
 }
 
 func (f *FaceModule) String() string {
@@ -34,6 +35,8 @@ func (f *FaceModule) String() string {
 
 func (f *FaceModule) registerManager(manager *Thread) {
 	f.manager = manager
+	f.eventStream.queue = make(chan *mgmt.FaceEventNotificationValue, faceEventQueueSize)
+	face.AddFaceEventHandler(f.onFaceEvent)
 }
 
 func (f *FaceModule) getManager() *Thread {
@@ -60,6 +63,8 @@ func (f *FaceModule) handleIncomingInterest(interest *spec.Interest, pitToken []
 		f.list(interest, pitToken, inFace)
 	case "query":
 		f.query(interest, pitToken, inFace)
+	case "events":
+		f.events(interest, pitToken, inFace)
 	default:
 		core.LogWarn(f, "Received Interest for non-existent verb '", verb, "'")
 		response := makeControlResponse(501, "Unknown verb", nil)
@@ -86,6 +91,13 @@ func (f *FaceModule) create(interest *spec.Interest, pitToken []byte, inFace uin
 		return
 	}
 
+	if core.Draining.Load() {
+		core.LogWarn(f, "Refusing to create face while shutting down")
+		response = makeControlResponse(503, "Forwarder is shutting down", nil)
+		f.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+
 	if params.Uri == nil {
 		core.LogWarn(f, "Missing URI in ControlParameters for ", interest.Name())
 		response = makeControlResponse(400, "ControlParameters is incorrect", nil)
@@ -121,6 +133,7 @@ func (f *FaceModule) create(interest *spec.Interest, pitToken []byte, inFace uin
 	}
 
 	var linkService *face.NDNLPLinkService
+	var ok bool
 
 	if URI.Scheme() == "udp4" || URI.Scheme() == "udp6" {
 		// Validate that remote endpoint is an IP address
@@ -140,78 +153,15 @@ func (f *FaceModule) create(interest *spec.Interest, pitToken []byte, inFace uin
 			return
 		}
 
-		// Check face persistency
-		persistency := face.PersistencyPersistent
-		if params.FacePersistency != nil && (*params.FacePersistency == uint64(face.PersistencyPersistent) ||
-			*params.FacePersistency == uint64(face.PersistencyPermanent)) {
-			persistency = face.Persistency(*params.FacePersistency)
-		} else if params.FacePersistency != nil {
-			core.LogWarn(f, "Unacceptable persistency ", face.Persistency(*params.FacePersistency),
-				" for UDP face specified in ControlParameters for ", interest.Name())
-			response = makeControlResponse(406, "Unacceptable persistency", nil)
-			f.manager.sendResponse(response, interest, pitToken, inFace)
-			return
-		}
-
-		// Check congestion control
-		baseCongestionMarkingInterval := 100 * time.Millisecond
-		if params.BaseCongestionMarkInterval != nil {
-			baseCongestionMarkingInterval = time.Duration(*params.BaseCongestionMarkInterval) * time.Nanosecond
-		}
-
-		defaultCongestionThresholdBytes := uint64(math.Pow(2, 16))
-		if params.DefaultCongestionThreshold != nil {
-			defaultCongestionThresholdBytes = *params.DefaultCongestionThreshold
-		}
-
 		// Create new UDP face
-		transport, err := face.MakeUnicastUDPTransport(URI, nil, persistency)
-		if err != nil {
-			core.LogWarn(f, "Unable to create unicast UDP face with URI ", URI, ":", err.Error())
-			response = makeControlResponse(406, "Transport error", nil)
-			f.manager.sendResponse(response, interest, pitToken, inFace)
-			return
-		}
-
-		if params.Mtu != nil {
-			mtu := int(*params.Mtu)
-			if *params.Mtu > defn.MaxNDNPacketSize {
-				mtu = defn.MaxNDNPacketSize
-			}
-			transport.SetMTU(mtu)
-		}
-
-		// NDNLP link service parameters
-		options := face.MakeNDNLPLinkServiceOptions()
-		if params.Flags != nil {
-			// Mask already guaranteed to be present if Flags is above
-			flags := *params.Flags
-			mask := *params.Mask
-
-			if mask&face.FaceFlagLocalFields > 0 {
-				// LocalFieldsEnabled
-				if flags&face.FaceFlagLocalFields > 0 {
-					options.IsConsumerControlledForwardingEnabled = true
-					options.IsIncomingFaceIndicationEnabled = true
-					options.IsLocalCachePolicyEnabled = true
-				} else {
-					options.IsConsumerControlledForwardingEnabled = false
-					options.IsIncomingFaceIndicationEnabled = false
-					options.IsLocalCachePolicyEnabled = false
+		linkService, ok = f.startFace("unicast UDP", URI, params, interest, pitToken, inFace,
+			func(persistency face.Persistency, options face.NDNLPLinkServiceOptions) (*face.NDNLPLinkService, error) {
+				transport, err := face.MakeUnicastUDPTransport(URI, nil, persistency)
+				if err != nil {
+					return nil, err
 				}
-			}
-
-			// Congestion control
-			if mask&face.FaceFlagCongestionMarking > 0 {
-				// CongestionMarkingEnabled
-				options.IsCongestionMarkingEnabled = flags&face.FaceFlagCongestionMarking > 0
-			}
-			options.BaseCongestionMarkingInterval = baseCongestionMarkingInterval
-			options.DefaultCongestionThresholdBytes = defaultCongestionThresholdBytes
-		}
-
-		linkService = face.MakeNDNLPLinkService(transport, options)
-		linkService.Run(nil)
+				return face.MakeNDNLPLinkService(transport, options), nil
+			})
 	} else if URI.Scheme() == "tcp4" || URI.Scheme() == "tcp6" {
 		// Validate that remote endpoint is an IP address
 		remoteAddr := net.ParseIP(URI.Path())
@@ -230,79 +180,37 @@ func (f *FaceModule) create(interest *spec.Interest, pitToken []byte, inFace uin
 			return
 		}
 
-		// Check face persistency
-		persistency := face.PersistencyPersistent
-		if params.FacePersistency != nil && (*params.FacePersistency == uint64(face.PersistencyPersistent) ||
-			*params.FacePersistency == uint64(face.PersistencyPermanent)) {
-			persistency = face.Persistency(*params.FacePersistency)
-		} else if params.FacePersistency != nil {
-			core.LogWarn(f, "Unacceptable persistency ", face.Persistency(*params.FacePersistency),
-				" for UDP face specified in ControlParameters for ", interest.Name())
-			response = makeControlResponse(406, "Unacceptable persistency", nil)
-			f.manager.sendResponse(response, interest, pitToken, inFace)
-			return
-		}
-
-		// Check congestion control
-		baseCongestionMarkingInterval := 100 * time.Millisecond
-		if params.BaseCongestionMarkInterval != nil {
-			baseCongestionMarkingInterval = time.Duration(*params.BaseCongestionMarkInterval) * time.Nanosecond
-		}
-
-		defaultCongestionThresholdBytes := uint64(math.Pow(2, 16))
-		if params.DefaultCongestionThreshold != nil {
-			defaultCongestionThresholdBytes = *params.DefaultCongestionThreshold
-		}
-
 		// Create new TCP face
-		transport, err := face.MakeUnicastTCPTransport(URI, nil, persistency)
-		if err != nil {
-			core.LogWarn(f, "Unable to create unicast TCP face with URI ", URI, ":", err.Error())
-			response = makeControlResponse(406, "Transport error", nil)
-			f.manager.sendResponse(response, interest, pitToken, inFace)
-			return
-		}
-
-		if params.Mtu != nil {
-			mtu := int(*params.Mtu)
-			if *params.Mtu > defn.MaxNDNPacketSize {
-				mtu = defn.MaxNDNPacketSize
-			}
-			transport.SetMTU(mtu)
-		}
-
-		// NDNLP link service parameters
-		options := face.MakeNDNLPLinkServiceOptions()
-		options.IsFragmentationEnabled = false // reliable stream
-		if params.Flags != nil {
-			// Mask already guaranteed to be present if Flags is above
-			flags := *params.Flags
-			mask := *params.Mask
-
-			if mask&face.FaceFlagLocalFields > 0 {
-				// LocalFieldsEnabled
-				if flags&face.FaceFlagLocalFields > 0 {
-					options.IsConsumerControlledForwardingEnabled = true
-					options.IsIncomingFaceIndicationEnabled = true
-					options.IsLocalCachePolicyEnabled = true
-				} else {
-					options.IsConsumerControlledForwardingEnabled = false
-					options.IsIncomingFaceIndicationEnabled = false
-					options.IsLocalCachePolicyEnabled = false
+		linkService, ok = f.startFace("unicast TCP", URI, params, interest, pitToken, inFace,
+			func(persistency face.Persistency, options face.NDNLPLinkServiceOptions) (*face.NDNLPLinkService, error) {
+				transport, err := face.MakeUnicastTCPTransport(URI, nil, persistency)
+				if err != nil {
+					return nil, err
 				}
-			}
-
-			// Congestion control
-			if mask&face.FaceFlagCongestionMarking > 0 {
-				// CongestionMarkingEnabled
-				options.IsCongestionMarkingEnabled = flags&face.FaceFlagCongestionMarking > 0
-			}
-			options.BaseCongestionMarkingInterval = baseCongestionMarkingInterval
-			options.DefaultCongestionThresholdBytes = defaultCongestionThresholdBytes
-		}
-
-		linkService = face.MakeNDNLPLinkService(transport, options)
-		linkService.Run(nil)
+				options.IsFragmentationEnabled = false // reliable stream
+				return face.MakeNDNLPLinkService(transport, options), nil
+			})
+	} else if URI.Scheme() == "ws" || URI.Scheme() == "wss" {
+		// Create new WebSocket face
+		linkService, ok = f.startFace("WebSocket", URI, params, interest, pitToken, inFace,
+			func(persistency face.Persistency, options face.NDNLPLinkServiceOptions) (*face.NDNLPLinkService, error) {
+				transport, err := face.MakeWebSocketTransport(URI, persistency)
+				if err != nil {
+					return nil, err
+				}
+				options.IsFragmentationEnabled = false // message-oriented
+				return face.MakeNDNLPLinkService(transport, options), nil
+			})
+	} else if URI.Scheme() == "quic" {
+		// Create new QUIC face
+		linkService, ok = f.startFace("QUIC", URI, params, interest, pitToken, inFace,
+			func(persistency face.Persistency, options face.NDNLPLinkServiceOptions) (*face.NDNLPLinkService, error) {
+				transport, err := face.MakeQUICTransport(URI, persistency)
+				if err != nil {
+					return nil, err
+				}
+				return face.MakeNDNLPLinkService(transport, options), nil
+			})
 	} else {
 		// Unsupported scheme
 		core.LogWarn(f, "Cannot create face with URI ", URI, ": Unsupported scheme ", URI)
@@ -311,11 +219,8 @@ func (f *FaceModule) create(interest *spec.Interest, pitToken []byte, inFace uin
 		return
 	}
 
-	if linkService == nil {
-		// Internal failure --> 504
-		core.LogWarn(f, "Transport error when creating face ", URI)
-		response = makeControlResponse(504, "Transport error when creating face", nil)
-		f.manager.sendResponse(response, interest, pitToken, inFace)
+	if !ok {
+		// Response already sent by startFace
 		return
 	}
 
@@ -326,6 +231,93 @@ func (f *FaceModule) create(interest *spec.Interest, pitToken []byte, inFace uin
 	f.manager.sendResponse(response, interest, pitToken, inFace)
 }
 
+// startFace creates and starts a unicast face requested by faces/create.
+// makeLinkService creates the transport with the requested persistency and the
+// link service on top of it, while the persistency, MTU, flags and congestion
+// marking parameters are checked and applied here for all kinds of faces.
+// It returns false if the request was rejected, after sending the response.
+func (f *FaceModule) startFace(
+	kind string,
+	URI *defn.URI,
+	params *mgmt.ControlArgs,
+	interest *spec.Interest,
+	pitToken []byte,
+	inFace uint64,
+	makeLinkService func(face.Persistency, face.NDNLPLinkServiceOptions) (*face.NDNLPLinkService, error),
+) (*face.NDNLPLinkService, bool) {
+	// Check face persistency
+	persistency := face.PersistencyPersistent
+	if params.FacePersistency != nil && (*params.FacePersistency == uint64(face.PersistencyPersistent) ||
+		*params.FacePersistency == uint64(face.PersistencyPermanent)) {
+		persistency = face.Persistency(*params.FacePersistency)
+	} else if params.FacePersistency != nil {
+		core.LogWarn(f, "Unacceptable persistency ", face.Persistency(*params.FacePersistency),
+			" for ", kind, " face specified in ControlParameters for ", interest.Name())
+		response := makeControlResponse(406, "Unacceptable persistency", nil)
+		f.manager.sendResponse(response, interest, pitToken, inFace)
+		return nil, false
+	}
+
+	// Check congestion control
+	baseCongestionMarkingInterval := 100 * time.Millisecond
+	if params.BaseCongestionMarkInterval != nil {
+		baseCongestionMarkingInterval = time.Duration(*params.BaseCongestionMarkInterval) * time.Nanosecond
+	}
+
+	defaultCongestionThresholdBytes := uint64(math.Pow(2, 16))
+	if params.DefaultCongestionThreshold != nil {
+		defaultCongestionThresholdBytes = *params.DefaultCongestionThreshold
+	}
+
+	// NDNLP link service parameters
+	options := face.MakeNDNLPLinkServiceOptions()
+	if params.Flags != nil {
+		// Mask already guaranteed to be present if Flags is above
+		flags := *params.Flags
+		mask := *params.Mask
+
+		if mask&face.FaceFlagLocalFields > 0 {
+			// LocalFieldsEnabled
+			if flags&face.FaceFlagLocalFields > 0 {
+				options.IsConsumerControlledForwardingEnabled = true
+				options.IsIncomingFaceIndicationEnabled = true
+				options.IsLocalCachePolicyEnabled = true
+			} else {
+				options.IsConsumerControlledForwardingEnabled = false
+				options.IsIncomingFaceIndicationEnabled = false
+				options.IsLocalCachePolicyEnabled = false
+			}
+		}
+
+		// Congestion control
+		if mask&face.FaceFlagCongestionMarking > 0 {
+			// CongestionMarkingEnabled
+			options.IsCongestionMarkingEnabled = flags&face.FaceFlagCongestionMarking > 0
+		}
+		options.BaseCongestionMarkingInterval = baseCongestionMarkingInterval
+		options.DefaultCongestionThresholdBytes = defaultCongestionThresholdBytes
+	}
+
+	linkService, err := makeLinkService(persistency, options)
+	if err != nil {
+		core.LogWarn(f, "Unable to create ", kind, " face with URI ", URI, ":", err.Error())
+		response := makeControlResponse(406, "Transport error", nil)
+		f.manager.sendResponse(response, interest, pitToken, inFace)
+		return nil, false
+	}
+
+	if params.Mtu != nil {
+		mtu := int(*params.Mtu)
+		if *params.Mtu > defn.MaxNDNPacketSize {
+			mtu = defn.MaxNDNPacketSize
+		}
+		linkService.SetMTU(mtu)
+	}
+
+	linkService.Run(nil)
+	return linkService, true
+}
+
 func (f *FaceModule) update(interest *spec.Interest, pitToken []byte, inFace uint64) {
 	var response *mgmt.ControlResponse
 
@@ -535,11 +527,10 @@ func (f *FaceModule) list(interest *spec.Interest, pitToken []byte, _ uint64) {
 	}
 
 	name, _ := enc.NameFromStr(f.manager.localPrefix.String() + "/faces/list")
-	segments := makeStatusDataset(name, f.nextFaceDatasetVersion, dataset.Encode())
-	f.manager.transport.Send(segments, pitToken, nil)
+	segments := f.manager.sendStatusDataset(name, f.nextFaceDatasetVersion, dataset.Encode(), pitToken)
 
 	core.LogTrace(f, "Published face dataset version=", f.nextFaceDatasetVersion,
-		", containing ", len(segments), " segments")
+		", containing ", segments, " segments")
 	f.nextFaceDatasetVersion++
 }
 
@@ -599,11 +590,10 @@ func (f *FaceModule) query(interest *spec.Interest, pitToken []byte, _ uint64) {
 		dataset.Vals = append(dataset.Vals, f.createDataset(faces[pos]))
 	}
 
-	segments := makeStatusDataset(interest.Name(), f.nextFaceDatasetVersion, dataset.Encode())
-	f.manager.transport.Send(segments, pitToken, nil)
+	segments := f.manager.sendStatusDataset(interest.Name(), f.nextFaceDatasetVersion, dataset.Encode(), pitToken)
 
 	core.LogTrace(f, "Published face query dataset version=", f.nextFaceDatasetVersion,
-		", containing ", len(segments), " segments")
+		", containing ", segments, " segments")
 	f.nextFaceDatasetVersion++
 }
 
@@ -624,6 +614,8 @@ func (f *FaceModule) createDataset(selectedFace face.LinkService) *mgmt.FaceStat
 		NOutNacks:       0,
 		NInBytes:        selectedFace.NInBytes(),
 		NOutBytes:       selectedFace.NInBytes(),
+		FaceState:       utils.IdPtr(uint64(selectedFace.State())),
+		Flags:           faceFlags(selectedFace),
 	}
 	if selectedFace.ExpirationPeriod() != 0 {
 		faceDataset.ExpirationPeriod = utils.IdPtr(uint64(selectedFace.ExpirationPeriod().Milliseconds()))
@@ -634,19 +626,30 @@ func (f *FaceModule) createDataset(selectedFace face.LinkService) *mgmt.FaceStat
 
 		faceDataset.BaseCongestionMarkInterval = utils.IdPtr(uint64(options.BaseCongestionMarkingInterval.Nanoseconds()))
 		faceDataset.DefaultCongestionThreshold = utils.IdPtr(options.DefaultCongestionThresholdBytes)
-		faceDataset.Flags = options.Flags()
-		if options.IsConsumerControlledForwardingEnabled {
-			// This one will only be enabled if the other two local fields are enabled (and vice versa)
-			faceDataset.Flags |= face.FaceFlagLocalFields
-		}
-		if options.IsCongestionMarkingEnabled {
-			faceDataset.Flags |= face.FaceFlagCongestionMarking
-		}
 	}
 
 	return faceDataset
 }
 
+// faceFlags returns the flags of a face in datasets and notifications.
+func faceFlags(selectedFace face.LinkService) uint64 {
+	linkService, ok := selectedFace.(*face.NDNLPLinkService)
+	if !ok {
+		return 0
+	}
+
+	options := linkService.Options()
+	flags := options.Flags()
+	if options.IsConsumerControlledForwardingEnabled {
+		// This one will only be enabled if the other two local fields are enabled (and vice versa)
+		flags |= face.FaceFlagLocalFields
+	}
+	if options.IsCongestionMarkingEnabled {
+		flags |= face.FaceFlagCongestionMarking
+	}
+	return flags
+}
+
 func (f *FaceModule) fillFaceProperties(params map[string]any, selectedFace face.LinkService) {
 	params["FaceId"] = uint64(selectedFace.FaceID())
 	params["Uri"] = selectedFace.RemoteURI().String()
@@ -176,10 +176,9 @@ func (f *FIBModule) list(interest *spec.Interest, pitToken []byte, _ uint64) {
 	}
 
 	name, _ := enc.NameFromStr(f.manager.localPrefix.String() + "/fib/list")
-	segments := makeStatusDataset(name, f.nextFIBDatasetVersion, dataset.Encode())
-	f.manager.transport.Send(segments, pitToken, nil)
+	segments := f.manager.sendStatusDataset(name, f.nextFIBDatasetVersion, dataset.Encode(), pitToken)
 
 	core.LogTrace(f, "Published FIB dataset version=", f.nextFIBDatasetVersion,
-		", containing ", len(segments), " segments")
+		", containing ", segments, " segments")
 	f.nextFIBDatasetVersion++
 }
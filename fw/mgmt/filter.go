@@ -0,0 +1,184 @@
+package mgmt
+
+import (
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/table"
+	enc "github.com/named-data/ndnd/std/encoding"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/utils"
+)
+
+// FilterModule is the module that handles the rules of the packet filter.
+type FilterModule struct {
+	manager                  *Thread
+	nextFilterDatasetVersion uint64
+}
+
+func (f *FilterModule) String() string {
+	return "FilterMgmt"
+}
+
+func (f *FilterModule) registerManager(manager *Thread) {
+	f.manager = manager
+}
+
+func (f *FilterModule) getManager() *Thread {
+	return f.manager
+}
+
+func (f *FilterModule) handleIncomingInterest(interest *spec.Interest, pitToken []byte, inFace uint64) {
+	// Only allow from /localhost
+	if !f.manager.localPrefix.IsPrefix(interest.NameV) {
+		core.LogWarn(f, "Received filter management Interest from non-local source - DROP")
+		return
+	}
+
+	// Dispatch by verb
+	verb := interest.NameV[f.manager.prefixLength()+1].String()
+	switch verb {
+	case "add":
+		f.add(interest, pitToken, inFace)
+	case "remove":
+		f.remove(interest, pitToken, inFace)
+	case "list":
+		f.list(interest, pitToken, inFace)
+	default:
+		core.LogWarn(f, "Received Interest for non-existent verb '", verb, "'")
+		response := makeControlResponse(501, "Unknown verb", nil)
+		f.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+}
+
+// add appends a rule to the filter. Action is required, and Name defaults to the root prefix.
+func (f *FilterModule) add(interest *spec.Interest, pitToken []byte, inFace uint64) {
+	var response *mgmt.ControlResponse
+
+	if len(interest.NameV) < f.manager.prefixLength()+3 {
+		// Name not long enough to contain ControlParameters
+		core.LogWarn(f, "Missing ControlParameters in ", interest.Name())
+		response = makeControlResponse(400, "ControlParameters is incorrect", nil)
+		f.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+
+	params := decodeControlParameters(f, interest)
+	if params == nil || params.Action == nil {
+		response = makeControlResponse(400, "ControlParameters is incorrect", nil)
+		f.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+
+	prefix := params.Name
+	if prefix == nil {
+		prefix = enc.Name{}
+	}
+	rule, err := table.ParseFilterRule(*params.Action, prefix.String(),
+		optString(params.Direction), optString(params.PacketType), optString(params.Uri), optString(params.Scope))
+	if err != nil {
+		core.LogWarn(f, "Invalid filter rule: ", err)
+		response = makeControlResponse(400, "ControlParameters is incorrect: "+err.Error(), nil)
+		f.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+
+	table.Filter.Add(rule)
+	core.LogInfo(f, "Added filter rule ", rule.ID, ": ", rule.ActionString(), " ", rule.Prefix)
+
+	responseParams := map[string]any{
+		"RuleId": rule.ID,
+		"Action": rule.ActionString(),
+		"Name":   rule.Prefix,
+	}
+	if params.Direction != nil {
+		responseParams["Direction"] = rule.DirectionString()
+	}
+	if params.PacketType != nil {
+		responseParams["PacketType"] = rule.PacketTypeString()
+	}
+	if params.Uri != nil {
+		responseParams["Uri"] = rule.Face
+	}
+	if params.Scope != nil {
+		responseParams["Scope"] = rule.ScopeString()
+	}
+	response = makeControlResponse(200, "OK", responseParams)
+	f.manager.sendResponse(response, interest, pitToken, inFace)
+}
+
+// remove removes the rule in RuleId from the filter.
+func (f *FilterModule) remove(interest *spec.Interest, pitToken []byte, inFace uint64) {
+	var response *mgmt.ControlResponse
+
+	if len(interest.NameV) < f.manager.prefixLength()+3 {
+		// Name not long enough to contain ControlParameters
+		core.LogWarn(f, "Missing ControlParameters in ", interest.Name())
+		response = makeControlResponse(400, "ControlParameters is incorrect", nil)
+		f.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+
+	params := decodeControlParameters(f, interest)
+	if params == nil || params.RuleId == nil {
+		response = makeControlResponse(400, "ControlParameters is incorrect", nil)
+		f.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+
+	if !table.Filter.Remove(*params.RuleId) {
+		response = makeControlResponse(404, "Filter rule does not exist", nil)
+		f.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+
+	core.LogInfo(f, "Removed filter rule ", *params.RuleId)
+	response = makeControlResponse(200, "OK", map[string]any{"RuleId": *params.RuleId})
+	f.manager.sendResponse(response, interest, pitToken, inFace)
+}
+
+// list publishes the filter rules with their hit counters.
+func (f *FilterModule) list(interest *spec.Interest, pitToken []byte, _ uint64) {
+	if len(interest.NameV) > f.manager.prefixLength()+2 {
+		// Ignore because contains version and/or segment components
+		return
+	}
+
+	rules := []*mgmt.FilterRule{}
+	for _, rule := range table.Filter.Rules() {
+		rules = append(rules, &mgmt.FilterRule{
+			RuleId:     rule.ID,
+			Action:     rule.ActionString(),
+			Name:       rule.Prefix,
+			Direction:  optStringPtr(rule.DirectionString()),
+			PacketType: optStringPtr(rule.PacketTypeString()),
+			Uri:        optStringPtr(rule.Face),
+			Scope:      optStringPtr(rule.ScopeString()),
+			NHits:      rule.Hits(),
+		})
+	}
+
+	dataset := &mgmt.FilterRuleMsg{Rules: rules}
+	name, _ := enc.NameFromStr(f.manager.localPrefix.String() + "/filter/list")
+	segments := f.manager.sendStatusDataset(name, f.nextFilterDatasetVersion, dataset.Encode(), pitToken)
+
+	core.LogTrace(f, "Published filter dataset version=", f.nextFilterDatasetVersion,
+		", containing ", segments, " segments")
+	f.nextFilterDatasetVersion++
+}
+
+// optString returns the value of an optional string, or the empty string if absent.
+func optString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// optStringPtr returns a pointer to a string, or nil if it is empty.
+func optStringPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return utils.IdPtr(s)
+}
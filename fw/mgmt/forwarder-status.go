@@ -71,24 +71,28 @@ func (f *ForwarderStatusModule) general(interest *spec.Interest, pitToken []byte
 		NFibEntries:      uint64(len(table.FibStrategyTable.GetAllFIBEntries())),
 	}
 	// Don't set NNameTreeEntries because we don't use a NameTree
+	var nDroppedInterests, nDroppedData uint64
 	for threadID := 0; threadID < fw.NumFwThreads; threadID++ {
 		thread := dispatch.GetFWThread(threadID)
 		status.NPitEntries += uint64(thread.GetNumPitEntries())
 		status.NCsEntries += uint64(thread.GetNumCsEntries())
-		status.NInInterests += thread.(*fw.Thread).NInInterests
-		status.NInData += thread.(*fw.Thread).NInData
-		status.NOutInterests += thread.(*fw.Thread).NOutInterests
-		status.NOutData += thread.(*fw.Thread).NOutData
-		status.NSatisfiedInterests += thread.(*fw.Thread).NSatisfiedInterests
-		status.NUnsatisfiedInterests += thread.(*fw.Thread).NUnsatisfiedInterests
+		status.NInInterests += thread.(*fw.Thread).NInInterests.Load()
+		status.NInData += thread.(*fw.Thread).NInData.Load()
+		status.NOutInterests += thread.(*fw.Thread).NOutInterests.Load()
+		status.NOutData += thread.(*fw.Thread).NOutData.Load()
+		status.NSatisfiedInterests += thread.(*fw.Thread).NSatisfiedInterests.Load()
+		status.NUnsatisfiedInterests += thread.(*fw.Thread).NUnsatisfiedInterests.Load()
+		nDroppedInterests += thread.(*fw.Thread).NDroppedInterests.Load()
+		nDroppedData += thread.(*fw.Thread).NDroppedData.Load()
 	}
+	status.NDroppedInterests = &nDroppedInterests
+	status.NDroppedData = &nDroppedData
 	wire := status.Encode()
 
 	name, _ := enc.NameFromStr(f.manager.localPrefix.String() + "/status/general")
-	segments := makeStatusDataset(name, f.nextGeneralDatasetVersion, wire)
-	f.manager.transport.Send(segments, pitToken, nil)
+	segments := f.manager.sendStatusDataset(name, f.nextGeneralDatasetVersion, wire, pitToken)
 
 	core.LogTrace(f, "Published forwarder status dataset version=", f.nextGeneralDatasetVersion,
-		", containing ", len(segments), " segments")
+		", containing ", segments, " segments")
 	f.nextGeneralDatasetVersion++
 }
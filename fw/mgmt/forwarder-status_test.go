@@ -0,0 +1,53 @@
+package mgmt
+
+import (
+	"testing"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneralStatusDropCounters(t *testing.T) {
+	nDroppedInterests, nDroppedData := uint64(5), uint64(300)
+	status := &mgmt.GeneralStatus{
+		NfdVersion:        "test",
+		NInInterests:      10,
+		NDroppedInterests: &nDroppedInterests,
+		NDroppedData:      &nDroppedData,
+	}
+	wire := status.Encode().Join()
+
+	// The counters use non-critical types of the extension range
+	types := map[enc.TLNum][]byte{}
+	for buf := wire; len(buf) > 0; {
+		typ, n := enc.ParseTLNum(buf)
+		length, m := enc.ParseTLNum(buf[n:])
+		types[typ] = buf[n+m : n+m+int(length)]
+		buf = buf[n+m+int(length):]
+	}
+	assert.Equal(t, []byte{5}, types[0xec])
+	assert.Equal(t, []byte{0x01, 0x2c}, types[0xee])
+	for typ := range types {
+		if typ >= 0xe0 && typ <= 0xef {
+			assert.Zero(t, typ&1, "critical type %x", typ)
+		}
+	}
+
+	// Round trip
+	parsed, err := mgmt.ParseGeneralStatus(enc.NewWireReader(enc.Wire{wire}), false)
+	require.NoError(t, err)
+	assert.Equal(t, "test", parsed.NfdVersion)
+	assert.Equal(t, uint64(10), parsed.NInInterests)
+	require.NotNil(t, parsed.NDroppedInterests)
+	assert.Equal(t, nDroppedInterests, *parsed.NDroppedInterests)
+	require.NotNil(t, parsed.NDroppedData)
+	assert.Equal(t, nDroppedData, *parsed.NDroppedData)
+
+	// Without the counters, as sent by NFD
+	parsed, err = mgmt.ParseGeneralStatus(enc.NewWireReader((&mgmt.GeneralStatus{}).Encode()), false)
+	require.NoError(t, err)
+	assert.Nil(t, parsed.NDroppedInterests)
+	assert.Nil(t, parsed.NDroppedData)
+}
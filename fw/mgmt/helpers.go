@@ -52,29 +52,31 @@ func makeControlResponse(statusCode uint64, statusText string, args map[string]a
 	}
 }
 
-// makeStatusDataset creates a set of status dataset packets based upon the specified prefix, version,
-// and dataset information.
-// Note: The old mgmt.MakeStatusDataset is clearly wrong as it is against the single-Interest-single-Data
-// principle. Thus, we simply assume that the data packet should always fit in one segment.
-func makeStatusDataset(name enc.Name, version uint64, dataset enc.Wire) enc.Wire {
-	// Split into 8000 byte segments and publish
-	if len(dataset) > 8000 {
-		core.LogError("mgmt", "Status dataset is too large")
-		return nil
-	}
-	name = append(name, enc.NewVersionComponent(version), enc.NewSegmentComponent(0))
-	data, err := spec.Spec{}.MakeData(name,
-		&ndn.DataConfig{
-			ContentType:  utils.IdPtr(ndn.ContentTypeBlob),
-			Freshness:    utils.IdPtr(time.Second),
-			FinalBlockID: utils.IdPtr(enc.NewSegmentComponent(0)),
-		},
-		dataset,
-		sec.NewSha256Signer(),
-	)
-	if err != nil {
-		core.LogError("mgmt", "Unable to encode status dataset")
-		return nil
+// makeStatusDataset creates the segments of a version of a status dataset, named under the
+// specified versioned prefix. The segments carry the FinalBlockId of the last segment.
+func makeStatusDataset(name enc.Name, dataset enc.Wire) []enc.Wire {
+	content := dataset.Join()
+	count := max((len(content)+statusDatasetSegmentSize-1)/statusDatasetSegmentSize, 1)
+	finalBlockID := enc.NewSegmentComponent(uint64(count - 1))
+
+	segments := make([]enc.Wire, 0, count)
+	for seg := 0; seg < count; seg++ {
+		chunk := content[seg*statusDatasetSegmentSize : min((seg+1)*statusDatasetSegmentSize, len(content))]
+		segName := append(name[:len(name):len(name)], enc.NewSegmentComponent(uint64(seg)))
+		data, err := spec.Spec{}.MakeData(segName,
+			&ndn.DataConfig{
+				ContentType:  utils.IdPtr(ndn.ContentTypeBlob),
+				Freshness:    utils.IdPtr(time.Second),
+				FinalBlockID: utils.IdPtr(finalBlockID),
+			},
+			enc.Wire{chunk},
+			sec.NewSha256Signer(),
+		)
+		if err != nil {
+			core.LogError("mgmt", "Unable to encode status dataset")
+			return nil
+		}
+		segments = append(segments, data.Wire)
 	}
-	return data.Wire
+	return segments
 }
@@ -18,4 +18,5 @@ var enableLocalhopManagement bool
 // Configure configures the face system.
 func Configure() {
 	enableLocalhopManagement = core.GetConfig().Mgmt.AllowLocalhop
+	configureLocalUsers()
 }
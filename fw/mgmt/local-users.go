@@ -0,0 +1,158 @@
+package mgmt
+
+import (
+	"os/user"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/face"
+	enc "github.com/named-data/ndnd/std/encoding"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+)
+
+// localUserRule contains the restrictions of a local user connected over the Unix socket.
+type localUserRule struct {
+	management bool
+	prefixes   []enc.Name
+	readOnly   bool // only status datasets can be read
+}
+
+// localUserTable contains the restrictions of all local users.
+type localUserTable struct {
+	// rules contains the restrictions of local users, indexed by UID.
+	rules map[uint32]*localUserRule
+	// defaultRule contains the restrictions of local users without their own rule,
+	// and of faces without peer credentials.
+	defaultRule *localUserRule
+}
+
+// localUsers is replaced as a whole on configuration reload, so that the management
+// thread takes no lock to read it. It is nil if local users are not restricted.
+var localUsers atomic.Pointer[localUserTable]
+
+// localUserNoPrivileges is applied to faces without peer credentials when there is no
+// rule for all users, so that they may only read status datasets.
+var localUserNoPrivileges = &localUserRule{readOnly: true}
+
+// datasetVerbs are the verbs of status datasets, which can be read by all local users.
+var datasetVerbs = map[string]bool{
+	"list":     true,
+	"query":    true,
+	"channels": true,
+	"general":  true,
+	"info":     true,
+	"events":   true,
+}
+
+// configureLocalUsers reads the restrictions of local users from the configuration.
+func configureLocalUsers() {
+	if len(core.GetConfig().Mgmt.LocalUsers) == 0 {
+		localUsers.Store(nil)
+		return
+	}
+
+	users := &localUserTable{rules: make(map[uint32]*localUserRule)}
+	for _, cfg := range core.GetConfig().Mgmt.LocalUsers {
+		rule := &localUserRule{management: cfg.Management}
+		for _, prefixStr := range cfg.Prefixes {
+			prefix, err := enc.NameFromStr(prefixStr)
+			if err != nil {
+				core.LogError("mgmt", "Invalid prefix ", prefixStr, " of local user ", cfg.User, ": ", err)
+				continue
+			}
+			rule.prefixes = append(rule.prefixes, prefix)
+		}
+
+		if cfg.User == "*" {
+			users.defaultRule = rule
+			continue
+		}
+
+		uid, err := lookupUID(cfg.User)
+		if err != nil {
+			core.LogError("mgmt", "Unknown local user ", cfg.User, ": ", err)
+			continue
+		}
+		users.rules[uid] = rule
+	}
+	localUsers.Store(users)
+}
+
+// lookupUID returns the UID of a user name or numeric UID.
+func lookupUID(name string) (uint32, error) {
+	if uid, err := strconv.ParseUint(name, 10, 32); err == nil {
+		return uint32(uid), nil
+	}
+
+	u, err := user.Lookup(name)
+	if err != nil {
+		return 0, err
+	}
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(uid), nil
+}
+
+// localUserRuleOf returns the restrictions of the local user connected to a face,
+// or nil if the face is not restricted. Faces without peer credentials, e.g., TCP or
+// WebSocket faces from the loopback address, cannot be told apart from any local user,
+// so they get the rule for all users, or may only read status datasets without it.
+func localUserRuleOf(faceID uint64) *localUserRule {
+	users := localUsers.Load()
+	if users == nil {
+		return nil
+	}
+
+	cred := face.GetPeerCredentials(faceID)
+	if cred == nil {
+		if users.defaultRule == nil {
+			return localUserNoPrivileges
+		}
+		return users.defaultRule
+	}
+	if rule, ok := users.rules[cred.UID]; ok {
+		return rule
+	}
+	return users.defaultRule
+}
+
+// authorize returns whether the local user connected to the incoming face may send
+// a management command. Status datasets can always be read, prefixes can be registered
+// under the allowed prefixes, and other commands require management privileges.
+func (m *Thread) authorize(module string, interest *spec.Interest, inFace uint64) bool {
+	rule := localUserRuleOf(inFace)
+	if rule == nil {
+		return true
+	}
+
+	verb := interest.NameV[m.prefixLength()+1].String()
+	if datasetVerbs[verb] || rule.management {
+		return true
+	}
+	if rule.readOnly {
+		return false
+	}
+
+	if module == "rib" && (verb == "register" || verb == "unregister") {
+		if len(rule.prefixes) == 0 {
+			return true
+		}
+		if len(interest.NameV) < m.prefixLength()+3 {
+			return true // rejected by the module
+		}
+		params := decodeControlParameters(m.modules[module], interest)
+		if params == nil || params.Name == nil {
+			return true // rejected by the module
+		}
+		for _, prefix := range rule.prefixes {
+			if prefix.IsPrefix(params.Name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,142 @@
+package mgmt
+
+import (
+	"net"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/face"
+	enc "github.com/named-data/ndnd/std/encoding"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setLocalUsers configures the restrictions of local users for the duration of a test.
+func setLocalUsers(t *testing.T, users ...core.LocalUserConfig) {
+	config := core.DefaultConfig()
+	config.Mgmt.LocalUsers = users
+	core.LoadConfig(config, "")
+	configureLocalUsers()
+	t.Cleanup(func() {
+		core.LoadConfig(core.DefaultConfig(), "")
+		configureLocalUsers()
+	})
+}
+
+// makeUnixFace creates a Unix stream face connected to the test process, which has
+// peer credentials where they are supported.
+func makeUnixFace(t *testing.T) face.LinkService {
+	path := filepath.Join(t.TempDir(), "nfd.sock")
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	client, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+
+	transport, err := face.MakeUnixStreamTransport(defn.MakeFDFaceURI(3), defn.MakeUnixFaceURI(path), conn)
+	require.NoError(t, err)
+	if transport.PeerCredentials() == nil {
+		conn.Close()
+		t.Skip("peer credentials are not supported")
+	}
+	linkService := face.MakeNDNLPLinkService(transport, face.MakeNDNLPLinkServiceOptions())
+	linkService.Run(nil)
+	t.Cleanup(linkService.Close)
+	return linkService
+}
+
+// makeInternalFace creates a face without peer credentials.
+func makeInternalFace(t *testing.T) face.LinkService {
+	linkService := face.MakeNDNLPLinkService(face.MakeInternalTransport(), face.MakeNDNLPLinkServiceOptions())
+	linkService.Run(nil)
+	t.Cleanup(linkService.Close)
+	return linkService
+}
+
+// makeCommand returns a management command Interest, with ControlParameters for a name.
+func makeCommand(t *testing.T, module string, verb string, name string) *spec.Interest {
+	interestName, err := enc.NameFromStr("/localhost/nfd/" + module + "/" + verb)
+	require.NoError(t, err)
+	if name != "" {
+		params := &mgmt.ControlParameters{Val: &mgmt.ControlArgs{}}
+		params.Val.Name, err = enc.NameFromStr(name)
+		require.NoError(t, err)
+		interestName = append(interestName, enc.NewBytesComponent(enc.TypeGenericNameComponent, params.Bytes()))
+	}
+	return &spec.Interest{NameV: interestName}
+}
+
+func TestAuthorize(t *testing.T) {
+	m := MakeMgmtThread()
+	unixFace := makeUnixFace(t)
+	internalFace := makeInternalFace(t)
+	uid := face.GetPeerCredentials(unixFace.FaceID()).UID
+	assert.Nil(t, face.GetPeerCredentials(internalFace.FaceID()))
+
+	authorize := func(faceID uint64, module string, verb string, name string) bool {
+		return m.authorize(module, makeCommand(t, module, verb, name), faceID)
+	}
+
+	// Everything is allowed without local users
+	setLocalUsers(t)
+	assert.Nil(t, localUsers.Load())
+	assert.True(t, authorize(unixFace.FaceID(), "faces", "create", "/"))
+	assert.True(t, authorize(internalFace.FaceID(), "faces", "create", "/"))
+
+	// Users may register prefixes under theirs, while all others have management privileges
+	setLocalUsers(t,
+		core.LocalUserConfig{User: strconv.FormatUint(uint64(uid), 10), Prefixes: []string{"/app"}},
+		core.LocalUserConfig{User: "*", Management: true})
+	assert.True(t, authorize(unixFace.FaceID(), "faces", "list", ""))
+	assert.True(t, authorize(unixFace.FaceID(), "rib", "register", "/app"))
+	assert.True(t, authorize(unixFace.FaceID(), "rib", "unregister", "/app/sub"))
+	assert.False(t, authorize(unixFace.FaceID(), "rib", "register", "/other"))
+	assert.False(t, authorize(unixFace.FaceID(), "faces", "create", "/app"))
+	assert.False(t, authorize(unixFace.FaceID(), "strategy-choice", "set", "/app"))
+	assert.True(t, authorize(internalFace.FaceID(), "faces", "create", "/"))
+
+	// Without prefixes, any prefix may be registered
+	setLocalUsers(t, core.LocalUserConfig{User: strconv.FormatUint(uint64(uid), 10)})
+	assert.True(t, authorize(unixFace.FaceID(), "rib", "register", "/other"))
+	assert.False(t, authorize(unixFace.FaceID(), "faces", "destroy", "/"))
+
+	// Faces without peer credentials may only read status datasets without a rule for all users
+	assert.True(t, authorize(internalFace.FaceID(), "faces", "list", ""))
+	assert.True(t, authorize(internalFace.FaceID(), "status", "general", ""))
+	assert.False(t, authorize(internalFace.FaceID(), "rib", "register", "/app"))
+	assert.False(t, authorize(internalFace.FaceID(), "faces", "create", "/"))
+
+	// Users without a rule are not restricted when there is no rule for all users
+	setLocalUsers(t, core.LocalUserConfig{User: strconv.FormatUint(uint64(uid)+1, 10)})
+	assert.True(t, authorize(unixFace.FaceID(), "faces", "create", "/"))
+	assert.False(t, authorize(internalFace.FaceID(), "faces", "create", "/"))
+	assert.Same(t, localUserNoPrivileges, localUserRuleOf(internalFace.FaceID()))
+
+	// Unknown users and invalid prefixes are ignored
+	setLocalUsers(t,
+		core.LocalUserConfig{User: "no-such-user-ndnd", Management: true},
+		core.LocalUserConfig{User: "*", Prefixes: []string{"/app", "/%%"}})
+	require.NotNil(t, localUsers.Load())
+	assert.Empty(t, localUsers.Load().rules)
+	assert.Len(t, localUsers.Load().defaultRule.prefixes, 1)
+	assert.True(t, authorize(unixFace.FaceID(), "rib", "register", "/app"))
+	assert.False(t, authorize(unixFace.FaceID(), "faces", "create", "/"))
+}
+
+func TestLookupUID(t *testing.T) {
+	uid, err := lookupUID("1234")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1234), uid)
+
+	_, err = lookupUID("no-such-user-ndnd")
+	assert.Error(t, err)
+}
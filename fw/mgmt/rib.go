@@ -253,9 +253,8 @@ func (r *RIBModule) list(interest *spec.Interest, pitToken []byte, _ uint64) {
 	}
 
 	name, _ := enc.NameFromStr(interest.NameV[:r.manager.prefixLength()].String() + "/rib/list")
-	segments := makeStatusDataset(name, r.nextRIBDatasetVersion, dataset.Encode())
-	r.manager.transport.Send(segments, pitToken, nil)
+	segments := r.manager.sendStatusDataset(name, r.nextRIBDatasetVersion, dataset.Encode(), pitToken)
 	core.LogTrace(r, "Published RIB dataset version=", r.nextRIBDatasetVersion,
-		", containing ", len(segments), " segments")
+		", containing ", segments, " segments")
 	r.nextRIBDatasetVersion++
 }
@@ -221,10 +221,9 @@ func (s *StrategyChoiceModule) list(interest *spec.Interest, pitToken []byte, _
 	strategyChoiceMsg := &mgmt.StrategyChoiceMsg{StrategyChoices: strategyChoiceList}
 	wire := strategyChoiceMsg.Encode()
 	name, _ := enc.NameFromStr(s.manager.localPrefix.String() + "/strategy-choice/list")
-	segments := makeStatusDataset(name, s.nextStrategyDatasetVersion, wire)
-	s.manager.transport.Send(segments, pitToken, nil)
+	segments := s.manager.sendStatusDataset(name, s.nextStrategyDatasetVersion, wire, pitToken)
 
 	core.LogTrace(s, "Published strategy choice dataset version=", s.nextStrategyDatasetVersion,
-		", containing ", len(segments), " segments")
+		", containing ", segments, " segments")
 	s.nextStrategyDatasetVersion++
 }
@@ -9,6 +9,7 @@ package mgmt
 
 import (
 	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/named-data/ndnd/fw/core"
@@ -30,13 +31,23 @@ type Thread struct {
 	localPrefix    enc.Name
 	nonLocalPrefix enc.Name
 	modules        map[string]Module
+	datasets       map[string]*statusDataset
 	timer          ndn.Timer
+	reloadConfig   func() ([]string, error)
+
+	// Tasks posted by other goroutines, run by the management thread
+	tasksMutex sync.Mutex
+	tasks      []func()
+	tasksReady chan struct{}
+	hasQuit    chan struct{}
 }
 
 // MakeMgmtThread creates a new management thread.
 func MakeMgmtThread() *Thread {
 	m := new(Thread)
 	m.timer = basic_engine.NewTimer()
+	m.tasksReady = make(chan struct{}, 1)
+	m.hasQuit = make(chan struct{})
 
 	var err error
 	m.localPrefix, err = enc.NameFromStr("/localhost/nfd")
@@ -49,12 +60,17 @@ func MakeMgmtThread() *Thread {
 	}
 
 	m.modules = make(map[string]Module)
+	m.datasets = make(map[string]*statusDataset)
+	m.registerModule("capture", new(CaptureModule))
+	m.registerModule("config", new(ConfigModule))
 	m.registerModule("cs", new(ContentStoreModule))
 	m.registerModule("faces", new(FaceModule))
 	m.registerModule("fib", new(FIBModule))
+	m.registerModule("filter", new(FilterModule))
 	m.registerModule("rib", new(RIBModule))
 	m.registerModule("status", new(ForwarderStatusModule))
 	m.registerModule("strategy-choice", new(StrategyChoiceModule))
+	m.registerModule("trace", new(TraceModule))
 
 	// readvertisers run in the management thread for ease of
 	// implementation, since they use the internal transport
@@ -62,6 +78,14 @@ func MakeMgmtThread() *Thread {
 		table.AddReadvertiser(NewNlsrReadvertiser(m))
 	}
 
+	// The RIB is only modified by the management thread
+	face.AddFaceEventHandler(func(kind face.FaceEventKind, destroyed face.LinkService) {
+		if kind == face.FaceEventDestroyed {
+			faceID := destroyed.FaceID()
+			m.Post(func() { table.Rib.CleanUpFace(faceID) })
+		}
+	})
+
 	return m
 }
 
@@ -74,6 +98,79 @@ func (m *Thread) registerModule(name string, module Module) {
 	module.registerManager(m)
 }
 
+// SetReloadHandler sets the function called by the config/reload command.
+// The handler returns the changed settings that require a restart.
+func (m *Thread) SetReloadHandler(handler func() ([]string, error)) {
+	m.reloadConfig = handler
+}
+
+// Post queues a task to be run by the management thread, which owns the RIB and the
+// management state. It does not block, so it may be called from any goroutine.
+// Tasks posted before the thread starts are run when it starts, and those posted
+// after it has quit are never run.
+func (m *Thread) Post(task func()) {
+	m.tasksMutex.Lock()
+	m.tasks = append(m.tasks, task)
+	m.tasksMutex.Unlock()
+
+	select {
+	case m.tasksReady <- struct{}{}:
+	default: // already signaled
+	}
+}
+
+// Exec runs a task on the management thread and waits for it to complete.
+// It returns false if the management thread has quit without running the task.
+// It must not be called from the management thread itself.
+func (m *Thread) Exec(task func()) bool {
+	done := make(chan struct{})
+	m.Post(func() {
+		task()
+		close(done)
+	})
+
+	select {
+	case <-done:
+		return true
+	case <-m.hasQuit:
+		select {
+		case <-done:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// runTasks runs the tasks posted since the last call.
+func (m *Thread) runTasks() {
+	m.tasksMutex.Lock()
+	tasks := m.tasks
+	m.tasks = nil
+	m.tasksMutex.Unlock()
+
+	for _, task := range tasks {
+		task()
+	}
+}
+
+// Reconfigure applies management settings that can be changed while running.
+func (m *Thread) Reconfigure() {
+	Configure()
+	if m.face != nil {
+		m.registerLocalhop()
+	}
+}
+
+// registerLocalhop adds or removes the /localhop/nfd route to management.
+func (m *Thread) registerLocalhop() {
+	if enableLocalhopManagement {
+		table.FibStrategyTable.InsertNextHopEnc(m.nonLocalPrefix, m.face.FaceID(), 0)
+	} else {
+		table.FibStrategyTable.RemoveNextHopEnc(m.nonLocalPrefix, m.face.FaceID())
+	}
+}
+
 func (m *Thread) prefixLength() int {
 	return len(m.localPrefix)
 }
@@ -116,6 +213,7 @@ func (m *Thread) sendResponse(response *mgmt.ControlResponse, interest *spec.Int
 // Run management thread
 func (m *Thread) Run() {
 	core.LogInfo(m, "Starting management")
+	defer close(m.hasQuit)
 
 	// Create and register Internal transport
 	m.face, m.transport = face.RegisterInternalTransport()
@@ -125,56 +223,101 @@ func (m *Thread) Run() {
 	}
 	table.FibStrategyTable.InsertNextHopEnc(faces, m.face.FaceID(), 0)
 	if enableLocalhopManagement {
-		add1, _ := enc.NameFromStr("/localhop/nfd")
-		table.FibStrategyTable.InsertNextHopEnc(add1, m.face.FaceID(), 0)
+		m.registerLocalhop()
 	}
-	for {
-		fragment, pitToken, inFace := m.transport.Receive()
-		if fragment == nil {
-			// Indicates that internal face has quit, which means it's time for us to quit
-			core.LogInfo(m, "Face quit, so management quitting")
-			break
-		}
-		core.LogTrace(m, "Received block on face, IncomingFaceID=", inFace)
 
-		pkt, _, err := spec.ReadPacket(enc.NewWireReader(fragment))
-		if err != nil {
-			// Indicates that internal face has quit, which means it's time for us to quit
-			core.LogInfo(m, "Unable to decode internal packet, drop")
-			continue
+	// Receive packets in a separate goroutine, so that posted tasks,
+	// face events and timers are also handled by this thread
+	type received struct {
+		fragment enc.Wire
+		pitToken []byte
+		inFace   uint64
+	}
+	incoming := make(chan received)
+	go func() {
+		defer close(incoming)
+		for {
+			fragment, pitToken, inFace := m.transport.Receive()
+			if fragment == nil {
+				return
+			}
+			incoming <- received{fragment, pitToken, inFace}
 		}
+	}()
 
-		// We only expect Interests, so drop Data packets
-		if pkt.Interest == nil {
-			core.LogDebug(m, "Dropping received non-Interest packet")
-			continue
+	faceModule := m.modules["faces"].(*FaceModule)
+	faceModule.eventStream.enabled.Store(true)
+	defer faceModule.eventStream.enabled.Store(false)
+	pruneTicker := core.NewTicker(faceEventPruneInterval)
+	defer pruneTicker.Stop()
+
+	for {
+		select {
+		case pkt, ok := <-incoming:
+			if !ok {
+				// Indicates that internal face has quit, which means it's time for us to quit
+				core.LogInfo(m, "Face quit, so management quitting")
+				return
+			}
+			m.handlePacket(pkt.fragment, pkt.pitToken, pkt.inFace)
+		case <-m.tasksReady:
+			m.runTasks()
+		case event := <-faceModule.eventStream.queue:
+			faceModule.publishFaceEvent(event)
+		case <-pruneTicker.C():
+			faceModule.prunePendingEvents()
 		}
-		interest := pkt.Interest
+	}
+}
+
+// handlePacket handles a packet received by management.
+func (m *Thread) handlePacket(fragment enc.Wire, pitToken []byte, inFace uint64) {
+	core.LogTrace(m, "Received block on face, IncomingFaceID=", inFace)
 
-		// Ensure Interest name matches expectations
-		if len(interest.NameV) < len(m.localPrefix)+2 { // Module + Verb
-			core.LogInfo(m, "Control command name ", interest.Name().String(), " has unexpected number of components - DROP")
-			continue
+	pkt, _, err := spec.ReadPacket(enc.NewWireReader(fragment))
+	if err != nil {
+		core.LogInfo(m, "Unable to decode internal packet, drop")
+		return
+	}
+
+	// We only expect Interests, so drop Data packets
+	if pkt.Interest == nil {
+		core.LogDebug(m, "Dropping received non-Interest packet")
+		return
+	}
+	interest := pkt.Interest
+
+	// Ensure Interest name matches expectations
+	if len(interest.NameV) < len(m.localPrefix)+2 { // Module + Verb
+		core.LogInfo(m, "Control command name ", interest.Name().String(), " has unexpected number of components - DROP")
+		return
+	}
+	if !m.localPrefix.IsPrefix(interest.NameV) && !m.nonLocalPrefix.IsPrefix(interest.Name()) {
+		core.LogInfo(m, "Control command name ", interest.Name(), " has unexpected prefix - DROP")
+		return
+	}
+
+	core.LogTrace(m, "Received management Interest ", interest.Name())
+
+	// Dispatch interest based on name
+	moduleName := interest.NameV[len(m.localPrefix)].String()
+	if module, ok := m.modules[moduleName]; ok {
+		if !m.authorize(moduleName, interest, inFace) {
+			core.LogInfo(m, "Local user of FaceID=", inFace, " is not authorized for ", interest.Name())
+			m.sendResponse(makeControlResponse(403, "Not authorized", nil), interest, pitToken, inFace)
+			return
 		}
-		if !m.localPrefix.IsPrefix(interest.NameV) && !m.nonLocalPrefix.IsPrefix(interest.Name()) {
-			core.LogInfo(m, "Control command name ", interest.Name(), " has unexpected prefix - DROP")
-			continue
+		if m.serveStatusDataset(interest, pitToken) {
+			return
 		}
-
-		core.LogTrace(m, "Received management Interest ", interest.Name())
-
-		// Dispatch interest based on name
-		moduleName := interest.NameV[len(m.localPrefix)].String()
-		if module, ok := m.modules[moduleName]; ok {
-			module.handleIncomingInterest(interest, pitToken, inFace)
-		} else {
-			core.LogWarn(m, "Received management Interest for unknown module ", moduleName)
-			response := makeControlResponse(501, "Unknown module", nil)
-			if response == nil {
-				core.LogError(m, "Unable to encode control response")
-				continue
-			}
-			m.sendResponse(response, interest, pitToken, inFace)
+		module.handleIncomingInterest(interest, pitToken, inFace)
+	} else {
+		core.LogWarn(m, "Received management Interest for unknown module ", moduleName)
+		response := makeControlResponse(501, "Unknown module", nil)
+		if response == nil {
+			core.LogError(m, "Unable to encode control response")
+			return
 		}
+		m.sendResponse(response, interest, pitToken, inFace)
 	}
 }
@@ -0,0 +1,89 @@
+package mgmt
+
+import (
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/fw"
+	enc "github.com/named-data/ndnd/std/encoding"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/utils"
+)
+
+// TraceModule is the module that publishes the sampled packet traces.
+type TraceModule struct {
+	manager                 *Thread
+	nextTraceDatasetVersion uint64
+}
+
+func (t *TraceModule) String() string {
+	return "TraceMgmt"
+}
+
+func (t *TraceModule) registerManager(manager *Thread) {
+	t.manager = manager
+}
+
+func (t *TraceModule) getManager() *Thread {
+	return t.manager
+}
+
+func (t *TraceModule) handleIncomingInterest(interest *spec.Interest, pitToken []byte, inFace uint64) {
+	// Only allow from /localhost
+	if !t.manager.localPrefix.IsPrefix(interest.NameV) {
+		core.LogWarn(t, "Received trace management Interest from non-local source - DROP")
+		return
+	}
+
+	// Dispatch by verb
+	verb := interest.NameV[t.manager.prefixLength()+1].String()
+	switch verb {
+	case "list":
+		t.list(interest, pitToken, inFace)
+	default:
+		core.LogWarn(t, "Received Interest for non-existent verb '", verb, "'")
+		response := makeControlResponse(501, "Unknown verb", nil)
+		t.manager.sendResponse(response, interest, pitToken, inFace)
+		return
+	}
+}
+
+// list publishes the most recent packet traces, oldest first.
+func (t *TraceModule) list(interest *spec.Interest, pitToken []byte, _ uint64) {
+	if len(interest.NameV) > t.manager.prefixLength()+2 {
+		// Ignore because contains version and/or segment components
+		return
+	}
+
+	traces := []*mgmt.Trace{}
+	for _, trace := range fw.Tracer.Traces() {
+		events := make([]*mgmt.TraceEvent, 0, len(trace.Events))
+		for _, event := range trace.Events {
+			e := &mgmt.TraceEvent{
+				Timestamp: uint64(event.Time.UnixNano()),
+				Event:     event.Event,
+				Thread:    uint64(event.Thread),
+				Detail:    optStringPtr(event.Detail),
+			}
+			if event.FaceID != 0 {
+				e.FaceId = utils.IdPtr(event.FaceID)
+			}
+			events = append(events, e)
+		}
+
+		traces = append(traces, &mgmt.Trace{
+			TraceId:    trace.ID,
+			Name:       trace.Name,
+			PacketType: trace.PacketType,
+			FaceId:     trace.InFace,
+			Events:     events,
+		})
+	}
+
+	dataset := &mgmt.TraceMsg{Traces: traces}
+	name, _ := enc.NameFromStr(t.manager.localPrefix.String() + "/trace/list")
+	segments := t.manager.sendStatusDataset(name, t.nextTraceDatasetVersion, dataset.Encode(), pitToken)
+
+	core.LogTrace(t, "Published trace dataset version=", t.nextTraceDatasetVersion,
+		", containing ", segments, " segments")
+	t.nextTraceDatasetVersion++
+}
@@ -10,6 +10,7 @@ package table
 import (
 	"time"
 
+	"github.com/named-data/ndnd/fw/core"
 	enc "github.com/named-data/ndnd/std/encoding"
 	"github.com/named-data/ndnd/std/utils/priority_queue"
 )
@@ -18,14 +19,14 @@ import (
 type DeadNonceList struct {
 	list            map[uint64]bool
 	expirationQueue priority_queue.Queue[uint64, int64]
-	Ticker          *time.Ticker
+	Ticker          core.Ticker
 }
 
 // NewDeadNonceList creates a new Dead Nonce List for a forwarding thread.
 func NewDeadNonceList() *DeadNonceList {
 	d := new(DeadNonceList)
 	d.list = make(map[uint64]bool)
-	d.Ticker = time.NewTicker(100 * time.Millisecond)
+	d.Ticker = core.NewTicker(100 * time.Millisecond)
 	d.expirationQueue = priority_queue.New[uint64, int64]()
 	return d
 }
@@ -44,7 +45,7 @@ func (d *DeadNonceList) Insert(name enc.Name, nonce uint32) bool {
 
 	if !exists {
 		d.list[hash] = true
-		d.expirationQueue.Push(hash, time.Now().Add(deadNonceListLifetime).UnixNano())
+		d.expirationQueue.Push(hash, core.Now().Add(deadNonceListLifetime).UnixNano())
 	}
 	return exists
 }
@@ -52,7 +53,7 @@ func (d *DeadNonceList) Insert(name enc.Name, nonce uint32) bool {
 // RemoveExpiredEntry removes all expired entries from Dead Nonce List.
 func (d *DeadNonceList) RemoveExpiredEntries() {
 	evicted := 0
-	for d.expirationQueue.Len() > 0 && d.expirationQueue.PeekPriority() < time.Now().UnixNano() {
+	for d.expirationQueue.Len() > 0 && d.expirationQueue.PeekPriority() < core.Now().UnixNano() {
 		hash := d.expirationQueue.Pop()
 		delete(d.list, hash)
 		evicted += 1
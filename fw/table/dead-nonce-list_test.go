@@ -0,0 +1,36 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadNonceListExpiry(t *testing.T) {
+	clock := core.NewManualClock(time.Unix(1000, 0))
+	prevClock := core.SetClock(clock)
+	defer core.SetClock(prevClock)
+
+	deadNonceListLifetime = 6 * time.Second
+	dnl := NewDeadNonceList()
+	defer dnl.Ticker.Stop()
+
+	name, _ := enc.NameFromStr("/dnl/test")
+	assert.False(t, dnl.Insert(name, 1))
+	assert.True(t, dnl.Insert(name, 1))
+
+	// The ticker fires on the clock, and entries are kept during their lifetime
+	clock.Advance(5 * time.Second)
+	<-dnl.Ticker.C()
+	dnl.RemoveExpiredEntries()
+	assert.True(t, dnl.Find(name, 1))
+
+	clock.Advance(2 * time.Second)
+	<-dnl.Ticker.C()
+	dnl.RemoveExpiredEntries()
+	assert.False(t, dnl.Find(name, 1))
+	assert.False(t, dnl.Insert(name, 1))
+}
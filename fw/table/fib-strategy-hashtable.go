@@ -15,49 +15,113 @@ package table
 
 import (
 	"sync"
+	"sync/atomic"
 
 	enc "github.com/named-data/ndnd/std/encoding"
 )
 
-type virtualDetails struct {
-	// md is the max depth associated with this virtual node, as defined in the paper.
-	md int
+// fibHashTableShards is the number of shards of the hash table FIB maps.
+// An update copies the shards it modifies, so its cost is divided by this number.
+const fibHashTableShards = 256
+
+// fibShardedMap is a map of name hashes split into shards. Published shards are never
+// modified: an update replaces the shards it modifies with modified copies.
+type fibShardedMap[V any] struct {
+	shards [fibHashTableShards]map[uint64]V
 }
 
-// FibStrategyHashTable represents a tree implementation of the FIB-Strategy table.
-type FibStrategyHashTable struct {
-	// m is the name length for virtual nodes, as defined in the paper
-	// Must be a positive value
-	m int
+func (m *fibShardedMap[V]) get(hash uint64) (val V, ok bool) {
+	val, ok = m.shards[hash%fibHashTableShards][hash]
+	return val, ok
+}
+
+func (m *fibShardedMap[V]) values() []V {
+	values := make([]V, 0)
+	for _, shard := range m.shards {
+		for _, v := range shard {
+			values = append(values, v)
+		}
+	}
+	return values
+}
 
+// fibShardedMapBatch modifies a copy of a sharded map. Each shard is copied once,
+// the first time it is modified, and is then modified in place until it is published.
+type fibShardedMapBatch[V any] struct {
+	fibShardedMap[V]
+	owned [fibHashTableShards]bool
+}
+
+// own returns the shard of a hash, which the batch may modify.
+func (m *fibShardedMapBatch[V]) own(hash uint64) map[uint64]V {
+	i := hash % fibHashTableShards
+	if !m.owned[i] {
+		shard := make(map[uint64]V, len(m.shards[i])+1)
+		for k, v := range m.shards[i] {
+			shard[k] = v
+		}
+		m.shards[i], m.owned[i] = shard, true
+	}
+	return m.shards[i]
+}
+
+func (m *fibShardedMapBatch[V]) set(hash uint64, val V) {
+	m.own(hash)[hash] = val
+}
+
+func (m *fibShardedMapBatch[V]) delete(hash uint64) {
+	if _, ok := m.get(hash); ok {
+		delete(m.own(hash), hash)
+	}
+}
+
+// fibHashTables is a version of the tables of the hash table FIB.
+type fibHashTables struct {
 	// realTable is a map of names (hashed as uint64 values) to the FIB entry
 	// associated with that name.
-	realTable map[uint64]*baseFibStrategyEntry
+	realTable fibShardedMap[*baseFibStrategyEntry]
 
 	// virtTable is a map of virtual names (hashed as uint64 values) to the
-	// virtualDetails struct associated with that virtual name.
-	virtTable map[uint64]*virtualDetails
+	// max depth associated with this virtual name, as defined in the paper.
+	virtTable fibShardedMap[int]
+}
+
+// FibStrategyHashTable represents a hash table implementation of the FIB-Strategy table.
+// Lookups take no lock, and see a consistent version of the tables: published entries
+// and shards are never modified, and an update replaces the tables in a single step.
+type FibStrategyHashTable struct {
+	// m is the name length for virtual nodes, as defined in the paper
+	// Must be a positive value
+	m int
+
+	tables atomic.Pointer[fibHashTables]
 
 	// virtTableNames is a map of virtual names (hashed as uint64 values) to
 	// a set of all the real names associated with that virtual name. The
 	// inner map is being used as a set to map name bytes into lengths.
 	// string is simply used as an immutable version of bytes
+	// It is only used by writers.
 	virtTableNames map[uint64](map[string]int)
 
-	// fibStrategyRWMutex is a mutex used to synchronize accesses to the FIB,
+	// fibStrategyMutex is a mutex used to serialize updates to the FIB,
 	// which is shared across all the forwarding threads.
-	fibStrategyRWMutex sync.RWMutex
+	fibStrategyMutex sync.Mutex
+}
+
+// fibStrategyHashTableBatch makes changes to a copy of the tables, which is published at once.
+type fibStrategyHashTableBatch struct {
+	f         *FibStrategyHashTable
+	realTable fibShardedMapBatch[*baseFibStrategyEntry]
+	virtTable fibShardedMapBatch[int]
 }
 
 // newFibStrategyTableHashTable creates a new FIB with the hash table algorithm.
 // The argument m determines the virtual name length.
 func newFibStrategyTableHashTable(m uint16) {
-	FibStrategyTable = new(FibStrategyHashTable)
-	fibStrategyTableHashTable := FibStrategyTable.(*FibStrategyHashTable)
+	fibStrategyTableHashTable := new(FibStrategyHashTable)
+	FibStrategyTable = fibStrategyTableHashTable
 
 	fibStrategyTableHashTable.m = int(m) // Cast to int so that it's easy to pass to name.Prefix
-	fibStrategyTableHashTable.realTable = make(map[uint64]*baseFibStrategyEntry)
-	fibStrategyTableHashTable.virtTable = make(map[uint64]*virtualDetails)
 	fibStrategyTableHashTable.virtTableNames = make(map[uint64]map[string]int)
 	rootName, _ := enc.NameFromStr(("/"))
 	defaultStrategy, _ := enc.NameFromStr("/localhost/nfd/strategy/best-route/v=1")
@@ -65,17 +129,18 @@ func newFibStrategyTableHashTable(m uint16) {
 	rtEntry := new(baseFibStrategyEntry)
 	rtEntry.name = rootName
 	rtEntry.strategy = defaultStrategy
-	fibStrategyTableHashTable.realTable[rootName.Hash()] = rtEntry
+	tables := new(fibHashTables)
+	tables.realTable.shards[rootName.Hash()%fibHashTableShards] = map[uint64]*baseFibStrategyEntry{rootName.Hash(): rtEntry}
+	fibStrategyTableHashTable.tables.Store(tables)
 }
 
 // findLongestPrefixMatch returns the entry corresponding to the longest
 // prefix match of the given name. It returns nil if no exact match was found.
-func (f *FibStrategyHashTable) findLongestPrefixMatchEnc(name enc.Name) *baseFibStrategyEntry {
-	prefixHash := name.PrefixHash()
-	if len(name) <= f.m {
+func (f *fibHashTables) findLongestPrefixMatchEnc(m int, name enc.Name, prefixHash []uint64) *baseFibStrategyEntry {
+	if len(name) <= m {
 		// Name length is less than or equal to M, so only need to check real table
 		for pfx := len(name); pfx >= 0; pfx-- {
-			if val, ok := f.realTable[prefixHash[pfx]]; ok {
+			if val, ok := f.realTable.get(prefixHash[pfx]); ok {
 				return val
 			}
 		}
@@ -83,14 +148,14 @@ func (f *FibStrategyHashTable) findLongestPrefixMatchEnc(name enc.Name) *baseFib
 	}
 
 	// Name is longer than M, so use virtual node to lookup first
-	// virtName := (name)[:f.m]
-	virtNameHash := prefixHash[f.m]
-	virtEntry, ok := f.virtTable[virtNameHash]
+	// virtName := (name)[:m]
+	virtNameHash := prefixHash[m]
+	md, ok := f.virtTable.get(virtNameHash)
 	if ok {
 		// Virtual name present, look for longer matches
-		pfx := min(virtEntry.md, len(name))
-		for ; pfx > f.m; pfx-- {
-			if val, ok := f.realTable[prefixHash[pfx]]; ok {
+		pfx := min(md, len(name))
+		for ; pfx > m; pfx-- {
+			if val, ok := f.realTable.get(prefixHash[pfx]); ok {
 				return val
 			}
 		}
@@ -100,8 +165,8 @@ func (f *FibStrategyHashTable) findLongestPrefixMatchEnc(name enc.Name) *baseFib
 	// Start looking in the real table from length M
 	// For example: Table has prefixes /a and /a/b/c, virtual entry is /a/b
 	// A search for /a/b/d will not match /a/b/c, so we need it to match /a
-	for pfx := f.m; pfx >= 0; pfx-- {
-		if val, ok := f.realTable[prefixHash[pfx]]; ok {
+	for pfx := m; pfx >= 0; pfx-- {
+		if val, ok := f.realTable.get(prefixHash[pfx]); ok {
 			return val
 		}
 	}
@@ -110,137 +175,102 @@ func (f *FibStrategyHashTable) findLongestPrefixMatchEnc(name enc.Name) *baseFib
 	return nil
 }
 
-// insertEntry inserts an entry into the FIB table, specifically realTable,
-// virtTable, and virtTableNames. It does not set the nexthops or strategy
-// fields of the newly created entry. The caller is responsible for doing that.
-func (f *FibStrategyHashTable) insertEntryEnc(name enc.Name) *baseFibStrategyEntry {
+// updateEntryEnc replaces the entry of a name by a copy modified by update, which
+// is created if needed.
+func (b *fibStrategyHashTableBatch) updateEntryEnc(name enc.Name, update func(entry *baseFibStrategyEntry)) {
 	prefixHash := name.PrefixHash()
 	nameHash := prefixHash[len(name)]
-	nameBytes := string(name.Bytes())
 
-	if _, ok := f.realTable[nameHash]; !ok {
-		rtEntry := new(baseFibStrategyEntry)
-		rtEntry.name = name
-		f.realTable[nameHash] = rtEntry
+	entry := new(baseFibStrategyEntry)
+	if old, ok := b.realTable.get(nameHash); ok {
+		*entry = *old
+	} else {
+		entry.name = name.Clone()
 	}
+	update(entry)
 
-	// Insert into virtual table if name size >= M
-	if len(name) == f.m {
-		if _, ok := f.virtTable[nameHash]; !ok {
-			vtEntry := new(virtualDetails)
-			vtEntry.md = len(name)
-			f.virtTable[nameHash] = vtEntry
-		}
+	if len(entry.nexthops) == 0 && entry.strategy == nil {
+		b.pruneTables(entry, prefixHash)
+		return
+	}
+	b.realTable.set(nameHash, entry)
 
-		if _, ok := f.virtTableNames[nameHash]; !ok {
-			f.virtTableNames[nameHash] = make(map[string]int)
+	// Insert into virtual table if name size >= M
+	if len(name) >= b.f.m {
+		virtNameHash := prefixHash[b.f.m]
+		if _, ok := b.f.virtTableNames[virtNameHash]; !ok {
+			b.f.virtTableNames[virtNameHash] = make(map[string]int)
 		}
 
-		f.virtTable[nameHash].md = max(f.virtTable[nameHash].md, len(name))
-
 		// Insert into set of names
-		f.virtTableNames[nameHash][nameBytes] = len(name)
-
-	} else if len(name) > f.m {
-		virtNameHash := prefixHash[f.m]
-		if _, ok := f.virtTable[virtNameHash]; !ok {
-			vtEntry := new(virtualDetails)
-			vtEntry.md = len(name)
-			f.virtTable[virtNameHash] = vtEntry
-		}
+		b.f.virtTableNames[virtNameHash][string(name.Bytes())] = len(name)
 
-		if _, ok := f.virtTableNames[virtNameHash]; !ok {
-			f.virtTableNames[virtNameHash] = make(map[string]int)
+		if md, ok := b.virtTable.get(virtNameHash); !ok || md < len(name) {
+			b.virtTable.set(virtNameHash, len(name))
 		}
-
-		f.virtTable[virtNameHash].md = max(f.virtTable[virtNameHash].md, len(name))
-
-		// Insert into set of names
-		f.virtTableNames[virtNameHash][nameBytes] = len(name)
 	}
-
-	return f.realTable[nameHash]
 }
 
-// pruneTables takes in an entry and removes it from the real table if it has no
-// next hops and no strategy associated with it. It also eliminates its
-// corresponding virtual entry, if applicable.
-func (f *FibStrategyHashTable) pruneTables(entry *baseFibStrategyEntry) {
-	var pruned bool
+// pruneTables removes an entry that has no next hops and no strategy associated
+// with it from the real table. It also eliminates its corresponding virtual entry,
+// if applicable.
+func (b *fibStrategyHashTableBatch) pruneTables(entry *baseFibStrategyEntry, prefixHash []uint64) {
 	name := entry.name
-	prefixHash := name.PrefixHash()
-	nameHash := prefixHash[len(name)]
 	nameBytes := string(name.Bytes())
 
 	// Delete the real entry
-	if len(entry.nexthops) == 0 && entry.strategy == nil {
-		delete(f.realTable, nameHash)
-		pruned = true
-	}
-
-	if !pruned {
-		return
-	}
+	b.realTable.delete(prefixHash[len(name)])
 
 	// Delete the virtual entry too, if needed
-	if len(name) >= f.m {
-		virtNameHash := prefixHash[f.m]
-		virtEntry, inVirtTable := f.virtTable[virtNameHash]
-		virtTableNamesEntry, inVirtNameTable := f.virtTableNames[virtNameHash]
-		namePresentForVirtName := false
-		if inVirtNameTable {
-			_, namePresentForVirtName = virtTableNamesEntry[nameBytes]
+	if len(name) >= b.f.m {
+		virtNameHash := prefixHash[b.f.m]
+		virtTableNamesEntry, ok := b.f.virtTableNames[virtNameHash]
+		if !ok {
+			return
+		}
+		if _, ok := virtTableNamesEntry[nameBytes]; !ok {
+			return
 		}
 
-		// If virtual name is present in table
-		// AND real name is associated with this virtual name
-		// AND this real name was deleted from the real table
-		// Then delete from virtualTableNames if it's last real name
-		// associated with the virtual name
-		if inVirtTable && namePresentForVirtName && pruned {
-			delete(virtTableNamesEntry, nameBytes)
-			if len(virtTableNamesEntry) == 0 {
-				delete(f.virtTableNames, virtNameHash)
-			}
+		// Delete from virtualTableNames, and from the virtual table if it was
+		// the last real name associated with the virtual name
+		delete(virtTableNamesEntry, nameBytes)
+		if len(virtTableNamesEntry) == 0 {
+			delete(b.f.virtTableNames, virtNameHash)
+			b.virtTable.delete(virtNameHash)
+			return
 		}
 
-		// If virtual name is present in table
-		// AND the real name being deleted was the longest associated with the virtual name
-		// AND this real name was deleted from the real table
-		if inVirtTable && len(name) == virtEntry.md && pruned {
-			_, inVirtNameTable = f.virtTableNames[virtNameHash]
-			if !inVirtNameTable {
-				// Delete the entry entirely from the virtual table too
-				// if it was removed it from the virtual name table
-				delete(f.virtTable, virtNameHash)
-			} else {
-				// Update with length of next longest real prefix associated
-				// with this virtual prefix
-				for _, l := range f.virtTableNames[virtNameHash] {
-					virtEntry.md = max(virtEntry.md, l)
-				}
+		// Update with length of next longest real prefix associated
+		// with this virtual prefix, if the deleted name was the longest
+		if md, _ := b.virtTable.get(virtNameHash); len(name) == md {
+			md = 0
+			for _, l := range virtTableNamesEntry {
+				md = max(md, l)
 			}
+			b.virtTable.set(virtNameHash, md)
 		}
 	}
 }
 
 // FindNextHops returns the longest-prefix matching nexthop(s) matching the specified name.
-
+// The returned slice must not be modified.
 func (f *FibStrategyHashTable) FindNextHopsEnc(name enc.Name) []*FibNextHopEntry {
-	f.fibStrategyRWMutex.RLock()
-	defer f.fibStrategyRWMutex.RUnlock()
-
-	entry := f.findLongestPrefixMatchEnc(name)
+	tables := f.tables.Load()
+	prefixHash := name.PrefixHash()
+	entry := tables.findLongestPrefixMatchEnc(f.m, name, prefixHash)
 
 	if entry == nil {
 		return nil
 	}
+	if len(entry.nexthops) > 0 {
+		return entry.nexthops
+	}
 
 	// Go backwards to find the first entry with nexthops
 	// since some might only have a strategy but no nexthops
-	prefixHash := name.PrefixHash()
-	for pfx := len(entry.name); pfx >= 0; pfx-- {
-		val, ok := f.realTable[prefixHash[pfx]]
+	for pfx := len(entry.name) - 1; pfx >= 0; pfx-- {
+		val, ok := tables.realTable.get(prefixHash[pfx])
 		if ok && len(val.nexthops) > 0 {
 			return val.nexthops
 		}
@@ -250,22 +280,22 @@ func (f *FibStrategyHashTable) FindNextHopsEnc(name enc.Name) []*FibNextHopEntry
 }
 
 // FindStrategy returns the longest-prefix matching strategy choice entry for the specified name.
-
 func (f *FibStrategyHashTable) FindStrategyEnc(name enc.Name) enc.Name {
-	f.fibStrategyRWMutex.RLock()
-	defer f.fibStrategyRWMutex.RUnlock()
-
-	entry := f.findLongestPrefixMatchEnc(name)
+	tables := f.tables.Load()
+	prefixHash := name.PrefixHash()
+	entry := tables.findLongestPrefixMatchEnc(f.m, name, prefixHash)
 
 	if entry == nil {
 		return nil
 	}
+	if entry.strategy != nil {
+		return entry.strategy
+	}
 
 	// Go backwards to find the first entry with strategy
 	// since some might only have a nexthops but no strategy
-	prefixHash := name.PrefixHash()
-	for pfx := len(entry.name); pfx >= 0; pfx-- {
-		val, ok := f.realTable[prefixHash[pfx]]
+	for pfx := len(entry.name) - 1; pfx >= 0; pfx-- {
+		val, ok := tables.realTable.get(prefixHash[pfx])
 		if ok && val.strategy != nil {
 			return val.strategy
 		}
@@ -275,72 +305,94 @@ func (f *FibStrategyHashTable) FindStrategyEnc(name enc.Name) enc.Name {
 }
 
 // InsertNextHop adds or updates a nexthop entry for the specified prefix.
-func (f *FibStrategyHashTable) InsertNextHopEnc(name enc.Name, nexthop uint64, cost uint64) {
-	f.fibStrategyRWMutex.Lock()
-	defer f.fibStrategyRWMutex.Unlock()
+func (b *fibStrategyHashTableBatch) InsertNextHopEnc(name enc.Name, nexthop uint64, cost uint64) {
+	b.updateEntryEnc(name, func(entry *baseFibStrategyEntry) {
+		entry.nexthops = insertNextHop(entry.nexthops, nexthop, cost)
+	})
+}
 
-	realEntry := f.insertEntryEnc(name)
+// SetNextHops replaces all nexthops for the specified prefix at once.
+func (b *fibStrategyHashTableBatch) SetNextHopsEnc(name enc.Name, nexthops []*FibNextHopEntry) {
+	b.updateEntryEnc(name, func(entry *baseFibStrategyEntry) {
+		entry.nexthops = copyNextHops(nexthops)
+	})
+}
 
-	for _, existingNextHop := range realEntry.nexthops {
-		if existingNextHop.Nexthop == nexthop {
-			// Update existing hop
-			existingNextHop.Cost = cost
-			return
-		}
+// ClearNextHops clears all nexthops for the specified prefix.
+func (b *fibStrategyHashTableBatch) ClearNextHopsEnc(name enc.Name) {
+	if _, ok := b.realTable.get(name.Hash()); ok {
+		b.updateEntryEnc(name, func(entry *baseFibStrategyEntry) {
+			entry.nexthops = nil
+		})
 	}
+}
 
-	// Did not update existing hop, so add a new hop
-	nhEntry := new(FibNextHopEntry)
-	nhEntry.Nexthop = nexthop
-	nhEntry.Cost = cost
-	realEntry.nexthops = append(realEntry.nexthops, nhEntry)
-
+// RemoveNextHop removes the specified nexthop entry from the specified prefix
+func (b *fibStrategyHashTableBatch) RemoveNextHopEnc(name enc.Name, nexthop uint64) {
+	if _, ok := b.realTable.get(name.Hash()); ok {
+		b.updateEntryEnc(name, func(entry *baseFibStrategyEntry) {
+			entry.nexthops = removeNextHop(entry.nexthops, nexthop)
+		})
+	}
 }
 
-// ClearNextHops clears all nexthops for the specified prefix.
-func (f *FibStrategyHashTable) ClearNextHopsEnc(name enc.Name) {
-	f.fibStrategyRWMutex.Lock()
-	defer f.fibStrategyRWMutex.Unlock()
+// SetStrategy sets the strategy for the specified prefix.
+func (b *fibStrategyHashTableBatch) SetStrategyEnc(name enc.Name, strategy enc.Name) {
+	strategy = strategy.Clone()
+	b.updateEntryEnc(name, func(entry *baseFibStrategyEntry) {
+		entry.strategy = strategy
+	})
+}
 
-	entry, ok := f.realTable[name.Hash()]
-	if ok {
-		entry.nexthops = make([]*FibNextHopEntry, 0)
-		f.pruneTables(entry)
+// UnsetStrategy unsets the strategy for the specified prefix.
+func (b *fibStrategyHashTableBatch) UnSetStrategyEnc(name enc.Name) {
+	if _, ok := b.realTable.get(name.Hash()); ok {
+		b.updateEntryEnc(name, func(entry *baseFibStrategyEntry) {
+			entry.strategy = nil
+		})
 	}
 }
 
-// RemoveNextHop removes the specified nexthop entry from the specified prefix
+// Update makes several changes that lookups see all at once, when it returns.
+func (f *FibStrategyHashTable) Update(changes func(fib FibStrategyUpdate)) {
+	f.fibStrategyMutex.Lock()
+	defer f.fibStrategyMutex.Unlock()
+
+	tables := f.tables.Load()
+	batch := &fibStrategyHashTableBatch{f: f}
+	batch.realTable.fibShardedMap = tables.realTable
+	batch.virtTable.fibShardedMap = tables.virtTable
+	changes(batch)
+	f.tables.Store(&fibHashTables{
+		realTable: batch.realTable.fibShardedMap,
+		virtTable: batch.virtTable.fibShardedMap,
+	})
+}
 
-func (f *FibStrategyHashTable) RemoveNextHopEnc(name enc.Name, nexthop uint64) {
-	f.fibStrategyRWMutex.Lock()
-	defer f.fibStrategyRWMutex.Unlock()
+// InsertNextHop adds or updates a nexthop entry for the specified prefix.
+func (f *FibStrategyHashTable) InsertNextHopEnc(name enc.Name, nexthop uint64, cost uint64) {
+	f.Update(func(fib FibStrategyUpdate) { fib.InsertNextHopEnc(name, nexthop, cost) })
+}
 
-	nameHash := name.Hash()
-	if _, ok := f.realTable[nameHash]; !ok {
-		return
-	}
+// SetNextHops replaces all nexthops for the specified prefix at once.
+func (f *FibStrategyHashTable) SetNextHopsEnc(name enc.Name, nexthops []*FibNextHopEntry) {
+	f.Update(func(fib FibStrategyUpdate) { fib.SetNextHopsEnc(name, nexthops) })
+}
 
-	// Remove matching nexthop from real table (if one exists)
-	realEntry := f.realTable[nameHash]
-	nextHops := realEntry.nexthops
-	for i, nh := range nextHops {
-		if nh.Nexthop == nexthop {
-			if len(nextHops) > 1 {
-				nextHops[i] = nextHops[len(nextHops)-1]
-			}
-			realEntry.nexthops = nextHops[:len(nextHops)-1]
-			f.pruneTables(realEntry)
-			break
-		}
-	}
+// ClearNextHops clears all nexthops for the specified prefix.
+func (f *FibStrategyHashTable) ClearNextHopsEnc(name enc.Name) {
+	f.Update(func(fib FibStrategyUpdate) { fib.ClearNextHopsEnc(name) })
+}
+
+// RemoveNextHop removes the specified nexthop entry from the specified prefix
+func (f *FibStrategyHashTable) RemoveNextHopEnc(name enc.Name, nexthop uint64) {
+	f.Update(func(fib FibStrategyUpdate) { fib.RemoveNextHopEnc(name, nexthop) })
 }
 
 // GetAllFIBEntries returns all nexthop entries in the FIB.
 func (f *FibStrategyHashTable) GetAllFIBEntries() []FibStrategyEntry {
-	f.fibStrategyRWMutex.RLock()
-	defer f.fibStrategyRWMutex.RUnlock()
 	entries := make([]FibStrategyEntry, 0)
-	for _, v := range f.realTable {
+	for _, v := range f.tables.Load().realTable.values() {
 		if len(v.nexthops) > 0 {
 			entries = append(entries, v)
 		}
@@ -350,33 +402,19 @@ func (f *FibStrategyHashTable) GetAllFIBEntries() []FibStrategyEntry {
 }
 
 // SetStrategy sets the strategy for the specified prefix.
-
 func (f *FibStrategyHashTable) SetStrategyEnc(name enc.Name, strategy enc.Name) {
-	f.fibStrategyRWMutex.Lock()
-	defer f.fibStrategyRWMutex.Unlock()
-
-	realEntry := f.insertEntryEnc(name)
-	realEntry.strategy = strategy
+	f.Update(func(fib FibStrategyUpdate) { fib.SetStrategyEnc(name, strategy) })
 }
 
 // UnsetStrategy unsets the strategy for the specified prefix.
 func (f *FibStrategyHashTable) UnSetStrategyEnc(name enc.Name) {
-	f.fibStrategyRWMutex.Lock()
-	defer f.fibStrategyRWMutex.Unlock()
-
-	entry, ok := f.realTable[name.Hash()]
-	if ok {
-		entry.strategy = nil
-		f.pruneTables(entry)
-	}
+	f.Update(func(fib FibStrategyUpdate) { fib.UnSetStrategyEnc(name) })
 }
 
 // GetAllForwardingStrategies returns all strategy choice entries in the Strategy Table.
 func (f *FibStrategyHashTable) GetAllForwardingStrategies() []FibStrategyEntry {
-	f.fibStrategyRWMutex.RLock()
-	defer f.fibStrategyRWMutex.RUnlock()
 	entries := make([]FibStrategyEntry, 0)
-	for _, v := range f.realTable {
+	for _, v := range f.tables.Load().realTable.values() {
 		if v.strategy != nil {
 			entries = append(entries, v)
 		}
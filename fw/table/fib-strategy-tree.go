@@ -9,136 +9,194 @@ package table
 
 import (
 	"container/list"
+	"slices"
 	"sync"
+	"sync/atomic"
 
 	enc "github.com/named-data/ndnd/std/encoding"
 )
 
+// fibStrategyTreeEntry is a node of the tree. Published nodes are never modified:
+// an update replaces the node and all its ancestors with modified copies.
 type fibStrategyTreeEntry struct {
 	baseFibStrategyEntry
 	depth    int
-	parent   *fibStrategyTreeEntry
 	children []*fibStrategyTreeEntry
 }
 
 // FibStrategy Tree represents a tree implementation of the FIB-Strategy table.
+// Lookups take no lock, and see a consistent version of the tree.
 type FibStrategyTree struct {
-	root *fibStrategyTreeEntry
+	root atomic.Pointer[fibStrategyTreeEntry]
 
-	// fibStrategyRWMutex is a mutex used to synchronize accesses to the FIB,
+	// fibStrategyMutex is a mutex used to serialize updates to the FIB,
 	// which is shared across all the forwarding threads.
-	fibStrategyRWMutex sync.RWMutex
-
-	fibPrefixes map[uint64]*fibStrategyTreeEntry
+	fibStrategyMutex sync.Mutex
 }
 
 func newFibStrategyTableTree() {
-	FibStrategyTable = new(FibStrategyTree)
-	fibStrategyTableTree := FibStrategyTable.(*FibStrategyTree)
-	fibStrategyTableTree.root = new(fibStrategyTreeEntry)
+	fibStrategyTableTree := new(FibStrategyTree)
+	FibStrategyTable = fibStrategyTableTree
+	root := new(fibStrategyTreeEntry)
 	// Root component will be nil since it represents zero components
-	fibStrategyTableTree.root.component = enc.Component{}
+	root.component = enc.Component{}
 	base, _ := enc.NameFromStr("/localhost/nfd/strategy/best-route/v=1")
-	fibStrategyTableTree.root.strategy = base
-	fibStrategyTableTree.root.name = enc.Name{}
-	fibStrategyTableTree.fibPrefixes = make(map[uint64]*fibStrategyTreeEntry)
+	root.strategy = base
+	root.name = enc.Name{}
+	fibStrategyTableTree.root.Store(root)
 }
 
-// findExactMatchEntry returns the entry corresponding to the exact match of
-// the given name. It returns nil if no exact match was found.
-
-func (f *fibStrategyTreeEntry) findExactMatchEntryEnc(name enc.Name) *fibStrategyTreeEntry {
+// findChildEnc returns the child on the path to the given name.
+// It returns nil if there is no such child.
+func (f *fibStrategyTreeEntry) findChildEnc(name enc.Name) *fibStrategyTreeEntry {
 	if len(name) > f.depth {
 		for _, child := range f.children {
 			if At(name, child.depth-1).Equal(child.component) {
-				return child.findExactMatchEntryEnc(name)
+				return child
 			}
 		}
-	} else if len(name) == f.depth {
-		return f
 	}
 	return nil
 }
 
-// findLongestPrefixEntry returns the entry corresponding to the longest
-// prefix match of the given name. It returns nil if no exact match was found.
+// isEmpty returns whether a node no longer carries any information, where information
+// is the combination of child nodes, nexthops, and strategies.
+func (f *fibStrategyTreeEntry) isEmpty() bool {
+	return len(f.children) == 0 && len(f.nexthops) == 0 && f.strategy == nil
+}
 
-func (f *fibStrategyTreeEntry) findLongestPrefixEntryEnc(name enc.Name) *fibStrategyTreeEntry {
-	if len(name) > f.depth {
-		for _, child := range f.children {
-			if At(name, child.depth-1).Equal(child.component) {
-				return child.findLongestPrefixEntryEnc(name)
-			}
-		}
-	}
-	return f
+// fibStrategyTreeBatch makes changes to a copy of the tree, which is published at once.
+// Nodes are copied the first time a change is made under them, and are then owned by the
+// batch, which modifies them in place until they are published.
+type fibStrategyTreeBatch struct {
+	root  *fibStrategyTreeEntry
+	owned map[*fibStrategyTreeEntry]bool
 }
 
-// fillTreeToPrefix breaks the given name into components and adds nodes to the
-// tree for any missing components.
-
-func (f *FibStrategyTree) fillTreeToPrefixEnc(name enc.Name) *fibStrategyTreeEntry {
-	curNode := f.root.findLongestPrefixEntryEnc(name)
-	for depth := curNode.depth + 1; depth <= len(name); depth++ {
-		newNode := new(fibStrategyTreeEntry)
-		newNode.component = At(name, depth-1).Clone()
-		newNode.depth = depth
-		newNode.parent = curNode
-		curNode.children = append(curNode.children, newNode)
-		curNode = newNode
+// own returns a node that the batch may modify, which is a copy of a published node.
+func (b *fibStrategyTreeBatch) own(node *fibStrategyTreeEntry) *fibStrategyTreeEntry {
+	if b.owned[node] {
+		return node
 	}
-	return curNode
+	entry := new(fibStrategyTreeEntry)
+	*entry = *node
+	entry.children = slices.Clone(node.children)
+	b.owned[entry] = true
+	return entry
 }
 
-// pruneIfEmpty prunes nodes from the tree if they no longer carry any information,
-// where information is the combination of child nodes, nexthops, and strategies.
-func (f *fibStrategyTreeEntry) pruneIfEmpty() {
-	for curNode := f; curNode.parent != nil && len(curNode.children) == 0 && len(curNode.nexthops) == 0 && curNode.strategy == nil; curNode = curNode.parent {
-		// Remove from parent's children
-		for i, child := range curNode.parent.children {
-			if child == f {
-				if i < len(curNode.parent.children)-1 {
-					copy(curNode.parent.children[i:], curNode.parent.children[i+1:])
-				}
-				curNode.parent.children = curNode.parent.children[:len(curNode.parent.children)-1]
-				break
-			}
+// updateEnc modifies the entry of the given name by update. Missing nodes are created
+// if create is set, and nodes left empty are pruned. Nothing is modified if the entry
+// does not exist and create is not set.
+func (b *fibStrategyTreeBatch) updateEnc(name enc.Name, create bool, update func(entry *fibStrategyTreeEntry)) {
+	if !create {
+		node := b.root
+		for node != nil && node.depth < len(name) {
+			node = node.findChildEnc(name)
+		}
+		if node == nil {
+			return
 		}
 	}
-}
-func (f *fibStrategyTreeEntry) pruneIfEmptyEnc() {
-	for curNode := f; curNode.parent != nil && len(curNode.children) == 0 && len(curNode.nexthops) == 0 &&
-		curNode.strategy == nil; curNode = curNode.parent {
-		// Remove from parent's children
-		for i, child := range curNode.parent.children {
-			if child == f {
-				if i < len(curNode.parent.children)-1 {
-					copy(curNode.parent.children[i:], curNode.parent.children[i+1:])
-				}
-				curNode.parent.children = curNode.parent.children[:len(curNode.parent.children)-1]
-				break
-			}
+
+	// Own the nodes on the path to the entry
+	b.root = b.own(b.root)
+	path := []*fibStrategyTreeEntry{b.root}
+	for node := b.root; node.depth < len(name); node = path[len(path)-1] {
+		child := node.findChildEnc(name)
+		if child == nil {
+			child = new(fibStrategyTreeEntry)
+			child.component = At(name, node.depth).Clone()
+			child.depth = node.depth + 1
+			b.owned[child] = true
+			node.children = append(node.children, child)
+		} else if !b.owned[child] {
+			i := slices.Index(node.children, child)
+			child = b.own(child)
+			node.children[i] = child
 		}
+		path = append(path, child)
+	}
+
+	entry := path[len(path)-1]
+	if entry.name == nil {
+		entry.name = name.Clone()
+	}
+	update(entry)
+
+	// Prune the nodes left empty, but not the root
+	for i := len(path) - 1; i > 0 && path[i].isEmpty(); i-- {
+		path[i-1].children = slices.DeleteFunc(path[i-1].children, func(child *fibStrategyTreeEntry) bool {
+			return child == path[i]
+		})
 	}
 }
 
-// FindNextHops returns the longest-prefix matching nexthop(s) matching the specified name.
+// InsertNextHop adds or updates a nexthop entry for the specified prefix.
+func (b *fibStrategyTreeBatch) InsertNextHopEnc(name enc.Name, nexthop uint64, cost uint64) {
+	b.updateEnc(name, true, func(entry *fibStrategyTreeEntry) {
+		entry.nexthops = insertNextHop(entry.nexthops, nexthop, cost)
+	})
+}
 
-func (f *FibStrategyTree) FindNextHopsEnc(name enc.Name) []*FibNextHopEntry {
-	f.fibStrategyRWMutex.RLock()
-	defer f.fibStrategyRWMutex.RUnlock()
+// SetNextHops replaces all nexthops for the specified prefix at once.
+func (b *fibStrategyTreeBatch) SetNextHopsEnc(name enc.Name, nexthops []*FibNextHopEntry) {
+	b.updateEnc(name, len(nexthops) > 0, func(entry *fibStrategyTreeEntry) {
+		entry.nexthops = copyNextHops(nexthops)
+	})
+}
 
-	// Find longest prefix matching entry
-	curNode := f.root.findLongestPrefixEntryEnc(name)
+// ClearNextHops clears all nexthops for the specified prefix.
+func (b *fibStrategyTreeBatch) ClearNextHopsEnc(name enc.Name) {
+	if name == nil {
+		return // In some weird case, when RibEntry.updateNexthops() is called, the name becomes nil.
+	}
+	b.updateEnc(name, false, func(entry *fibStrategyTreeEntry) {
+		entry.nexthops = nil
+	})
+}
 
-	// Now step back up until we find a nexthops entry
+// RemoveNextHop removes the specified nexthop entry from the specified prefix.
+func (b *fibStrategyTreeBatch) RemoveNextHopEnc(name enc.Name, nexthop uint64) {
+	b.updateEnc(name, false, func(entry *fibStrategyTreeEntry) {
+		entry.nexthops = removeNextHop(entry.nexthops, nexthop)
+	})
+}
+
+// SetStrategy sets the strategy for the specified prefix.
+func (b *fibStrategyTreeBatch) SetStrategyEnc(name enc.Name, strategy enc.Name) {
+	strategy = strategy.Clone()
+	b.updateEnc(name, true, func(entry *fibStrategyTreeEntry) {
+		entry.strategy = strategy
+	})
+}
+
+// UnsetStrategy unsets the strategy for the specified prefix.
+func (b *fibStrategyTreeBatch) UnSetStrategyEnc(name enc.Name) {
+	b.updateEnc(name, false, func(entry *fibStrategyTreeEntry) {
+		entry.strategy = nil
+	})
+}
+
+// Update makes several changes that lookups see all at once, when it returns.
+func (f *FibStrategyTree) Update(changes func(fib FibStrategyUpdate)) {
+	f.fibStrategyMutex.Lock()
+	defer f.fibStrategyMutex.Unlock()
+
+	batch := &fibStrategyTreeBatch{root: f.root.Load(), owned: make(map[*fibStrategyTreeEntry]bool)}
+	changes(batch)
+	f.root.Store(batch.root)
+}
+
+// FindNextHops returns the longest-prefix matching nexthop(s) matching the specified name.
+// The returned slice must not be modified.
+func (f *FibStrategyTree) FindNextHopsEnc(name enc.Name) []*FibNextHopEntry {
+	// Keep the deepest entry with nexthops on the path to the name,
 	// since some might only have a strategy but no nexthops
 	var nexthops []*FibNextHopEntry
-	for ; curNode != nil; curNode = curNode.parent {
+	for curNode := f.root.Load(); curNode != nil; curNode = curNode.findChildEnc(name) {
 		if len(curNode.nexthops) > 0 {
-			nexthops = make([]*FibNextHopEntry, len(curNode.nexthops))
-			copy(nexthops, curNode.nexthops)
-			break
+			nexthops = curNode.nexthops
 		}
 	}
 
@@ -147,19 +205,12 @@ func (f *FibStrategyTree) FindNextHopsEnc(name enc.Name) []*FibNextHopEntry {
 
 // FindStrategy returns the longest-prefix matching strategy choice entry for the specified name.
 func (f *FibStrategyTree) FindStrategyEnc(name enc.Name) enc.Name {
-	f.fibStrategyRWMutex.RLock()
-	defer f.fibStrategyRWMutex.RUnlock()
-
-	// Find longest prefix matching entry
-	curNode := f.root.findLongestPrefixEntryEnc(name)
-
-	// Now step back up until we find a strategy entry
+	// Keep the deepest entry with a strategy on the path to the name,
 	// since some might only have a nexthops but no strategy
 	var strategy enc.Name
-	for ; curNode != nil; curNode = curNode.parent {
+	for curNode := f.root.Load(); curNode != nil; curNode = curNode.findChildEnc(name) {
 		if curNode.strategy != nil {
 			strategy = curNode.strategy
-			break
 		}
 	}
 
@@ -167,76 +218,31 @@ func (f *FibStrategyTree) FindStrategyEnc(name enc.Name) enc.Name {
 }
 
 // InsertNextHop adds or updates a nexthop entry for the specified prefix.
-
 func (f *FibStrategyTree) InsertNextHopEnc(name enc.Name, nexthop uint64, cost uint64) {
-	f.fibStrategyRWMutex.Lock()
-	defer f.fibStrategyRWMutex.Unlock()
-
-	name = name.Clone()
-	entry := f.fillTreeToPrefixEnc(name)
-	if entry.name == nil {
-		entry.name = name
-	}
-	for _, existingNexthop := range entry.nexthops {
-		if existingNexthop.Nexthop == nexthop {
-			existingNexthop.Cost = cost
-			return
-		}
-	}
+	f.Update(func(fib FibStrategyUpdate) { fib.InsertNextHopEnc(name, nexthop, cost) })
+}
 
-	newEntry := new(FibNextHopEntry)
-	newEntry.Nexthop = nexthop
-	newEntry.Cost = cost
-	entry.nexthops = append(entry.nexthops, newEntry)
-	f.fibPrefixes[name.Hash()] = entry
+// SetNextHops replaces all nexthops for the specified prefix at once.
+func (f *FibStrategyTree) SetNextHopsEnc(name enc.Name, nexthops []*FibNextHopEntry) {
+	f.Update(func(fib FibStrategyUpdate) { fib.SetNextHopsEnc(name, nexthops) })
 }
 
 // ClearNextHops clears all nexthops for the specified prefix.
 func (f *FibStrategyTree) ClearNextHopsEnc(name enc.Name) {
-	f.fibStrategyRWMutex.Lock()
-	defer f.fibStrategyRWMutex.Unlock()
-
-	if name == nil {
-		return // In some weird case, when RibEntry.updateNexthops() is called, the name becomes nil.
-	}
-	node := f.root.findExactMatchEntryEnc(name)
-	if node != nil {
-		node.nexthops = make([]*FibNextHopEntry, 0)
-	}
+	f.Update(func(fib FibStrategyUpdate) { fib.ClearNextHopsEnc(name) })
 }
 
 // RemoveNextHop removes the specified nexthop entry from the specified prefix.
-
 func (f *FibStrategyTree) RemoveNextHopEnc(name enc.Name, nexthop uint64) {
-	f.fibStrategyRWMutex.Lock()
-	defer f.fibStrategyRWMutex.Unlock()
-	entry := f.root.findExactMatchEntryEnc(name)
-	if entry != nil {
-		for i, existingNexthop := range entry.nexthops {
-			if existingNexthop.Nexthop == nexthop {
-				if i < len(entry.nexthops)-1 {
-					copy(entry.nexthops[i:], entry.nexthops[i+1:])
-				}
-				entry.nexthops = entry.nexthops[:len(entry.nexthops)-1]
-				break
-			}
-		}
-		if len(entry.nexthops) == 0 {
-			delete(f.fibPrefixes, name.Hash())
-		}
-		entry.pruneIfEmpty()
-	}
+	f.Update(func(fib FibStrategyUpdate) { fib.RemoveNextHopEnc(name, nexthop) })
 }
 
 // GetAllFIBEntries returns all nexthop entries in the FIB.
 func (f *FibStrategyTree) GetAllFIBEntries() []FibStrategyEntry {
-	f.fibStrategyRWMutex.RLock()
-	defer f.fibStrategyRWMutex.RUnlock()
-
 	entries := make([]FibStrategyEntry, 0)
 	// Walk tree in-order
 	queue := list.New()
-	queue.PushBack(f.root)
+	queue.PushBack(f.root.Load())
 	for queue.Len() > 0 {
 		fsEntry := queue.Front().Value.(*fibStrategyTreeEntry)
 		queue.Remove(queue.Front())
@@ -255,39 +261,20 @@ func (f *FibStrategyTree) GetAllFIBEntries() []FibStrategyEntry {
 
 // SetStrategy sets the strategy for the specified prefix.
 func (f *FibStrategyTree) SetStrategyEnc(name enc.Name, strategy enc.Name) {
-	f.fibStrategyRWMutex.Lock()
-	defer f.fibStrategyRWMutex.Unlock()
-
-	name = name.Clone()
-	strategy = strategy.Clone()
-
-	entry := f.fillTreeToPrefixEnc(name)
-	if entry.name == nil {
-		entry.name = name
-	}
-	entry.strategy = strategy
+	f.Update(func(fib FibStrategyUpdate) { fib.SetStrategyEnc(name, strategy) })
 }
 
 // UnsetStrategy unsets the strategy for the specified prefix.
 func (f *FibStrategyTree) UnSetStrategyEnc(name enc.Name) {
-	f.fibStrategyRWMutex.Lock()
-	defer f.fibStrategyRWMutex.Unlock()
-	entry := f.root.findExactMatchEntryEnc(name)
-	if entry != nil {
-		entry.strategy = nil
-		entry.pruneIfEmpty()
-	}
+	f.Update(func(fib FibStrategyUpdate) { fib.UnSetStrategyEnc(name) })
 }
 
 // GetAllForwardingStrategies returns all strategy choice entries in the Strategy Table.
 func (f *FibStrategyTree) GetAllForwardingStrategies() []FibStrategyEntry {
-	f.fibStrategyRWMutex.RLock()
-	defer f.fibStrategyRWMutex.RUnlock()
-
 	entries := make([]FibStrategyEntry, 0)
 	// Walk tree in-order
 	queue := list.New()
-	queue.PushBack(f.root)
+	queue.PushBack(f.root.Load())
 	for queue.Len() > 0 {
 		fsEntry := queue.Front().Value.(*fibStrategyTreeEntry)
 		queue.Remove(queue.Front())
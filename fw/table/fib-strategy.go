@@ -33,17 +33,28 @@ type FibNextHopEntry struct {
 	Cost    uint64
 }
 
-// FibStrategy represents the functionality that a FIB-strategy table should implement.
-type FibStrategy interface {
-	FindNextHopsEnc(name enc.Name) []*FibNextHopEntry
-	FindStrategyEnc(name enc.Name) enc.Name
+// FibStrategyUpdate contains the changes that can be made to a FIB-Strategy table.
+type FibStrategyUpdate interface {
 	InsertNextHopEnc(name enc.Name, nextHop uint64, cost uint64)
+	SetNextHopsEnc(name enc.Name, nextHops []*FibNextHopEntry)
 	ClearNextHopsEnc(name enc.Name)
 	RemoveNextHopEnc(name enc.Name, nextHop uint64)
-	GetAllFIBEntries() []FibStrategyEntry
 	SetStrategyEnc(name enc.Name, strategy enc.Name)
 	UnSetStrategyEnc(name enc.Name)
+}
+
+// FibStrategy represents the functionality that a FIB-strategy table should implement.
+// Lookups must not take locks, as they are made by all the forwarding threads, and
+// every update must be seen by them either entirely or not at all.
+type FibStrategy interface {
+	FibStrategyUpdate
+	FindNextHopsEnc(name enc.Name) []*FibNextHopEntry
+	FindStrategyEnc(name enc.Name) enc.Name
+	GetAllFIBEntries() []FibStrategyEntry
 	GetAllForwardingStrategies() []FibStrategyEntry
+	// Update makes several changes that lookups see all at once, when it returns.
+	// A batch copies each modified part of the table once, whatever the number of changes.
+	Update(changes func(fib FibStrategyUpdate))
 }
 
 // FibStrategy is a table containing FIB and Strategy entries for given prefixes.
@@ -63,3 +74,41 @@ func (e *baseFibStrategyEntry) GetStrategy() enc.Name {
 func (e *baseFibStrategyEntry) GetNextHops() []*FibNextHopEntry {
 	return e.nexthops
 }
+
+// insertNextHop returns a copy of nexthops with a nexthop added or updated.
+// Published nexthops are never modified, as lookups read them without locks.
+func insertNextHop(nexthops []*FibNextHopEntry, nexthop uint64, cost uint64) []*FibNextHopEntry {
+	updated := make([]*FibNextHopEntry, 0, len(nexthops)+1)
+	found := false
+	for _, existing := range nexthops {
+		if existing.Nexthop == nexthop {
+			existing = &FibNextHopEntry{Nexthop: nexthop, Cost: cost}
+			found = true
+		}
+		updated = append(updated, existing)
+	}
+	if !found {
+		updated = append(updated, &FibNextHopEntry{Nexthop: nexthop, Cost: cost})
+	}
+	return updated
+}
+
+// removeNextHop returns a copy of nexthops without a nexthop.
+func removeNextHop(nexthops []*FibNextHopEntry, nexthop uint64) []*FibNextHopEntry {
+	updated := make([]*FibNextHopEntry, 0, len(nexthops))
+	for _, existing := range nexthops {
+		if existing.Nexthop != nexthop {
+			updated = append(updated, existing)
+		}
+	}
+	return updated
+}
+
+// copyNextHops returns a copy of nexthops that the caller cannot modify.
+func copyNextHops(nexthops []*FibNextHopEntry) []*FibNextHopEntry {
+	updated := make([]*FibNextHopEntry, 0, len(nexthops))
+	for _, existing := range nexthops {
+		updated = append(updated, &FibNextHopEntry{Nexthop: existing.Nexthop, Cost: existing.Cost})
+	}
+	return updated
+}
@@ -1,6 +1,9 @@
 package table
 
 import (
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	enc "github.com/named-data/ndnd/std/encoding"
@@ -33,3 +36,172 @@ func TestFibStrategyEntryGetters(t *testing.T) {
 	assert.True(t, bfse.GetStrategy().Equal(name))
 	assert.Equal(t, 2, len(bfse.GetNextHops()))
 }
+
+// fibAlgorithms creates a FIB with each algorithm.
+var fibAlgorithms = map[string]func(){
+	"nametree":  newFibStrategyTableTree,
+	"hashtable": func() { newFibStrategyTableHashTable(2) },
+}
+
+func TestFibConcurrentUpdates(t *testing.T) {
+	const nPrefixes = 64
+	const nReaders = 8
+	const nUpdates = 2000
+
+	bestRoute, _ := enc.NameFromStr("/localhost/nfd/strategy/best-route/v=1")
+	multicast, _ := enc.NameFromStr("/localhost/nfd/strategy/multicast/v=1")
+	stable, _ := enc.NameFromStr("/stable")
+	prefixes := make([]enc.Name, nPrefixes)
+	for i := range prefixes {
+		prefixes[i], _ = enc.NameFromStr("/stable/a/b/" + strconv.Itoa(i))
+	}
+	nexthopSets := [][]*FibNextHopEntry{
+		{{Nexthop: 1, Cost: 10}, {Nexthop: 2, Cost: 20}},
+		{{Nexthop: 3, Cost: 30}, {Nexthop: 4, Cost: 40}, {Nexthop: 5, Cost: 50}},
+	}
+
+	for algo, create := range fibAlgorithms {
+		t.Run(algo, func(t *testing.T) {
+			create()
+			FibStrategyTable.InsertNextHopEnc(stable, 100, 0)
+			for _, prefix := range prefixes {
+				FibStrategyTable.SetNextHopsEnc(prefix, nexthopSets[0])
+			}
+
+			var done atomic.Bool
+			var errors atomic.Int64
+			var wg sync.WaitGroup
+			for r := 0; r < nReaders; r++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; !done.Load(); i++ {
+						name := append(prefixes[i%nPrefixes].Clone(), enc.NewStringComponent(enc.TypeGenericNameComponent, "data"))
+
+						// An update is seen entirely or not at all
+						nexthops := FibStrategyTable.FindNextHopsEnc(name)
+						switch {
+						case len(nexthops) == 1 && nexthops[0].Nexthop == 100:
+						case len(nexthops) == 2 && nexthops[0].Nexthop == 1 && nexthops[1].Nexthop == 2:
+						case len(nexthops) == 3 && nexthops[0].Nexthop == 3 && nexthops[2].Nexthop == 5:
+						default:
+							errors.Add(1)
+						}
+
+						strategy := FibStrategyTable.FindStrategyEnc(name)
+						if !strategy.Equal(bestRoute) && !strategy.Equal(multicast) {
+							errors.Add(1)
+						}
+					}
+				}()
+			}
+
+			for i := 0; i < nUpdates; i++ {
+				prefix := prefixes[i%nPrefixes]
+				switch i % 4 {
+				case 0:
+					FibStrategyTable.SetNextHopsEnc(prefix, nexthopSets[1])
+					FibStrategyTable.SetStrategyEnc(prefix, multicast)
+				case 1:
+					FibStrategyTable.SetNextHopsEnc(prefix, nil)
+					FibStrategyTable.UnSetStrategyEnc(prefix)
+				case 2:
+					FibStrategyTable.SetNextHopsEnc(prefix, nexthopSets[0])
+				case 3:
+					FibStrategyTable.InsertNextHopEnc(prefix, 2, 25)
+				}
+			}
+			done.Store(true)
+			wg.Wait()
+
+			assert.Equal(t, int64(0), errors.Load())
+			assert.Len(t, FibStrategyTable.FindNextHopsEnc(stable), 1)
+			assert.True(t, FibStrategyTable.FindStrategyEnc(stable).Equal(bestRoute))
+		})
+	}
+}
+
+func TestFibUpdate(t *testing.T) {
+	a, _ := enc.NameFromStr("/batch/a")
+	b, _ := enc.NameFromStr("/batch/b/c")
+	multicast, _ := enc.NameFromStr("/localhost/nfd/strategy/multicast/v=1")
+
+	for algo, create := range fibAlgorithms {
+		t.Run(algo, func(t *testing.T) {
+			create()
+			nEntries := len(FibStrategyTable.GetAllFIBEntries())
+
+			FibStrategyTable.Update(func(fib FibStrategyUpdate) {
+				fib.InsertNextHopEnc(a, 1, 10)
+				fib.InsertNextHopEnc(a, 2, 20)
+				fib.RemoveNextHopEnc(a, 1)
+				fib.SetNextHopsEnc(b, []*FibNextHopEntry{{Nexthop: 3, Cost: 30}})
+				fib.SetStrategyEnc(b, multicast)
+
+				// Changes are not published until the batch is done
+				assert.Empty(t, FibStrategyTable.FindNextHopsEnc(a))
+				assert.Len(t, FibStrategyTable.GetAllFIBEntries(), nEntries)
+			})
+			nexthops := FibStrategyTable.FindNextHopsEnc(a)
+			assert.Len(t, nexthops, 1)
+			assert.Equal(t, uint64(2), nexthops[0].Nexthop)
+			assert.Equal(t, uint64(3), FibStrategyTable.FindNextHopsEnc(b)[0].Nexthop)
+			assert.True(t, FibStrategyTable.FindStrategyEnc(b).Equal(multicast))
+			assert.Len(t, FibStrategyTable.GetAllFIBEntries(), nEntries+2)
+
+			// Entries created and emptied by the same batch are not left behind
+			FibStrategyTable.Update(func(fib FibStrategyUpdate) {
+				fib.ClearNextHopsEnc(a)
+				fib.InsertNextHopEnc(append(b.Clone(), b...), 4, 0)
+				fib.RemoveNextHopEnc(append(b.Clone(), b...), 4)
+				fib.SetNextHopsEnc(b, nil)
+				fib.UnSetStrategyEnc(b)
+			})
+			assert.Empty(t, FibStrategyTable.FindNextHopsEnc(b))
+			assert.Len(t, FibStrategyTable.GetAllFIBEntries(), nEntries)
+			assert.Len(t, FibStrategyTable.GetAllForwardingStrategies(), 1)
+			if tree, ok := FibStrategyTable.(*FibStrategyTree); ok {
+				assert.Empty(t, tree.root.Load().children)
+			}
+		})
+	}
+}
+
+func BenchmarkFibFindNextHops(b *testing.B) {
+	for algo, create := range fibAlgorithms {
+		for _, updating := range []bool{false, true} {
+			b.Run(algo+"/updating="+strconv.FormatBool(updating), func(b *testing.B) {
+				create()
+				prefixes := make([]enc.Name, 1000)
+				for i := range prefixes {
+					prefixes[i], _ = enc.NameFromStr("/bench/" + strconv.Itoa(i%10) + "/" + strconv.Itoa(i))
+					FibStrategyTable.InsertNextHopEnc(prefixes[i], uint64(i), 1)
+				}
+
+				var done atomic.Bool
+				var wg sync.WaitGroup
+				if updating {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						for i := 0; !done.Load(); i++ {
+							FibStrategyTable.InsertNextHopEnc(prefixes[i%len(prefixes)], uint64(i), 1)
+							FibStrategyTable.RemoveNextHopEnc(prefixes[i%len(prefixes)], uint64(i))
+						}
+					}()
+				}
+
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					name := append(prefixes[len(prefixes)/2].Clone(), enc.NewStringComponent(enc.TypeGenericNameComponent, "data"))
+					for pb.Next() {
+						FibStrategyTable.FindNextHopsEnc(name)
+					}
+				})
+				b.StopTimer()
+				done.Store(true)
+				wg.Wait()
+			})
+		}
+	}
+}
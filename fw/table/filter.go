@@ -0,0 +1,322 @@
+package table
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	enc "github.com/named-data/ndnd/std/encoding"
+)
+
+// FilterDirection is the direction of packets matched by a filter rule.
+type FilterDirection int
+
+const (
+	FilterBoth FilterDirection = iota
+	FilterIn
+	FilterOut
+)
+
+// FilterPacketType is the type of packets matched by a filter rule.
+type FilterPacketType int
+
+const (
+	FilterAnyPacket FilterPacketType = iota
+	FilterInterest
+	FilterData
+)
+
+// FilterRule is a rule of the packet filter, which allows or denies packets under a name prefix.
+type FilterRule struct {
+	ID         uint64
+	Deny       bool
+	Prefix     enc.Name
+	Direction  FilterDirection
+	PacketType FilterPacketType
+	Face       string      // remote URI pattern, any face if empty
+	Scope      *defn.Scope // any scope if nil
+
+	facePattern *regexp.Regexp
+	fromConfig  bool
+	hits        atomic.Uint64
+}
+
+// filterFace is the part of a face examined by the filter.
+type filterFace interface {
+	RemoteURI() *defn.URI
+	Scope() defn.Scope
+}
+
+type filterTable struct {
+	mutex  sync.Mutex
+	rules  atomic.Pointer[[]*FilterRule] // copied on write, so that lookups take no lock
+	nextID uint64
+}
+
+// Filter contains the packet filter rules, evaluated in the forwarding pipelines.
+var Filter = &filterTable{nextID: 1}
+
+// filterExemptPrefix is the prefix of the management of the forwarder, which local faces
+// can always reach, so that a rule cannot lock out the command that would remove it.
+var filterExemptPrefix, _ = enc.NameFromStr("/localhost/nfd")
+
+// ParseFilterRule makes a filter rule from its textual representation, as in the configuration.
+func ParseFilterRule(action string, prefix string, direction string, packetType string, face string, scope string) (*FilterRule, error) {
+	rule := &FilterRule{Face: face}
+
+	switch strings.ToLower(action) {
+	case "allow":
+		rule.Deny = false
+	case "deny":
+		rule.Deny = true
+	default:
+		return nil, errors.New("unknown action: " + action)
+	}
+
+	var err error
+	if rule.Prefix, err = enc.NameFromStr(prefix); err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(direction) {
+	case "":
+		rule.Direction = FilterBoth
+	case "in":
+		rule.Direction = FilterIn
+	case "out":
+		rule.Direction = FilterOut
+	default:
+		return nil, errors.New("unknown direction: " + direction)
+	}
+
+	switch strings.ToLower(packetType) {
+	case "":
+		rule.PacketType = FilterAnyPacket
+	case "interest":
+		rule.PacketType = FilterInterest
+	case "data":
+		rule.PacketType = FilterData
+	default:
+		return nil, errors.New("unknown packet type: " + packetType)
+	}
+
+	if face != "" {
+		if rule.facePattern, err = compileFacePattern(face); err != nil {
+			return nil, errors.New("invalid face pattern: " + face)
+		}
+	}
+
+	switch strings.ToLower(scope) {
+	case "":
+		rule.Scope = nil
+	case "local":
+		rule.Scope = new(defn.Scope)
+		*rule.Scope = defn.Local
+	case "non-local":
+		rule.Scope = new(defn.Scope)
+		*rule.Scope = defn.NonLocal
+	default:
+		return nil, errors.New("unknown scope: " + scope)
+	}
+
+	return rule, nil
+}
+
+// compileFacePattern compiles a remote URI pattern, with the syntax of path.Match except that
+// * also matches /, so that e.g. unix://* matches unix:///run/nfd/nfd.sock.
+func compileFacePattern(pattern string) (*regexp.Regexp, error) {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		case '\\':
+			i++
+			if i == len(pattern) {
+				return nil, errors.New("trailing backslash")
+			}
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return nil, errors.New("unterminated character class")
+			}
+			expr.WriteString(pattern[i : i+end+2])
+			i += end + 1
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.Compile(expr.String())
+}
+
+// Hits returns the number of packets that matched the rule.
+func (r *FilterRule) Hits() uint64 {
+	return r.hits.Load()
+}
+
+// ActionString returns the action of the rule, as in the configuration.
+func (r *FilterRule) ActionString() string {
+	if r.Deny {
+		return "deny"
+	}
+	return "allow"
+}
+
+// DirectionString returns the direction of the rule, as in the configuration.
+func (r *FilterRule) DirectionString() string {
+	switch r.Direction {
+	case FilterIn:
+		return "in"
+	case FilterOut:
+		return "out"
+	default:
+		return ""
+	}
+}
+
+// PacketTypeString returns the packet type of the rule, as in the configuration.
+func (r *FilterRule) PacketTypeString() string {
+	switch r.PacketType {
+	case FilterInterest:
+		return "interest"
+	case FilterData:
+		return "data"
+	default:
+		return ""
+	}
+}
+
+// ScopeString returns the face scope of the rule, as in the configuration.
+func (r *FilterRule) ScopeString() string {
+	switch {
+	case r.Scope == nil:
+		return ""
+	case *r.Scope == defn.Local:
+		return "local"
+	default:
+		return "non-local"
+	}
+}
+
+func (r *FilterRule) matches(direction FilterDirection, packetType FilterPacketType, name enc.Name, face filterFace) bool {
+	if r.Direction != FilterBoth && r.Direction != direction {
+		return false
+	}
+	if r.PacketType != FilterAnyPacket && r.PacketType != packetType {
+		return false
+	}
+	if !r.Prefix.IsPrefix(name) {
+		return false
+	}
+	if r.Scope != nil && *r.Scope != face.Scope() {
+		return false
+	}
+	if r.facePattern != nil && !r.facePattern.MatchString(face.RemoteURI().String()) {
+		return false
+	}
+	return true
+}
+
+// Allows returns whether a packet sent or received on a face passes the filter.
+// The first matching rule applies, and packets matching no rule are allowed.
+// Management packets exchanged with local faces are always allowed.
+func (f *filterTable) Allows(direction FilterDirection, packetType FilterPacketType, name enc.Name, face filterFace) bool {
+	rules := f.rules.Load()
+	if rules == nil {
+		return true
+	}
+	if face.Scope() == defn.Local && filterExemptPrefix.IsPrefix(name) {
+		return true
+	}
+
+	for _, rule := range *rules {
+		if rule.matches(direction, packetType, name, face) {
+			rule.hits.Add(1)
+			return !rule.Deny
+		}
+	}
+	return true
+}
+
+// Rules returns the rules of the filter, in evaluation order.
+func (f *filterTable) Rules() []*FilterRule {
+	rules := f.rules.Load()
+	if rules == nil {
+		return nil
+	}
+	return *rules
+}
+
+// Add appends a rule to the filter and returns its ID.
+func (f *filterTable) Add(rule *FilterRule) uint64 {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	rule.ID = f.nextID
+	f.nextID++
+	f.update(append(f.Rules(), rule))
+	return rule.ID
+}
+
+// Remove removes a rule from the filter. It returns false if there is no such rule.
+func (f *filterTable) Remove(id uint64) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	rules := make([]*FilterRule, 0, len(f.Rules()))
+	for _, rule := range f.Rules() {
+		if rule.ID != id {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == len(f.Rules()) {
+		return false
+	}
+	f.update(rules)
+	return true
+}
+
+// update publishes a new set of rules. The mutex must be held.
+func (f *filterTable) update(rules []*FilterRule) {
+	if len(rules) == 0 {
+		f.rules.Store(nil)
+		return
+	}
+	rules = rules[:len(rules):len(rules)] // appending must copy
+	f.rules.Store(&rules)
+}
+
+// configureFilter replaces the rules from the configuration, before the rules added by management.
+func configureFilter() {
+	f := Filter
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	rules := make([]*FilterRule, 0)
+	for _, cfg := range core.GetConfig().Tables.Filter.Rules {
+		rule, err := ParseFilterRule(cfg.Action, cfg.Prefix, cfg.Direction, cfg.Type, cfg.Face, cfg.Scope)
+		if err != nil {
+			core.LogError("Filter", "Invalid filter rule for ", cfg.Prefix, ": ", err)
+			continue
+		}
+		rule.ID = f.nextID
+		rule.fromConfig = true
+		f.nextID++
+		rules = append(rules, rule)
+	}
+	for _, rule := range f.Rules() {
+		if !rule.fromConfig {
+			rules = append(rules, rule)
+		}
+	}
+	f.update(rules)
+}
@@ -0,0 +1,139 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/named-data/ndnd/fw/defn"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/stretchr/testify/assert"
+)
+
+type testFilterFace struct {
+	uri   *defn.URI
+	scope defn.Scope
+}
+
+func (f testFilterFace) RemoteURI() *defn.URI { return f.uri }
+func (f testFilterFace) Scope() defn.Scope    { return f.scope }
+
+func TestParseFilterRule(t *testing.T) {
+	rule, err := ParseFilterRule("deny", "/a/b", "in", "interest", "udp4://*", "non-local")
+	assert.NoError(t, err)
+	assert.True(t, rule.Deny)
+	assert.Equal(t, "/a/b", rule.Prefix.String())
+	assert.Equal(t, FilterIn, rule.Direction)
+	assert.Equal(t, FilterInterest, rule.PacketType)
+	assert.Equal(t, "non-local", rule.ScopeString())
+
+	rule, err = ParseFilterRule("allow", "/", "", "", "", "")
+	assert.NoError(t, err)
+	assert.False(t, rule.Deny)
+	assert.Equal(t, FilterBoth, rule.Direction)
+	assert.Nil(t, rule.Scope)
+
+	_, err = ParseFilterRule("drop", "/a", "", "", "", "")
+	assert.Error(t, err)
+	_, err = ParseFilterRule("deny", "/a", "sideways", "", "", "")
+	assert.Error(t, err)
+	_, err = ParseFilterRule("deny", "/a", "", "nack", "", "")
+	assert.Error(t, err)
+	_, err = ParseFilterRule("deny", "/a", "", "", "[", "")
+	assert.Error(t, err)
+	_, err = ParseFilterRule("deny", "/a", "", "", "", "remote")
+	assert.Error(t, err)
+}
+
+func TestFilterAllows(t *testing.T) {
+	f := &filterTable{nextID: 1}
+	udp := testFilterFace{uri: defn.MakeUDPFaceURI(4, "192.0.2.1", 6363), scope: defn.NonLocal}
+	unix := testFilterFace{uri: defn.MakeUnixFaceURI("/run/nfd/nfd.sock"), scope: defn.Local}
+	name := func(s string) enc.Name {
+		n, _ := enc.NameFromStr(s)
+		return n
+	}
+
+	// No rules
+	assert.True(t, f.Allows(FilterIn, FilterInterest, name("/a/b"), udp))
+
+	allow, _ := ParseFilterRule("allow", "/a/public", "", "", "", "")
+	deny, _ := ParseFilterRule("deny", "/a", "in", "interest", "udp4://*", "")
+	denyLocal, _ := ParseFilterRule("deny", "/b", "", "data", "", "local")
+	assert.Equal(t, uint64(1), f.Add(allow))
+	assert.Equal(t, uint64(2), f.Add(deny))
+	assert.Equal(t, uint64(3), f.Add(denyLocal))
+
+	assert.False(t, f.Allows(FilterIn, FilterInterest, name("/a/b"), udp))
+	assert.True(t, f.Allows(FilterIn, FilterInterest, name("/a/public/b"), udp))
+	assert.True(t, f.Allows(FilterOut, FilterInterest, name("/a/b"), udp))
+	assert.True(t, f.Allows(FilterIn, FilterData, name("/a/b"), udp))
+	assert.True(t, f.Allows(FilterIn, FilterInterest, name("/a/b"), unix))
+	assert.True(t, f.Allows(FilterIn, FilterInterest, name("/c"), udp))
+
+	assert.False(t, f.Allows(FilterOut, FilterData, name("/b/1"), unix))
+	assert.True(t, f.Allows(FilterOut, FilterData, name("/b/1"), udp))
+
+	assert.Equal(t, uint64(1), allow.Hits())
+	assert.Equal(t, uint64(1), deny.Hits())
+	assert.Equal(t, uint64(1), denyLocal.Hits())
+
+	// Removal
+	assert.True(t, f.Remove(2))
+	assert.False(t, f.Remove(2))
+	assert.Len(t, f.Rules(), 2)
+	assert.True(t, f.Allows(FilterIn, FilterInterest, name("/a/b"), udp))
+	assert.True(t, f.Remove(1))
+	assert.True(t, f.Remove(3))
+	assert.Nil(t, f.Rules())
+}
+
+func TestFilterFacePattern(t *testing.T) {
+	unix := testFilterFace{uri: defn.MakeUnixFaceURI("/run/nfd/nfd.sock"), scope: defn.Local}
+	udp := testFilterFace{uri: defn.MakeUDPFaceURI(4, "192.0.2.1", 6363), scope: defn.NonLocal}
+
+	tests := []struct {
+		pattern string
+		face    testFilterFace
+		match   bool
+	}{
+		{"unix://*", unix, true},
+		{"unix://*.sock", unix, true},
+		{"unix:///run/*/nfd.sock", unix, true},
+		{"unix://*", udp, false},
+		{"udp4://192.0.2.?:6363", udp, true},
+		{"udp4://192.0.2.[0-9]:*", udp, true},
+		{"udp4://192.0.2.[^1]:*", udp, false},
+		{"udp4://192.0.2.1", udp, false},
+		{`udp4://192\.0.2.1:6363`, udp, true},
+	}
+	for _, test := range tests {
+		rule, err := ParseFilterRule("deny", "/", "", "", test.pattern, "")
+		assert.NoError(t, err, test.pattern)
+		assert.Equal(t, test.match, rule.matches(FilterIn, FilterInterest, enc.Name{}, test.face), test.pattern)
+	}
+
+	_, err := ParseFilterRule("deny", "/", "", "", `udp4://\\`, "")
+	assert.NoError(t, err)
+	_, err = ParseFilterRule("deny", "/", "", "", `udp4://\`, "")
+	assert.Error(t, err)
+}
+
+func TestFilterManagementExempt(t *testing.T) {
+	f := &filterTable{nextID: 1}
+	unix := testFilterFace{uri: defn.MakeUnixFaceURI("/run/nfd/nfd.sock"), scope: defn.Local}
+	udp := testFilterFace{uri: defn.MakeUDPFaceURI(4, "192.0.2.1", 6363), scope: defn.NonLocal}
+	name := func(s string) enc.Name {
+		n, _ := enc.NameFromStr(s)
+		return n
+	}
+
+	denyAll, _ := ParseFilterRule("deny", "/", "", "", "", "")
+	f.Add(denyAll)
+
+	// The rule can still be removed by a local application
+	assert.True(t, f.Allows(FilterIn, FilterInterest, name("/localhost/nfd/filter/remove"), unix))
+	assert.True(t, f.Allows(FilterOut, FilterData, name("/localhost/nfd/filter/remove"), unix))
+	assert.False(t, f.Allows(FilterIn, FilterInterest, name("/localhost/nfd/filter/remove"), udp))
+	assert.False(t, f.Allows(FilterIn, FilterInterest, name("/localhost/other"), unix))
+	assert.False(t, f.Allows(FilterIn, FilterInterest, name("/a"), unix))
+	assert.Equal(t, uint64(3), denyAll.Hits())
+}
@@ -8,6 +8,7 @@
 package table
 
 import (
+	"sync/atomic"
 	"time"
 
 	"github.com/named-data/ndnd/fw/core"
@@ -21,13 +22,14 @@ var tableQueueSize int
 var deadNonceListLifetime time.Duration
 
 // csCapacity contains the default capacity of each forwarding thread's Content Store.
-var csCapacity int
+// The Content Store settings are changed by management while the forwarding threads read them.
+var csCapacity atomic.Int64
 
 // csAdmit determines whether contents will be admitted to the Content Store.
-var csAdmit bool
+var csAdmit atomic.Bool
 
 // csServe determines whether contents will be served from the Content Store.
-var csServe bool
+var csServe atomic.Bool
 
 // csReplacementPolicy contains the replacement policy used by Content Stores in the forwarder.
 var csReplacementPolicy string
@@ -43,10 +45,19 @@ var fibTableAlgorithm string
 func Configure() {
 	tableQueueSize = core.GetConfig().Tables.QueueSize
 
+	// Dead Nonce List
+	deadNonceListLifetime = time.Duration(core.GetConfig().Tables.DeadNonceList.Lifetime) * time.Millisecond
+
+	Reconfigure()
+}
+
+// Reconfigure applies the table settings that can be changed while the forwarder is running,
+// i.e. the Content Store, Network Region Table, packet filter and Data verification settings.
+func Reconfigure() {
 	// Content Store
-	csCapacity = int(core.GetConfig().Tables.ContentStore.Capacity)
-	csAdmit = core.GetConfig().Tables.ContentStore.Admit
-	csServe = core.GetConfig().Tables.ContentStore.Serve
+	csCapacity.Store(int64(core.GetConfig().Tables.ContentStore.Capacity))
+	csAdmit.Store(core.GetConfig().Tables.ContentStore.Admit)
+	csServe.Store(core.GetConfig().Tables.ContentStore.Serve)
 	csReplacementPolicyName := core.GetConfig().Tables.ContentStore.ReplacementPolicy
 	switch csReplacementPolicyName {
 	case "lru":
@@ -56,32 +67,57 @@ func Configure() {
 		csReplacementPolicy = "lru"
 	}
 
-	// Dead Nonce List
-	deadNonceListLifetime = time.Duration(core.GetConfig().Tables.DeadNonceList.Lifetime) * time.Millisecond
-
 	// Network Region Table
 	producerRegions = core.GetConfig().Tables.NetworkRegion.Regions
 	if producerRegions == nil {
 		producerRegions = make([]string, 0)
 	}
+	regions := new(networkRegionTable)
 	for _, region := range producerRegions {
 		name, err := enc.NameFromStr(region)
 		if err != nil {
 			core.LogFatal("NetworkRegionTable", "Could not add name=", region, " to table: ", err)
 		}
-		NetworkRegion.Add(name)
+		regions.Add(name)
 		core.LogDebug("NetworkRegionTable", "Added name=", region, " to table")
 	}
+	networkRegion.Store(regions)
+
+	// Packet filter
+	configureFilter()
+
+	// Data verification
+	configureVerifier()
 }
 
 // SetCsCapacity sets the CS capacity from management.
 func SetCsCapacity(capacity int) {
-	csCapacity = capacity
+	csCapacity.Store(int64(capacity))
 }
 
 // CsCapacity returns the CS capacity
 func CsCapacity() int {
-	return csCapacity
+	return int(csCapacity.Load())
+}
+
+// SetCsAdmit sets whether contents are admitted to the CS from management.
+func SetCsAdmit(admit bool) {
+	csAdmit.Store(admit)
+}
+
+// CsAdmit returns whether contents are admitted to the CS.
+func CsAdmit() bool {
+	return csAdmit.Load()
+}
+
+// SetCsServe sets whether contents are served from the CS from management.
+func SetCsServe(serve bool) {
+	csServe.Store(serve)
+}
+
+// CsServe returns whether contents are served from the CS.
+func CsServe() bool {
+	return csServe.Load()
 }
 
 func CreateFIBTable(fibTableAlgorithm string) {
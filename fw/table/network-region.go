@@ -8,6 +8,8 @@
 package table
 
 import (
+	"sync/atomic"
+
 	enc "github.com/named-data/ndnd/std/encoding"
 )
 
@@ -15,11 +17,17 @@ type networkRegionTable struct {
 	table []enc.Name
 }
 
-// NetworkRegion contains producer region names for this forwarder..
-var NetworkRegion *networkRegionTable
+// networkRegion contains producer region names for this forwarder.
+// It is replaced on reload, so that forwarding threads take no lock.
+var networkRegion atomic.Pointer[networkRegionTable]
 
 func init() {
-	NetworkRegion = new(networkRegionTable)
+	networkRegion.Store(new(networkRegionTable))
+}
+
+// NetworkRegion returns the current network region table.
+func NetworkRegion() *networkRegionTable {
+	return networkRegion.Load()
 }
 
 // Add adds a name to the network region table.
@@ -55,6 +55,9 @@ type pitCsTreeNode struct {
 	pitEntries []*nameTreePitEntry
 
 	csEntry *nameTreeCsEntry
+	// csFreshest is the CS entry with the latest stale time in the subtree of the node,
+	// so that prefix matches in the CS do not walk the subtree.
+	csFreshest *nameTreeCsEntry
 }
 
 // NewPitCS creates a new combined PIT-CS for a forwarding thread.
@@ -80,7 +83,7 @@ func NewPitCS(onExpiration OnPitExpiration) *PitCsTree {
 	pitCs.csMap = make(map[uint64]*nameTreeCsEntry)
 
 	// Schedule first signal
-	time.AfterFunc(expiredPitTickerInterval, func() {
+	core.AfterFunc(expiredPitTickerInterval, func() {
 		pitCs.updateTimer <- struct{}{}
 	})
 
@@ -92,16 +95,16 @@ func (p *PitCsTree) UpdateTimer() <-chan struct{} {
 }
 
 func (p *PitCsTree) Update() {
-	for p.pitExpiryQueue.Len() > 0 && p.pitExpiryQueue.PeekPriority() <= time.Now().UnixNano() {
+	for p.pitExpiryQueue.Len() > 0 && p.pitExpiryQueue.PeekPriority() <= core.Now().UnixNano() {
 		entry := p.pitExpiryQueue.Pop()
 		entry.pqItem = nil
 		p.onExpiration(entry)
 		p.RemoveInterest(entry)
 	}
-	if !core.ShouldQuit {
+	if !core.ShouldQuit.Load() {
 		updateDuration := expiredPitTickerInterval
 		if p.pitExpiryQueue.Len() > 0 {
-			sleepTime := time.Duration(p.pitExpiryQueue.PeekPriority()-time.Now().UnixNano()) * time.Nanosecond
+			sleepTime := time.Duration(p.pitExpiryQueue.PeekPriority()-core.Now().UnixNano()) * time.Nanosecond
 			if sleepTime > 0 {
 				if sleepTime > expiredPitTickerInterval {
 					sleepTime = expiredPitTickerInterval
@@ -110,7 +113,7 @@ func (p *PitCsTree) Update() {
 			}
 		}
 		// Schedule next signal
-		time.AfterFunc(updateDuration, func() {
+		core.AfterFunc(updateDuration, func() {
 			p.updateTimer <- struct{}{}
 		})
 	}
@@ -156,6 +159,7 @@ func (p *PitCsTree) InsertInterest(interest *spec.Interest, hint enc.Name, inFac
 		entry.forwardingHintNew = hint
 		entry.inRecords = make(map[uint64]*PitInRecord)
 		entry.outRecords = make(map[uint64]*PitOutRecord)
+		entry.creationTime = core.Now()
 		entry.satisfied = false
 		node.pitEntries = append(node.pitEntries, entry)
 		entry.token = p.generateNewPitToken()
@@ -257,12 +261,12 @@ func (p *PitCsTree) CsSize() int {
 
 // IsCsAdmitting returns whether the CS is admitting content.
 func (p *PitCsTree) IsCsAdmitting() bool {
-	return csAdmit
+	return csAdmit.Load()
 }
 
 // IsCsServing returns whether the CS is serving content.
 func (p *PitCsTree) IsCsServing() bool {
-	return csServe
+	return csServe.Load()
 }
 
 // InsertOutRecord inserts an outrecord for the given interest, updating the
@@ -279,18 +283,18 @@ func (e *nameTreePitEntry) InsertOutRecord(interest *spec.Interest, face uint64)
 		record := new(PitOutRecord)
 		record.Face = face
 		record.LatestNonce = *interest.NonceV
-		record.LatestTimestamp = time.Now()
+		record.LatestTimestamp = core.Now()
 		record.LatestInterest = interest.NameV.Clone()
-		record.ExpirationTime = time.Now().Add(lifetime)
+		record.ExpirationTime = core.Now().Add(lifetime)
 		e.outRecords[face] = record
 		return record
 	}
 
 	// Existing record
 	record.LatestNonce = *interest.NonceV
-	record.LatestTimestamp = time.Now()
+	record.LatestTimestamp = core.Now()
 	record.LatestInterest = interest.NameV.Clone()
-	record.ExpirationTime = time.Now().Add(lifetime)
+	record.ExpirationTime = core.Now().Add(lifetime)
 	return record
 }
 
@@ -378,7 +382,7 @@ func (p *PitCsTree) FindMatchingDataFromCS(interest *spec.Interest) CsEntry {
 	if node != nil {
 		if !interest.CanBePrefixV {
 			if node.csEntry != nil &&
-				(!interest.MustBeFreshV || time.Now().Before(node.csEntry.staleTime)) {
+				(!interest.MustBeFreshV || core.Now().Before(node.csEntry.staleTime)) {
 				p.csReplacement.BeforeUse(node.csEntry.index, node.csEntry.wire)
 				return node.csEntry
 			}
@@ -392,9 +396,10 @@ func (p *PitCsTree) FindMatchingDataFromCS(interest *spec.Interest) CsEntry {
 }
 
 // InsertData inserts a Data packet into the Content Store.
-func (p *PitCsTree) InsertData(data *spec.Data, wire []byte) {
-	index := data.NameV.Hash()
-	staleTime := time.Now()
+// The hash of the name, computed when the packet was dispatched, indexes the entry.
+func (p *PitCsTree) InsertData(data *spec.Data, nameHash uint64, wire []byte) {
+	index := nameHash
+	staleTime := core.Now()
 	if data.MetaInfo != nil && data.MetaInfo.FreshnessPeriod != nil {
 		staleTime = staleTime.Add(*data.MetaInfo.FreshnessPeriod)
 	}
@@ -404,8 +409,10 @@ func (p *PitCsTree) InsertData(data *spec.Data, wire []byte) {
 
 	if entry, ok := p.csMap[index]; ok {
 		// Replace existing entry
+		shrunk := staleTime.Before(entry.staleTime)
 		entry.wire = store
 		entry.staleTime = staleTime
+		entry.node.updateCsIndex(entry, shrunk)
 
 		p.csReplacement.AfterRefresh(index, wire, data)
 	} else {
@@ -421,6 +428,8 @@ func (p *PitCsTree) InsertData(data *spec.Data, wire []byte) {
 			},
 		}
 
+		node.updateCsIndex(node.csEntry, false)
+
 		p.csMap[index] = node.csEntry
 		p.csReplacement.AfterInsert(index, wire, data)
 
@@ -434,33 +443,83 @@ func (p *PitCsTree) InsertData(data *spec.Data, wire []byte) {
 func (p *PitCsTree) eraseCsDataFromReplacementStrategy(index uint64) {
 	if entry, ok := p.csMap[index]; ok {
 		entry.node.csEntry = nil
+		entry.node.updateCsIndex(entry, true)
 		delete(p.csMap, index)
 		p.nCsEntries--
 	}
 }
 
-// Given a pitCsTreeNode that is the longest prefix match of an interest, look for any
-// CS data rechable from this pitCsTreeNode. This function must be called only after
-// the interest as far as possible with the nodes components in the PitCSTree.
-// For example, if we have data for /a/b/v=10 and the interest is /a/b,
-// p should be the `b` node, not the root node.
+// EraseCsDataByPrefix erases up to limit entries under a prefix from the Content Store
+// through management, and returns the number of erased entries.
+func (p *PitCsTree) EraseCsDataByPrefix(prefix enc.Name, limit int) int {
+	node := p.root.findExactMatchEntryEnc(prefix)
+	if node == nil {
+		return 0
+	}
+
+	// Collect the entries first, since erasing them may prune the tree
+	entries := make([]*nameTreeCsEntry, 0)
+	node.collectCsEntries(&entries, limit)
+	for _, entry := range entries {
+		p.csReplacement.BeforeErase(entry.index, entry.wire)
+		p.eraseCsDataFromReplacementStrategy(entry.index)
+		entry.node.pruneIfEmpty()
+	}
+	return len(entries)
+}
+
+// collectCsEntries appends the CS entries in the subtree of the node, up to limit in total.
+func (p *pitCsTreeNode) collectCsEntries(entries *[]*nameTreeCsEntry, limit int) {
+	if p.csFreshest == nil || len(*entries) >= limit {
+		// No CS entry in the subtree
+		return
+	}
+	if p.csEntry != nil {
+		*entries = append(*entries, p.csEntry)
+	}
+	for _, child := range p.children {
+		child.collectCsEntries(entries, limit)
+	}
+}
+
+// findMatchingDataCSPrefix returns the freshest CS entry under the node that matches an
+// interest, if any. It must be called on the node of the interest name, e.g., for an
+// interest /a/b with data /a/b/v=10, p should be the `b` node, not the root node.
 func (p *pitCsTreeNode) findMatchingDataCSPrefix(interest *spec.Interest) CsEntry {
-	if p.csEntry != nil && (!interest.MustBeFreshV || time.Now().Before(p.csEntry.staleTime)) {
-		// A csEntry exists at this node and is acceptable to satisfy the interest
-		return p.csEntry
-	}
-
-	// No csEntry at current node, look farther down the tree
-	// We must have already matched the entire interest name
-	if p.depth >= len(interest.NameV) {
-		for _, child := range p.children {
-			potentialMatch := child.findMatchingDataCSPrefix(interest)
-			if potentialMatch != nil {
-				return potentialMatch
-			}
+	entry := p.csFreshest
+	if entry == nil || (interest.MustBeFreshV && !core.Now().Before(entry.staleTime)) {
+		// If the freshest entry is stale, all entries under the node are stale
+		return nil
+	}
+	return entry
+}
+
+// updateCsIndex updates the freshest CS entry of the node and its ancestors after
+// an entry of the node was inserted, refreshed or removed. shrunk is set if the entry
+// was removed or its stale time decreased.
+func (p *pitCsTreeNode) updateCsIndex(entry *nameTreeCsEntry, shrunk bool) {
+	removed := entry.node.csEntry != entry
+	for node := p; node != nil; node = node.parent {
+		switch {
+		case node.csFreshest == entry && shrunk:
+			node.csFreshest = node.findFreshestCsEntry()
+		case node.csFreshest == entry:
+			// Still the freshest here, but maybe not yet above
+		case !removed && (node.csFreshest == nil || entry.staleTime.After(node.csFreshest.staleTime)):
+			node.csFreshest = entry
+		default:
+			return // the ancestors are not affected
 		}
 	}
+}
 
-	// If found none, then return
-	return nil
+// findFreshestCsEntry finds the freshest CS entry under the node from the index of its children.
+func (p *pitCsTreeNode) findFreshestCsEntry() *nameTreeCsEntry {
+	freshest := p.csEntry
+	for _, child := range p.children {
+		if child.csFreshest != nil && (freshest == nil || child.csFreshest.staleTime.After(freshest.staleTime)) {
+			freshest = child.csFreshest
+		}
+	}
+	return freshest
 }
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"math/rand"
 	"sort"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/named-data/ndnd/fw/core"
 	enc "github.com/named-data/ndnd/std/encoding"
 	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
 	"github.com/named-data/ndnd/std/utils"
@@ -84,27 +86,27 @@ func TestNewPitCSTree(t *testing.T) {
 }
 
 func TestIsCsAdmitting(t *testing.T) {
-	csAdmit = false
+	csAdmit.Store(false)
 	csReplacementPolicy = "lru"
 
 	pitCS := NewPitCS(func(PitEntry) {})
-	assert.Equal(t, pitCS.IsCsAdmitting(), csAdmit)
+	assert.Equal(t, pitCS.IsCsAdmitting(), csAdmit.Load())
 
-	csAdmit = true
+	csAdmit.Store(true)
 	pitCS = NewPitCS(func(PitEntry) {})
-	assert.Equal(t, pitCS.IsCsAdmitting(), csAdmit)
+	assert.Equal(t, pitCS.IsCsAdmitting(), csAdmit.Load())
 }
 
 func TestIsCsServing(t *testing.T) {
-	csServe = false
+	csServe.Store(false)
 	csReplacementPolicy = "lru"
 
 	pitCS := NewPitCS(func(PitEntry) {})
-	assert.Equal(t, pitCS.IsCsServing(), csServe)
+	assert.Equal(t, pitCS.IsCsServing(), csServe.Load())
 
-	csServe = true
+	csServe.Store(true)
 	pitCS = NewPitCS(func(PitEntry) {})
-	assert.Equal(t, pitCS.IsCsServing(), csServe)
+	assert.Equal(t, pitCS.IsCsServing(), csServe.Load())
 }
 
 func TestInsertInterest(t *testing.T) {
@@ -477,7 +479,7 @@ func TestGetOutRecords(t *testing.T) {
 
 func FindMatchingDataFromCS(t *testing.T) {
 	csReplacementPolicy = "lru"
-	csCapacity = 1024
+	csCapacity.Store(1024)
 	pitCS := NewPitCS(func(PitEntry) {})
 
 	// Data does not already exist
@@ -488,7 +490,7 @@ func FindMatchingDataFromCS(t *testing.T) {
 	pkt, _, _ := spec.ReadPacket(enc.NewBufferReader(VALID_DATA_1))
 	data1 := pkt.Data
 
-	pitCS.InsertData(data1, VALID_DATA_1)
+	insertData(pitCS, data1, VALID_DATA_1)
 	csEntry1 := pitCS.FindMatchingDataFromCS(interest1)
 	_, csWire, _ := csEntry1.Copy()
 	assert.Equal(t, pitCS.CsSize(), 1)
@@ -496,7 +498,7 @@ func FindMatchingDataFromCS(t *testing.T) {
 
 	// Insert data associated with same name, so we should just update it
 	// Should not result in a new CsEntry
-	pitCS.InsertData(data1, VALID_DATA_1)
+	insertData(pitCS, data1, VALID_DATA_1)
 	csEntry1 = pitCS.FindMatchingDataFromCS(interest1)
 	_, csWire, _ = csEntry1.Copy()
 	assert.Equal(t, pitCS.CsSize(), 1)
@@ -510,7 +512,7 @@ func FindMatchingDataFromCS(t *testing.T) {
 	pkt, _, _ = spec.ReadPacket(enc.NewBufferReader(VALID_DATA_2))
 	data2 := pkt.Data
 
-	pitCS.InsertData(data2, VALID_DATA_2)
+	insertData(pitCS, data2, VALID_DATA_2)
 
 	csEntry2 := pitCS.FindMatchingDataFromCS(interest2)
 	_, csWire, _ = csEntry2.Copy()
@@ -527,9 +529,239 @@ func FindMatchingDataFromCS(t *testing.T) {
 	assert.True(t, bytes.Equal(csWire, VALID_DATA_1))
 
 	// Reduced CS capacity to check that eviction occurs
-	csCapacity = 1
+	csCapacity.Store(1)
 	pitCS = NewPitCS(func(PitEntry) {})
-	pitCS.InsertData(data1, VALID_DATA_1)
-	pitCS.InsertData(data2, VALID_DATA_2)
+	insertData(pitCS, data1, VALID_DATA_1)
+	insertData(pitCS, data2, VALID_DATA_2)
 	assert.Equal(t, pitCS.CsSize(), 1)
 }
+
+func TestPitExpiry(t *testing.T) {
+	clock := core.NewManualClock(time.Unix(1000, 0))
+	prevClock := core.SetClock(clock)
+	defer core.SetClock(prevClock)
+
+	csReplacementPolicy = "lru"
+	expired := make([]PitEntry, 0)
+	pitCS := NewPitCS(func(entry PitEntry) { expired = append(expired, entry) })
+	name, _ := enc.NameFromStr("/interest1")
+	interest := makeInterest(name)
+	interest.InterestLifetimeV = utils.IdPtr(time.Second)
+
+	pitEntry, _ := pitCS.InsertInterest(interest, nil, 1111)
+	pitEntry.InsertInRecord(interest, 1111, nil)
+	UpdateExpirationTimer(pitEntry)
+	assert.Equal(t, time.Unix(1001, 0), pitEntry.ExpirationTime())
+
+	// Not expired yet
+	clock.Advance(500 * time.Millisecond)
+	<-pitCS.UpdateTimer()
+	pitCS.Update()
+	assert.Equal(t, 0, len(expired))
+	assert.Equal(t, 1, pitCS.PitSize())
+
+	// Expired once the lifetime elapsed
+	clock.Advance(600 * time.Millisecond)
+	<-pitCS.UpdateTimer()
+	pitCS.Update()
+	assert.Equal(t, []PitEntry{pitEntry}, expired)
+	assert.Equal(t, 0, pitCS.PitSize())
+}
+
+func makeFreshData(name string, freshness time.Duration) *spec.Data {
+	n, _ := enc.NameFromStr(name)
+	data := makeData(n, enc.Wire{})
+	data.MetaInfo = &spec.MetaInfo{FreshnessPeriod: utils.IdPtr(freshness)}
+	return data
+}
+
+func makePrefixInterest(name string, mustBeFresh bool) *spec.Interest {
+	n, _ := enc.NameFromStr(name)
+	interest := makeInterest(n)
+	interest.CanBePrefixV = true
+	interest.MustBeFreshV = mustBeFresh
+	return interest
+}
+
+func csEntryName(entry CsEntry) string {
+	if entry == nil {
+		return ""
+	}
+	return entry.(*nameTreeCsEntry).node.nameForTest().String()
+}
+
+func (p *pitCsTreeNode) nameForTest() enc.Name {
+	name := make(enc.Name, p.depth)
+	for node := p; node.component != nil; node = node.parent {
+		name[node.depth-1] = *node.component
+	}
+	return name
+}
+
+func TestCsPrefixMatch(t *testing.T) {
+	clock := core.NewManualClock(time.Unix(1000, 0))
+	prevClock := core.SetClock(clock)
+	defer core.SetClock(prevClock)
+
+	csReplacementPolicy = "lru"
+	csCapacity.Store(1024)
+	pitCS := NewPitCS(func(PitEntry) {})
+
+	insertData(pitCS, makeFreshData("/a/b/1", time.Second), VALID_DATA_1)
+	insertData(pitCS, makeFreshData("/a/b/2", 10*time.Second), VALID_DATA_1)
+	insertData(pitCS, makeFreshData("/a/c", 0), VALID_DATA_1)
+
+	// The freshest Data under the prefix is returned
+	assert.Equal(t, "/a/b/2", csEntryName(pitCS.FindMatchingDataFromCS(makePrefixInterest("/a", true))))
+	assert.Equal(t, "/a/b/2", csEntryName(pitCS.FindMatchingDataFromCS(makePrefixInterest("/a", false))))
+	assert.Equal(t, "/a/c", csEntryName(pitCS.FindMatchingDataFromCS(makePrefixInterest("/a/c", false))))
+	assert.Nil(t, pitCS.FindMatchingDataFromCS(makePrefixInterest("/a/c", true)))
+	assert.Nil(t, pitCS.FindMatchingDataFromCS(makePrefixInterest("/a/d", false)))
+
+	// Stale Data only satisfies Interests without MustBeFresh
+	clock.Advance(11 * time.Second)
+	assert.Nil(t, pitCS.FindMatchingDataFromCS(makePrefixInterest("/a", true)))
+	assert.Equal(t, "/a/b/2", csEntryName(pitCS.FindMatchingDataFromCS(makePrefixInterest("/a", false))))
+
+	// Refreshed Data becomes the freshest
+	insertData(pitCS, makeFreshData("/a/b/1", time.Minute), VALID_DATA_1)
+	assert.Equal(t, "/a/b/1", csEntryName(pitCS.FindMatchingDataFromCS(makePrefixInterest("/a", true))))
+
+	// The index follows a decreasing freshness and evictions
+	insertData(pitCS, makeFreshData("/a/b/1", 0), VALID_DATA_1)
+	assert.Nil(t, pitCS.FindMatchingDataFromCS(makePrefixInterest("/a", true)))
+	assert.Equal(t, "/a/b/1", csEntryName(pitCS.FindMatchingDataFromCS(makePrefixInterest("/a", false))))
+	name, _ := enc.NameFromStr("/a/b/1")
+	pitCS.eraseCsDataFromReplacementStrategy(name.Hash())
+	assert.Equal(t, "/a/b/2", csEntryName(pitCS.FindMatchingDataFromCS(makePrefixInterest("/a", false))))
+	name, _ = enc.NameFromStr("/a/b/2")
+	pitCS.eraseCsDataFromReplacementStrategy(name.Hash())
+	assert.Equal(t, "/a/c", csEntryName(pitCS.FindMatchingDataFromCS(makePrefixInterest("/a", false))))
+	assert.Nil(t, pitCS.FindMatchingDataFromCS(makePrefixInterest("/a/b", false)))
+}
+
+func TestCsEraseByPrefix(t *testing.T) {
+	csReplacementPolicy = "lru"
+	csCapacity.Store(1024)
+	pitCS := NewPitCS(func(PitEntry) {})
+
+	for _, name := range []string{"/a", "/a/b/1", "/a/b/2", "/a/b/3", "/a/c", "/d"} {
+		insertData(pitCS, makeFreshData(name, time.Minute), VALID_DATA_1)
+	}
+
+	// Nothing is erased under a prefix without entries
+	prefix, _ := enc.NameFromStr("/e")
+	assert.Equal(t, 0, pitCS.EraseCsDataByPrefix(prefix, 10))
+	assert.Equal(t, 6, pitCS.CsSize())
+
+	// At most limit entries are erased
+	prefix, _ = enc.NameFromStr("/a/b")
+	assert.Equal(t, 2, pitCS.EraseCsDataByPrefix(prefix, 2))
+	assert.Equal(t, 4, pitCS.CsSize())
+	assert.Equal(t, 1, pitCS.EraseCsDataByPrefix(prefix, 2))
+	assert.Equal(t, 3, pitCS.CsSize())
+	assert.Nil(t, pitCS.FindMatchingDataFromCS(makePrefixInterest("/a/b", false)))
+	assert.Nil(t, pitCS.root.findExactMatchEntryEnc(prefix))
+
+	// The entry of the prefix itself is erased, but not its siblings
+	prefix, _ = enc.NameFromStr("/a")
+	assert.Equal(t, 2, pitCS.EraseCsDataByPrefix(prefix, 10))
+	assert.Equal(t, 1, pitCS.CsSize())
+	assert.Equal(t, "/d", csEntryName(pitCS.FindMatchingDataFromCS(makePrefixInterest("/", false))))
+	checkCsIndex(t, pitCS.root)
+
+	// Erased entries are no longer evicted by the replacement policy
+	insertData(pitCS, makeFreshData("/f", time.Minute), VALID_DATA_1)
+	csCapacity.Store(1)
+	insertData(pitCS, makeFreshData("/g", time.Minute), VALID_DATA_1)
+	assert.Equal(t, 1, pitCS.CsSize())
+	assert.Equal(t, "/g", csEntryName(pitCS.FindMatchingDataFromCS(makePrefixInterest("/", false))))
+}
+
+// checkCsIndex verifies that the index of every node holds the freshest entry of its subtree.
+func checkCsIndex(t *testing.T, node *pitCsTreeNode) *nameTreeCsEntry {
+	freshest := node.csEntry
+	for _, child := range node.children {
+		if entry := checkCsIndex(t, child); entry != nil && (freshest == nil || entry.staleTime.After(freshest.staleTime)) {
+			freshest = entry
+		}
+	}
+	if freshest == nil {
+		assert.Nil(t, node.csFreshest)
+	} else if assert.NotNil(t, node.csFreshest) {
+		assert.Equal(t, freshest.staleTime, node.csFreshest.staleTime)
+	}
+	return freshest
+}
+
+func TestCsIndexConsistency(t *testing.T) {
+	clock := core.NewManualClock(time.Unix(1000, 0))
+	prevClock := core.SetClock(clock)
+	defer core.SetClock(prevClock)
+
+	csReplacementPolicy = "lru"
+	csCapacity.Store(50)
+	pitCS := NewPitCS(func(PitEntry) {})
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 2000; i++ {
+		name := "/" + strconv.Itoa(rng.Intn(3)) + "/" + strconv.Itoa(rng.Intn(5)) + "/" + strconv.Itoa(rng.Intn(10))
+		if rng.Intn(4) == 0 {
+			n, _ := enc.NameFromStr(name)
+			pitCS.eraseCsDataFromReplacementStrategy(n.Hash())
+		} else {
+			insertData(pitCS, makeFreshData(name, time.Duration(rng.Intn(100))*time.Second), VALID_DATA_1)
+		}
+		clock.Advance(time.Duration(rng.Intn(1000)) * time.Millisecond)
+		checkCsIndex(t, pitCS.root)
+	}
+}
+
+func benchmarkCsPrefixMatch(b *testing.B, children int, mustBeFresh bool) {
+	csReplacementPolicy = "lru"
+	csCapacity.Store(int64(children + 1))
+	pitCS := NewPitCS(func(PitEntry) {})
+
+	// All Data is stale, so that no child matches an Interest with MustBeFresh
+	for i := 0; i < children; i++ {
+		insertData(pitCS, makeFreshData("/bench/"+strconv.Itoa(i)+"/data", 0), VALID_DATA_1)
+	}
+	interest := makePrefixInterest("/bench", mustBeFresh)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pitCS.FindMatchingDataFromCS(interest)
+	}
+}
+
+func BenchmarkCsPrefixMatch(b *testing.B) {
+	for _, children := range []int{10, 1000, 100000} {
+		b.Run("children="+strconv.Itoa(children), func(b *testing.B) {
+			benchmarkCsPrefixMatch(b, children, false)
+		})
+		b.Run("children="+strconv.Itoa(children)+"/fresh", func(b *testing.B) {
+			benchmarkCsPrefixMatch(b, children, true)
+		})
+	}
+}
+
+func BenchmarkCsInsertData(b *testing.B) {
+	csReplacementPolicy = "lru"
+	csCapacity.Store(10000)
+	pitCS := NewPitCS(func(PitEntry) {})
+
+	data := make([]*spec.Data, 100000)
+	for i := range data {
+		data[i] = makeFreshData("/bench/"+strconv.Itoa(i%100)+"/"+strconv.Itoa(i), time.Duration(i%10)*time.Second)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		insertData(pitCS, data[i%len(data)], VALID_DATA_1)
+	}
+}
+
+// insertData inserts a Data packet into the Content Store like the forwarding thread.
+func insertData(pitCS PitCsTable, data *spec.Data, wire []byte) {
+	pitCS.InsertData(data, data.NameV.Hash(), wire)
+}
@@ -10,6 +10,7 @@ package table
 import (
 	"time"
 
+	"github.com/named-data/ndnd/fw/core"
 	enc "github.com/named-data/ndnd/std/encoding"
 	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
 )
@@ -23,8 +24,9 @@ type PitCsTable interface {
 	FindInterestPrefixMatchByDataEnc(data *spec.Data, token *uint32) []PitEntry
 	PitSize() int
 
-	InsertData(data *spec.Data, wire []byte)
+	InsertData(data *spec.Data, nameHash uint64, wire []byte)
 	FindMatchingDataFromCS(interest *spec.Interest) CsEntry
+	EraseCsDataByPrefix(prefix enc.Name, limit int) int
 	CsSize() int
 	IsCsAdmitting() bool
 	IsCsServing() bool
@@ -53,6 +55,7 @@ type PitEntry interface {
 	// Interests must match in terms of Forwarding Hint to be aggregated in PIT.
 	InRecords() map[uint64]*PitInRecord   // Key is face ID
 	OutRecords() map[uint64]*PitOutRecord // Key is face ID
+	CreationTime() time.Time
 	ExpirationTime() time.Time
 	SetExpirationTime(t time.Time)
 	Satisfied() bool
@@ -79,6 +82,7 @@ type basePitEntry struct {
 	// aggregated in PIT.
 	inRecords      map[uint64]*PitInRecord  // Key is face ID
 	outRecords     map[uint64]*PitOutRecord // Key is face ID
+	creationTime   time.Time
 	expirationTime time.Time
 	satisfied      bool
 
@@ -136,9 +140,9 @@ func (bpe *basePitEntry) InsertInRecord(
 		record := new(PitInRecord)
 		record.Face = face
 		record.LatestNonce = *interest.NonceV
-		record.LatestTimestamp = time.Now()
+		record.LatestTimestamp = core.Now()
 		record.LatestInterest = interest.NameV.Clone()
-		record.ExpirationTime = time.Now().Add(lifetime)
+		record.ExpirationTime = core.Now().Add(lifetime)
 		record.PitToken = append([]byte{}, incomingPitToken...)
 		bpe.inRecords[face] = record
 		return record, false, 0
@@ -147,22 +151,22 @@ func (bpe *basePitEntry) InsertInRecord(
 	// Existing record
 	previousNonce := record.LatestNonce
 	record.LatestNonce = *interest.NonceV
-	record.LatestTimestamp = time.Now()
+	record.LatestTimestamp = core.Now()
 	record.LatestInterest = interest.NameV.Clone()
-	record.ExpirationTime = time.Now().Add(lifetime)
+	record.ExpirationTime = core.Now().Add(lifetime)
 	return record, true, previousNonce
 }
 
 // SetExpirationTimerToNow updates the expiration timer to the current time.
 func SetExpirationTimerToNow(e PitEntry) {
-	e.SetExpirationTime(time.Now())
+	e.SetExpirationTime(core.Now())
 	e.PitCs().updatePitExpiry(e)
 }
 
 // UpdateExpirationTimer updates the expiration timer to the latest expiration
 // time of any in or out record in the entry.
 func UpdateExpirationTimer(e PitEntry) {
-	e.SetExpirationTime(time.Now())
+	e.SetExpirationTime(core.Now())
 
 	for _, record := range e.InRecords() {
 		if record.ExpirationTime.After(e.ExpirationTime()) {
@@ -213,6 +217,10 @@ func (bpe *basePitEntry) ClearOutRecords() {
 	bpe.outRecords = make(map[uint64]*PitOutRecord)
 }
 
+func (bpe *basePitEntry) CreationTime() time.Time {
+	return bpe.creationTime
+}
+
 func (bpe *basePitEntry) ExpirationTime() time.Time {
 	return bpe.expirationTime
 }
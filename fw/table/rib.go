@@ -9,14 +9,17 @@ package table
 
 import (
 	"container/list"
+	"sync/atomic"
 	"time"
 
 	enc "github.com/named-data/ndnd/std/encoding"
 )
 
 // RibTable represents the Routing Information Base (RIB).
+// It is only accessed by the management thread.
 type RibTable struct {
 	RibEntry
+	nEntries atomic.Int64 // entries with routes, may be read by other threads
 }
 
 // RibEntry represents an entry in the RIB table.
@@ -109,9 +112,8 @@ func (r *RibEntry) pruneIfEmpty() {
 	}
 }
 
-func (r *RibEntry) updateNexthopsEnc() {
-	FibStrategyTable.ClearNextHopsEnc(r.Name)
-
+// updateNexthopsEnc replaces the nexthops of the entry and its children in the FIB.
+func (r *RibEntry) updateNexthopsEnc(fib FibStrategyUpdate) {
 	// All routes including parents if needed
 	routes := append([]*Route{}, r.routes...)
 
@@ -136,18 +138,24 @@ func (r *RibEntry) updateNexthopsEnc() {
 		}
 	}
 
-	// Add "flattened" set of nexthops
+	// Replace with the "flattened" set of nexthops, so that forwarding
+	// never sees the entry without its nexthops
+	nexthops := make([]*FibNextHopEntry, 0, len(minCostRoutes))
 	for nexthop, cost := range minCostRoutes {
-		FibStrategyTable.InsertNextHopEnc(r.Name, nexthop, cost)
+		nexthops = append(nexthops, &FibNextHopEntry{Nexthop: nexthop, Cost: cost})
+	}
+	if r.Name != nil {
+		fib.SetNextHopsEnc(r.Name, nexthops)
 	}
 
 	// Trigger update for all children for inheritance
 	for child := range r.children {
-		child.updateNexthopsEnc()
+		child.updateNexthopsEnc(fib)
 	}
 }
 
 // AddRoute adds or updates a RIB entry for the specified prefix.
+// The FIB entries of the prefix and the prefixes under it are updated at once.
 func (r *RibTable) AddEncRoute(name enc.Name, route *Route) {
 	name = name.Clone()
 	node := r.fillTreeToPrefixEnc(name)
@@ -155,7 +163,7 @@ func (r *RibTable) AddEncRoute(name enc.Name, route *Route) {
 		node.Name = name
 	}
 
-	defer node.updateNexthopsEnc()
+	defer FibStrategyTable.Update(node.updateNexthopsEnc)
 
 	for _, existingRoute := range node.routes {
 		if existingRoute.FaceID == route.FaceID && existingRoute.Origin == route.Origin {
@@ -166,6 +174,9 @@ func (r *RibTable) AddEncRoute(name enc.Name, route *Route) {
 		}
 	}
 
+	if len(node.routes) == 0 {
+		r.nEntries.Add(1)
+	}
 	node.routes = append(node.routes, route)
 	readvertiseAnnounce(name, route)
 }
@@ -192,12 +203,19 @@ func (r *RibTable) GetAllEntries() []*RibEntry {
 	return entries
 }
 
+// Len returns the number of entries with routes in the RIB.
+// Unlike the other methods, it may be called from any goroutine.
+func (r *RibTable) Len() int {
+	return int(r.nEntries.Load())
+}
+
 // GetRoutes returns all routes in the RIB entry.
 func (r *RibEntry) GetRoutes() []*Route {
 	return r.routes
 }
 
 // RemoveRoute removes the specified route from the specified prefix.
+// The FIB entries of the prefix and the prefixes under it are updated at once.
 func (r *RibTable) RemoveRouteEnc(name enc.Name, faceID uint64, origin uint64) {
 	entry := r.findExactMatchEntryEnc(name)
 	if entry != nil {
@@ -207,24 +225,36 @@ func (r *RibTable) RemoveRouteEnc(name enc.Name, faceID uint64, origin uint64) {
 					copy(entry.routes[i:], entry.routes[i+1:])
 				}
 				entry.routes = entry.routes[:len(entry.routes)-1]
+				if len(entry.routes) == 0 {
+					r.nEntries.Add(-1)
+				}
 				readvertiseWithdraw(name, route)
 				break
 			}
 		}
-		entry.updateNexthopsEnc()
+		FibStrategyTable.Update(entry.updateNexthopsEnc)
 		entry.pruneIfEmpty()
 	}
 }
 
 // CleanUpFace removes the specified face from all entries. Used for clean-up after a face is destroyed.
-func (r *RibEntry) CleanUpFace(faceId uint64) {
+// The FIB entries of all the prefixes are updated at once.
+func (r *RibTable) CleanUpFace(faceId uint64) {
+	FibStrategyTable.Update(func(fib FibStrategyUpdate) {
+		r.nEntries.Add(-int64(r.RibEntry.cleanUpFace(fib, faceId)))
+	})
+}
+
+// cleanUpFace removes the specified face from the entry and its children.
+// It returns the number of entries left without routes.
+func (r *RibEntry) cleanUpFace(fib FibStrategyUpdate, faceId uint64) (emptied int) {
 	// Recursively clean children
 	for child := range r.children {
-		child.CleanUpFace(faceId)
+		emptied += child.cleanUpFace(fib, faceId)
 	}
 
 	if r.Name == nil {
-		return
+		return emptied
 	}
 
 	for i, route := range r.routes {
@@ -233,12 +263,16 @@ func (r *RibEntry) CleanUpFace(faceId uint64) {
 				copy(r.routes[i:], r.routes[i+1:])
 			}
 			r.routes = r.routes[:len(r.routes)-1]
+			if len(r.routes) == 0 {
+				emptied++
+			}
 			readvertiseWithdraw(r.Name, route)
 			break
 		}
 	}
-	r.updateNexthopsEnc()
+	r.updateNexthopsEnc(fib)
 	r.pruneIfEmpty()
+	return emptied
 }
 
 func (r *RibEntry) HasCaptureRoute() bool {
@@ -16,6 +16,16 @@ func AddReadvertiser(r RibReadvertise) {
 	readvertisers = append(readvertisers, r)
 }
 
+// WithdrawAllReadvertised withdraws all routes in the RIB from the readvertisers,
+// e.g., before the forwarder shuts down.
+func WithdrawAllReadvertised() {
+	for _, entry := range Rib.GetAllEntries() {
+		for _, route := range entry.GetRoutes() {
+			readvertiseWithdraw(entry.Name, route)
+		}
+	}
+}
+
 func readvertiseAnnounce(name enc.Name, route *Route) {
 	for _, r := range readvertisers {
 		r.Announce(name, route)
@@ -0,0 +1,97 @@
+package table
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRibLen(t *testing.T) {
+	newFibStrategyTableTree()
+	a, _ := enc.NameFromStr("/rib/a")
+	ab, _ := enc.NameFromStr("/rib/a/b")
+	c, _ := enc.NameFromStr("/rib/c")
+	initial := Rib.Len()
+
+	// Entries are counted once, whatever their number of routes
+	Rib.AddEncRoute(a, &Route{FaceID: 1, Origin: RouteOriginStatic})
+	Rib.AddEncRoute(a, &Route{FaceID: 2, Origin: RouteOriginStatic})
+	Rib.AddEncRoute(a, &Route{FaceID: 2, Origin: RouteOriginStatic, Cost: 10})
+	Rib.AddEncRoute(ab, &Route{FaceID: 1, Origin: RouteOriginStatic})
+	Rib.AddEncRoute(c, &Route{FaceID: 3, Origin: RouteOriginStatic})
+	assert.Equal(t, initial+3, Rib.Len())
+	assert.Equal(t, len(Rib.GetAllEntries()), Rib.Len())
+
+	// Removing a missing route changes nothing
+	Rib.RemoveRouteEnc(c, 1, RouteOriginStatic)
+	assert.Equal(t, initial+3, Rib.Len())
+	Rib.RemoveRouteEnc(c, 3, RouteOriginStatic)
+	assert.Equal(t, initial+2, Rib.Len())
+
+	// Entries are left without routes when their face is destroyed
+	Rib.CleanUpFace(1)
+	assert.Equal(t, initial+1, Rib.Len())
+	Rib.CleanUpFace(2)
+	assert.Equal(t, initial, Rib.Len())
+	assert.Equal(t, len(Rib.GetAllEntries()), Rib.Len())
+}
+
+func TestRibUpdatesAtomic(t *testing.T) {
+	const nChildren = 100
+	const nUpdates = 200
+
+	for algo, create := range fibAlgorithms {
+		t.Run(algo, func(t *testing.T) {
+			create()
+			parent, _ := enc.NameFromStr("/atomic/" + algo)
+			for i := 0; i < nChildren; i++ {
+				child := append(parent.Clone(), enc.NewStringComponent(enc.TypeGenericNameComponent, strconv.Itoa(i)))
+				Rib.AddEncRoute(child, &Route{FaceID: 10, Origin: RouteOriginStatic})
+			}
+
+			// The inherited route is added to or removed from all children at once
+			var done atomic.Bool
+			var errors atomic.Int64
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for !done.Load() {
+					inherited := 0
+					for _, entry := range FibStrategyTable.GetAllFIBEntries() {
+						if len(entry.Name()) != len(parent)+1 || !parent.IsPrefix(entry.Name()) {
+							continue
+						}
+						for _, nexthop := range entry.GetNextHops() {
+							if nexthop.Nexthop == 20 {
+								inherited++
+							}
+						}
+					}
+					if inherited != 0 && inherited != nChildren {
+						errors.Add(1)
+					}
+				}
+			}()
+
+			for i := 0; i < nUpdates; i++ {
+				Rib.AddEncRoute(parent, &Route{FaceID: 20, Origin: RouteOriginStatic, Flags: RouteFlagChildInherit})
+				if i%2 == 0 {
+					Rib.RemoveRouteEnc(parent, 20, RouteOriginStatic)
+				} else {
+					Rib.CleanUpFace(20)
+				}
+			}
+			done.Store(true)
+			wg.Wait()
+			assert.Equal(t, int64(0), errors.Load())
+
+			Rib.CleanUpFace(10)
+			assert.Empty(t, FibStrategyTable.GetAllFIBEntries())
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package table
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	sec "github.com/named-data/ndnd/std/security"
+)
+
+// VerifyResult is the outcome of the verification of a Data packet.
+type VerifyResult int
+
+const (
+	// VerifyNotRequired means that no rule covers the Data.
+	VerifyNotRequired VerifyResult = iota
+	// VerifyValid means that the Data is signed by a trusted key allowed by its rule.
+	VerifyValid
+	// VerifyUnknownKey means that the Data is signed by a key that is not trusted yet.
+	VerifyUnknownKey
+	// VerifyInvalid means that the signature is wrong, or made by a key not allowed by the rule.
+	VerifyInvalid
+)
+
+// VerifyRule requires the Data under a prefix to be signed by a trusted key under a key prefix.
+type VerifyRule struct {
+	Prefix    enc.Name
+	KeyPrefix enc.Name
+}
+
+// trustedKey is the public key of a trust anchor, or of a certificate verified by a trusted key.
+type trustedKey struct {
+	key      any // as returned by x509.ParsePKIXPublicKey
+	notAfter *time.Time
+}
+
+type verifier struct {
+	rules atomic.Pointer[[]*VerifyRule] // replaced on reload, so that lookups take no lock
+
+	mutex    sync.RWMutex
+	keys     map[string]*trustedKey // trust anchors are never evicted
+	learned  []string               // names of the keys learned from certificates, oldest first
+	capacity int
+
+	nValid      atomic.Uint64
+	nInvalid    atomic.Uint64
+	nUnknownKey atomic.Uint64
+}
+
+// Verifier checks the signature of Data before it is admitted to the Content Store.
+// The keys of the certificates it verifies are kept, so that a certificate chain is
+// only verified once.
+var Verifier = &verifier{keys: make(map[string]*trustedKey)}
+
+// Verify checks the signature of a Data packet, whose signed portion is sigCovered.
+// The Data must be signed by a key under the key prefix of the rule with the longest
+// prefix of its name; the rules of shorter prefixes do not apply. Certificates whose key
+// is under the key prefix of a rule are verified too, by the rule with the longest key
+// prefix if no rule covers their name, and their key is trusted afterwards.
+func (v *verifier) Verify(data *spec.Data, sigCovered enc.Wire) VerifyResult {
+	rules := v.rules.Load()
+	if rules == nil {
+		return VerifyNotRequired
+	}
+
+	rule := matchVerifyRule(*rules, data.NameV, false)
+	isCert := false
+	if contentType := data.ContentType(); contentType != nil && *contentType == ndn.ContentTypeKey {
+		if keyRule := matchVerifyRule(*rules, keyNameOf(data.NameV), true); keyRule != nil {
+			isCert = true
+			if rule == nil {
+				rule = keyRule
+			}
+		}
+	}
+	if rule == nil {
+		return VerifyNotRequired
+	}
+
+	result := v.check(data, sigCovered, rule.KeyPrefix)
+	switch result {
+	case VerifyValid:
+		v.nValid.Add(1)
+		if isCert {
+			v.learn(data)
+		}
+	case VerifyUnknownKey:
+		v.nUnknownKey.Add(1)
+	case VerifyInvalid:
+		v.nInvalid.Add(1)
+	}
+	return result
+}
+
+// check verifies the signature of a Data packet with a trusted key under a key prefix.
+func (v *verifier) check(data *spec.Data, sigCovered enc.Wire, keyPrefix enc.Name) VerifyResult {
+	keyLocator := data.KeyName()
+	if keyLocator == nil || sigCovered == nil {
+		return VerifyInvalid
+	}
+	keyName := keyNameOf(keyLocator)
+	if !keyPrefix.IsPrefix(keyName) {
+		return VerifyInvalid
+	}
+
+	v.mutex.RLock()
+	key := v.keys[keyName.String()]
+	v.mutex.RUnlock()
+	if key == nil || (key.notAfter != nil && core.Now().After(*key.notAfter)) {
+		return VerifyUnknownKey
+	}
+
+	if !verifySignature(key.key, sigCovered, data.Signature()) {
+		return VerifyInvalid
+	}
+	return VerifyValid
+}
+
+// learn trusts the key of a verified certificate.
+func (v *verifier) learn(cert *spec.Data) {
+	key, err := parseCertificate(cert)
+	if err != nil {
+		core.LogDebug("Verifier", "Unable to use certificate ", cert.NameV, ": ", err)
+		return
+	}
+	name := keyNameOf(cert.NameV).String()
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if _, ok := v.keys[name]; ok {
+		return
+	}
+	v.keys[name] = key
+	v.learned = append(v.learned, name)
+	for len(v.learned) > v.capacity {
+		delete(v.keys, v.learned[0])
+		v.learned = v.learned[1:]
+	}
+	core.LogDebug("Verifier", "Trusted key ", name)
+}
+
+// Counters returns the number of Data packets with a valid signature, with an invalid
+// signature, and signed by a key that is not trusted.
+func (v *verifier) Counters() (nValid uint64, nInvalid uint64, nUnknownKey uint64) {
+	return v.nValid.Load(), v.nInvalid.Load(), v.nUnknownKey.Load()
+}
+
+// matchVerifyRule returns the rule with the longest prefix of a Data name or, if byKey is set,
+// the rule with the longest key prefix of a key name.
+func matchVerifyRule(rules []*VerifyRule, name enc.Name, byKey bool) *VerifyRule {
+	var match *VerifyRule
+	matchLen := -1
+	for _, rule := range rules {
+		prefix := rule.Prefix
+		if byKey {
+			prefix = rule.KeyPrefix
+		}
+		if prefix.IsPrefix(name) && len(prefix) > matchLen {
+			match, matchLen = rule, len(prefix)
+		}
+	}
+	return match
+}
+
+// keyNameOf returns the key name in a certificate name /<identity>/KEY/<key-id>/<issuer>/<version>,
+// or the name itself if it is not a certificate name.
+func keyNameOf(name enc.Name) enc.Name {
+	for i := len(name) - 2; i >= 0; i-- {
+		if name[i].Typ == enc.TypeGenericNameComponent && bytes.Equal(name[i].Val, []byte("KEY")) {
+			return name[:i+2]
+		}
+	}
+	return name
+}
+
+// verifySignature checks a signature with a public key of any supported type.
+func verifySignature(key any, sigCovered enc.Wire, sig ndn.Signature) bool {
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		return sec.EcdsaValidate(sigCovered, sig, key)
+	case *rsa.PublicKey:
+		return sec.RsaValidate(sigCovered, sig, key)
+	case ed25519.PublicKey:
+		return sec.EddsaValidate(sigCovered, sig, key)
+	default:
+		return false
+	}
+}
+
+// parseCertificate extracts the public key of a certificate, if it is currently valid.
+func parseCertificate(cert *spec.Data) (*trustedKey, error) {
+	key, err := x509.ParsePKIXPublicKey(cert.Content().Join())
+	if err != nil {
+		return nil, err
+	}
+	notBefore, notAfter := cert.Validity()
+	now := core.Now()
+	if (notBefore != nil && now.Before(*notBefore)) || (notAfter != nil && now.After(*notAfter)) {
+		return nil, errors.New("certificate is not valid at this time")
+	}
+	return &trustedKey{key: key, notAfter: notAfter}, nil
+}
+
+// loadTrustAnchor reads a certificate file, encoded in base64 or in binary.
+func loadTrustAnchor(path string) (enc.Name, *trustedKey, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	wire, err := base64.StdEncoding.DecodeString(string(bytes.Join(bytes.Fields(file), nil)))
+	if err != nil {
+		wire = file
+	}
+
+	pkt, _, err := spec.ReadPacket(enc.NewBufferReader(wire))
+	if err != nil {
+		return nil, nil, err
+	}
+	if pkt.Data == nil {
+		return nil, nil, errors.New("not a certificate")
+	}
+	key, err := parseCertificate(pkt.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return keyNameOf(pkt.Data.NameV), key, nil
+}
+
+// configureVerifier replaces the rules and trust anchors from the configuration.
+// The keys learned from certificates are forgotten, as they may no longer be trusted.
+func configureVerifier() {
+	cfg := core.GetConfig().Tables.Verify
+	v := Verifier
+
+	rules := make([]*VerifyRule, 0, len(cfg.Rules))
+	for _, ruleCfg := range cfg.Rules {
+		prefix, err := enc.NameFromStr(ruleCfg.Prefix)
+		if err != nil {
+			core.LogError("Verifier", "Invalid verification rule for ", ruleCfg.Prefix, ": ", err)
+			continue
+		}
+		keyPrefix := prefix
+		if ruleCfg.KeyPrefix != "" {
+			if keyPrefix, err = enc.NameFromStr(ruleCfg.KeyPrefix); err != nil {
+				core.LogError("Verifier", "Invalid key prefix for ", ruleCfg.Prefix, ": ", err)
+				continue
+			}
+		}
+		rules = append(rules, &VerifyRule{Prefix: prefix, KeyPrefix: keyPrefix})
+	}
+
+	keys := make(map[string]*trustedKey)
+	for _, file := range cfg.Anchors {
+		name, key, err := loadTrustAnchor(core.ResolveConfigFileRelPath(file))
+		if err != nil {
+			core.LogError("Verifier", "Unable to load trust anchor ", file, ": ", err)
+			continue
+		}
+		keys[name.String()] = key
+		core.LogInfo("Verifier", "Loaded trust anchor ", name)
+	}
+
+	v.mutex.Lock()
+	v.keys = keys
+	v.learned = nil
+	v.capacity = max(cfg.CacheSize, 0)
+	v.mutex.Unlock()
+
+	if len(rules) == 0 {
+		v.rules.Store(nil)
+	} else {
+		v.rules.Store(&rules)
+	}
+}
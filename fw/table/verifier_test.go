@@ -0,0 +1,208 @@
+package table
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	sec "github.com/named-data/ndnd/std/security"
+	"github.com/named-data/ndnd/std/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testKey struct {
+	name enc.Name
+	key  *ecdsa.PrivateKey
+}
+
+func newTestKey(t *testing.T, name string) testKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	keyName, _ := enc.NameFromStr(name)
+	return testKey{keyName, key}
+}
+
+// encodeTestData encodes a Data packet signed by a key.
+func encodeTestData(t *testing.T, name enc.Name, contentType ndn.ContentType, content []byte, signer testKey, forCert bool) []byte {
+	data, err := spec.Spec{}.MakeData(name, &ndn.DataConfig{
+		ContentType: utils.IdPtr(contentType),
+	}, enc.Wire{content}, sec.NewEccSigner(forCert, false, time.Hour, signer.key, signer.name))
+	require.NoError(t, err)
+	return data.Wire.Join()
+}
+
+// parseTestData parses a Data packet as the forwarder does, and returns its signed portion.
+func parseTestData(t *testing.T, raw []byte) (*spec.Data, enc.Wire) {
+	pkt, ctx, err := spec.ReadPacket(enc.NewBufferReader(raw))
+	require.NoError(t, err)
+	require.NotNil(t, pkt.Data)
+	return pkt.Data, ctx.Data_context.SigCovered()
+}
+
+// makeTestData makes a Data packet signed by a key.
+func makeTestData(t *testing.T, name enc.Name, contentType ndn.ContentType, content []byte, signer testKey, forCert bool) (*spec.Data, enc.Wire) {
+	return parseTestData(t, encodeTestData(t, name, contentType, content, signer, forCert))
+}
+
+// encodeTestCert encodes the certificate of a key signed by an issuer.
+func encodeTestCert(t *testing.T, key testKey, issuer testKey) []byte {
+	pub, err := x509.MarshalPKIXPublicKey(&key.key.PublicKey)
+	require.NoError(t, err)
+	name := append(key.name.Clone(),
+		enc.NewStringComponent(enc.TypeGenericNameComponent, "issuer"),
+		enc.NewVersionComponent(1))
+	return encodeTestData(t, name, ndn.ContentTypeKey, pub, issuer, true)
+}
+
+// makeTestCert makes the certificate of a key signed by an issuer.
+func makeTestCert(t *testing.T, key testKey, issuer testKey) (*spec.Data, enc.Wire) {
+	return parseTestData(t, encodeTestCert(t, key, issuer))
+}
+
+func newTestVerifier(rules ...*VerifyRule) *verifier {
+	v := &verifier{keys: make(map[string]*trustedKey), capacity: 1}
+	v.rules.Store(&rules)
+	return v
+}
+
+func TestVerifierData(t *testing.T) {
+	anchor := newTestKey(t, "/test/KEY/anchor")
+	other := newTestKey(t, "/test/KEY/other")
+	foreign := newTestKey(t, "/foreign/KEY/key")
+	prefix, _ := enc.NameFromStr("/test")
+	v := newTestVerifier(&VerifyRule{Prefix: prefix, KeyPrefix: prefix})
+
+	anchorCert, _ := makeTestCert(t, anchor, anchor)
+	key, err := parseCertificate(anchorCert)
+	require.NoError(t, err)
+	v.keys[anchor.name.String()] = key
+
+	name, _ := enc.NameFromStr("/test/data/1")
+	data, raw := makeTestData(t, name, ndn.ContentTypeBlob, []byte("content"), anchor, false)
+	assert.Equal(t, VerifyValid, v.Verify(data, raw))
+
+	// Corrupted content, in the buffer that was decoded
+	for _, buf := range raw {
+		if i := bytes.Index(buf, []byte("content")); i >= 0 {
+			buf[i] ^= 0xff
+		}
+	}
+	assert.Equal(t, VerifyInvalid, v.Verify(data, raw))
+	assert.Equal(t, VerifyInvalid, v.Verify(data, nil))
+
+	// Unknown key
+	data, raw = makeTestData(t, name, ndn.ContentTypeBlob, []byte("content"), other, false)
+	assert.Equal(t, VerifyUnknownKey, v.Verify(data, raw))
+
+	// Key not allowed by the rule, even if known
+	v.keys[foreign.name.String()] = &trustedKey{key: &foreign.key.PublicKey}
+	data, raw = makeTestData(t, name, ndn.ContentTypeBlob, []byte("content"), foreign, false)
+	assert.Equal(t, VerifyInvalid, v.Verify(data, raw))
+
+	// Not covered by a rule
+	name, _ = enc.NameFromStr("/other/data")
+	data, raw = makeTestData(t, name, ndn.ContentTypeBlob, []byte("content"), other, false)
+	assert.Equal(t, VerifyNotRequired, v.Verify(data, raw))
+
+	nValid, nInvalid, nUnknownKey := v.Counters()
+	assert.Equal(t, uint64(1), nValid)
+	assert.Equal(t, uint64(3), nInvalid)
+	assert.Equal(t, uint64(1), nUnknownKey)
+}
+
+func TestVerifierLearnCertificates(t *testing.T) {
+	anchor := newTestKey(t, "/test/KEY/anchor")
+	alice := newTestKey(t, "/test/alice/KEY/1")
+	bob := newTestKey(t, "/test/bob/KEY/1")
+	prefix, _ := enc.NameFromStr("/test")
+	dataPrefix, _ := enc.NameFromStr("/app")
+	v := newTestVerifier(&VerifyRule{Prefix: dataPrefix, KeyPrefix: prefix})
+	v.keys[anchor.name.String()] = &trustedKey{key: &anchor.key.PublicKey}
+
+	name, _ := enc.NameFromStr("/app/data")
+	data, raw := makeTestData(t, name, ndn.ContentTypeBlob, []byte("content"), alice, false)
+	assert.Equal(t, VerifyUnknownKey, v.Verify(data, raw))
+
+	// The certificate of alice is verified once, then its key is trusted
+	cert, certRaw := makeTestCert(t, alice, anchor)
+	assert.Equal(t, VerifyValid, v.Verify(cert, certRaw))
+	data, raw = makeTestData(t, name, ndn.ContentTypeBlob, []byte("content"), alice, false)
+	assert.Equal(t, VerifyValid, v.Verify(data, raw))
+
+	// A certificate signed by an untrusted key is not learned
+	cert, certRaw = makeTestCert(t, bob, bob)
+	assert.Equal(t, VerifyUnknownKey, v.Verify(cert, certRaw))
+	data, raw = makeTestData(t, name, ndn.ContentTypeBlob, []byte("content"), bob, false)
+	assert.Equal(t, VerifyUnknownKey, v.Verify(data, raw))
+
+	// Learned keys are evicted beyond the capacity, but not anchors
+	cert, certRaw = makeTestCert(t, bob, alice)
+	assert.Equal(t, VerifyValid, v.Verify(cert, certRaw))
+	data, raw = makeTestData(t, name, ndn.ContentTypeBlob, []byte("content"), alice, false)
+	assert.Equal(t, VerifyUnknownKey, v.Verify(data, raw))
+	data, raw = makeTestData(t, name, ndn.ContentTypeBlob, []byte("content"), anchor, false)
+	assert.Equal(t, VerifyValid, v.Verify(data, raw))
+}
+
+func TestVerifierNestedRules(t *testing.T) {
+	anchor := newTestKey(t, "/test/KEY/anchor")
+	alice := newTestKey(t, "/test/alice/KEY/1")
+	prefix, _ := enc.NameFromStr("/test")
+	alicePrefix, _ := enc.NameFromStr("/test/alice")
+	appPrefix, _ := enc.NameFromStr("/test/alice/app")
+	v := newTestVerifier(
+		&VerifyRule{Prefix: prefix, KeyPrefix: prefix},
+		&VerifyRule{Prefix: appPrefix, KeyPrefix: alicePrefix})
+	v.keys[anchor.name.String()] = &trustedKey{key: &anchor.key.PublicKey}
+
+	// The certificate of alice is covered by the rule of /test, not by the key prefix of the other
+	cert, certRaw := makeTestCert(t, alice, anchor)
+	assert.Equal(t, VerifyValid, v.Verify(cert, certRaw))
+
+	// Data is verified by the rule with the longest prefix only
+	name, _ := enc.NameFromStr("/test/alice/app/data")
+	data, raw := makeTestData(t, name, ndn.ContentTypeBlob, []byte("content"), alice, false)
+	assert.Equal(t, VerifyValid, v.Verify(data, raw))
+	data, raw = makeTestData(t, name, ndn.ContentTypeBlob, []byte("content"), anchor, false)
+	assert.Equal(t, VerifyInvalid, v.Verify(data, raw))
+
+	name, _ = enc.NameFromStr("/test/other")
+	data, raw = makeTestData(t, name, ndn.ContentTypeBlob, []byte("content"), alice, false)
+	assert.Equal(t, VerifyValid, v.Verify(data, raw))
+}
+
+func TestLoadTrustAnchor(t *testing.T) {
+	anchor := newTestKey(t, "/test/KEY/anchor")
+	raw := encodeTestCert(t, anchor, anchor)
+	dir := t.TempDir()
+
+	// ndnsec wraps the base64 encoding in lines
+	encoded := base64.StdEncoding.EncodeToString(raw)
+	text := encoded[:64] + "\n" + encoded[64:] + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "anchor.cert"), []byte(text), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "anchor.ndncert"), raw, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.cert"), []byte("invalid"), 0o600))
+
+	for _, file := range []string{"anchor.cert", "anchor.ndncert"} {
+		name, key, err := loadTrustAnchor(filepath.Join(dir, file))
+		assert.NoError(t, err)
+		assert.Equal(t, anchor.name.String(), name.String())
+		assert.True(t, anchor.key.PublicKey.Equal(key.key))
+	}
+
+	_, _, err := loadTrustAnchor(filepath.Join(dir, "invalid.cert"))
+	assert.Error(t, err)
+	_, _, err = loadTrustAnchor(filepath.Join(dir, "missing.cert"))
+	assert.Error(t, err)
+}
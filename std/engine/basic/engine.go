@@ -450,56 +450,70 @@ func (e *Engine) ExecMgmtCmd(module string, cmd string, args any) error {
 	if !ok {
 		return ndn.ErrInvalidValue{Item: "args", Value: args}
 	}
+	_, err := e.ExecMgmtCmdWithResponse(module, cmd, cmdArgs)
+	return err
+}
 
+// ExecMgmtCmdWithResponse executes a management command like ExecMgmtCmd, and also
+// returns the control response whenever one is received, including with an error
+// status code (e.g., the parameters of the existing face when creating a face).
+func (e *Engine) ExecMgmtCmdWithResponse(module string, cmd string, cmdArgs *mgmt.ControlArgs) (
+	*mgmt.ControlResponse, error,
+) {
 	intCfg := &ndn.InterestConfig{
 		Lifetime: utils.IdPtr(1 * time.Second),
 		Nonce:    utils.ConvertNonce(e.timer.Nonce()),
 	}
 	interest, err := e.mgmtConf.MakeCmd(module, cmd, cmdArgs, intCfg)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	type mgmtResp struct {
+		err error
+		val *mgmt.ControlResponse
 	}
-	ch := make(chan error)
+	ch := make(chan mgmtResp)
 	err = e.Express(interest, func(args ndn.ExpressCallbackArgs) {
 		if args.Result == ndn.InterestResultNack {
-			ch <- fmt.Errorf("nack received: %v", args.NackReason)
+			ch <- mgmtResp{err: fmt.Errorf("nack received: %v", args.NackReason)}
 		} else if args.Result == ndn.InterestResultTimeout {
-			ch <- ndn.ErrDeadlineExceed
+			ch <- mgmtResp{err: ndn.ErrDeadlineExceed}
 		} else if args.Result == ndn.InterestResultData {
 			data := args.Data
 			valid := e.cmdChecker(data.Name(), args.SigCovered, data.Signature())
 			if !valid {
-				ch <- fmt.Errorf("command signature is not valid")
+				ch <- mgmtResp{err: fmt.Errorf("command signature is not valid")}
 			} else {
 				ret, err := mgmt.ParseControlResponse(enc.NewWireReader(data.Content()), true)
 				if err != nil {
-					ch <- err
+					ch <- mgmtResp{err: err}
 				} else {
 					if ret.Val != nil {
 						if ret.Val.StatusCode == 200 {
-							ch <- nil
+							ch <- mgmtResp{val: ret}
 						} else {
 							errText := ret.Val.StatusText
-							ch <- fmt.Errorf("command failed due to error %d: %s", ret.Val.StatusCode, errText)
+							ch <- mgmtResp{
+								err: fmt.Errorf("command failed due to error %d: %s", ret.Val.StatusCode, errText),
+								val: ret,
+							}
 						}
 					} else {
-						ch <- fmt.Errorf("improper response")
+						ch <- mgmtResp{err: fmt.Errorf("improper response")}
 					}
 				}
 			}
 		} else {
-			ch <- fmt.Errorf("unknown result: %v", args.Result)
+			ch <- mgmtResp{err: fmt.Errorf("unknown result: %v", args.Result)}
 		}
 		close(ch)
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	err = <-ch
-	if err != nil {
-		return err
-	}
-	return nil
+	resp := <-ch
+	return resp.val, resp.err
 }
 
 func (e *Engine) RegisterRoute(prefix enc.Name) error {
@@ -90,6 +90,18 @@ type ControlArgs struct {
 	DefaultCongestionThreshold *uint64 `tlv:"0x88"`
 	//+field:natural:optional
 	Mtu *uint64 `tlv:"0x89"`
+
+	// Packet filter rules (YaNFD extension)
+	//+field:natural:optional
+	RuleId *uint64 `tlv:"0xe0"`
+	//+field:string:optional
+	Action *string `tlv:"0xe1"`
+	//+field:string:optional
+	Direction *string `tlv:"0xe2"`
+	//+field:string:optional
+	PacketType *string `tlv:"0xe3"`
+	//+field:string:optional
+	Scope *string `tlv:"0xe4"`
 }
 
 // +tlv-model:dict
@@ -188,6 +200,12 @@ type GeneralStatus struct {
 	NRetxExhausted *uint64 `tlv:"0xcf"`
 	//+field:natural:optional
 	NConngestionMarked *uint64 `tlv:"0xd0"`
+
+	// Packets dropped because a forwarding thread queue was full (YaNFD extension)
+	//+field:natural:optional
+	NDroppedInterests *uint64 `tlv:"0xec"`
+	//+field:natural:optional
+	NDroppedData *uint64 `tlv:"0xee"`
 }
 
 type FaceStatus struct {
@@ -231,6 +249,10 @@ type FaceStatus struct {
 
 	//+field:natural
 	Flags uint64 `tlv:"0x6c"`
+
+	// Face state, up (0), down (1) or administratively down (2) (YaNFD extension)
+	//+field:natural:optional
+	FaceState *uint64 `tlv:"0xd0"`
 }
 
 type FaceStatusMsg struct {
@@ -334,6 +356,65 @@ type CsInfoMsg struct {
 	CsInfo *CsInfo `tlv:"0x80"`
 }
 
+// Packet filter rule (YaNFD extension)
+type FilterRule struct {
+	//+field:natural
+	RuleId uint64 `tlv:"0xe0"`
+	//+field:string
+	Action string `tlv:"0xe1"`
+	//+field:name
+	Name enc.Name `tlv:"0x07"`
+	//+field:string:optional
+	Direction *string `tlv:"0xe2"`
+	//+field:string:optional
+	PacketType *string `tlv:"0xe3"`
+	//+field:string:optional
+	Uri *string `tlv:"0x72"`
+	//+field:string:optional
+	Scope *string `tlv:"0xe4"`
+	//+field:natural
+	NHits uint64 `tlv:"0xe5"`
+}
+
+type FilterRuleMsg struct {
+	//+field:sequence:*FilterRule:struct:FilterRule
+	Rules []*FilterRule `tlv:"0x80"`
+}
+
+// Pipeline decision on a traced packet (YaNFD extension)
+type TraceEvent struct {
+	// Nanoseconds since the Unix epoch
+	//+field:natural
+	Timestamp uint64 `tlv:"0xe8"`
+	//+field:string
+	Event string `tlv:"0xe9"`
+	//+field:natural
+	Thread uint64 `tlv:"0xea"`
+	//+field:natural:optional
+	FaceId *uint64 `tlv:"0x69"`
+	//+field:string:optional
+	Detail *string `tlv:"0xeb"`
+}
+
+// Sampled packet trace (YaNFD extension)
+type Trace struct {
+	//+field:natural
+	TraceId uint64 `tlv:"0xe6"`
+	//+field:name
+	Name enc.Name `tlv:"0x07"`
+	//+field:string
+	PacketType string `tlv:"0xe3"`
+	//+field:natural
+	FaceId uint64 `tlv:"0x69"`
+	//+field:sequence:*TraceEvent:struct:TraceEvent
+	Events []*TraceEvent `tlv:"0xe7"`
+}
+
+type TraceMsg struct {
+	//+field:sequence:*Trace:struct:Trace
+	Traces []*Trace `tlv:"0x80"`
+}
+
 // No Tlv numbers assigned yet
 type CsQuery struct {
 	Name            enc.Name
@@ -454,6 +454,75 @@ func (encoder *ControlArgsEncoder) Init(value *ControlArgs) {
 			l += 9
 		}
 	}
+	if value.RuleId != nil {
+		l += 1
+		switch x := *value.RuleId; {
+		case x <= 0xff:
+			l += 2
+		case x <= 0xffff:
+			l += 3
+		case x <= 0xffffffff:
+			l += 5
+		default:
+			l += 9
+		}
+	}
+	if value.Action != nil {
+		l += 1
+		switch x := len(*value.Action); {
+		case x <= 0xfc:
+			l += 1
+		case x <= 0xffff:
+			l += 3
+		case x <= 0xffffffff:
+			l += 5
+		default:
+			l += 9
+		}
+		l += uint(len(*value.Action))
+	}
+	if value.Direction != nil {
+		l += 1
+		switch x := len(*value.Direction); {
+		case x <= 0xfc:
+			l += 1
+		case x <= 0xffff:
+			l += 3
+		case x <= 0xffffffff:
+			l += 5
+		default:
+			l += 9
+		}
+		l += uint(len(*value.Direction))
+	}
+	if value.PacketType != nil {
+		l += 1
+		switch x := len(*value.PacketType); {
+		case x <= 0xfc:
+			l += 1
+		case x <= 0xffff:
+			l += 3
+		case x <= 0xffffffff:
+			l += 5
+		default:
+			l += 9
+		}
+		l += uint(len(*value.PacketType))
+	}
+	if value.Scope != nil {
+		l += 1
+		switch x := len(*value.Scope); {
+		case x <= 0xfc:
+			l += 1
+		case x <= 0xffff:
+			l += 3
+		case x <= 0xffffffff:
+			l += 5
+		default:
+			l += 9
+		}
+		l += uint(len(*value.Scope))
+	}
 	encoder.length = l
 
 }
@@ -827,6 +896,120 @@ func (encoder *ControlArgsEncoder) EncodeInto(value *ControlArgs, buf []byte) {
 			pos += 9
 		}
 	}
+	if value.RuleId != nil {
+		buf[pos] = byte(224)
+		pos += 1
+		switch x := *value.RuleId; {
+		case x <= 0xff:
+			buf[pos] = 1
+			buf[pos+1] = byte(x)
+			pos += 2
+		case x <= 0xffff:
+			buf[pos] = 2
+			binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+			pos += 3
+		case x <= 0xffffffff:
+			buf[pos] = 4
+			binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+			pos += 5
+		default:
+			buf[pos] = 8
+			binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+			pos += 9
+		}
+	}
+	if value.Action != nil {
+		buf[pos] = byte(225)
+		pos += 1
+		switch x := len(*value.Action); {
+		case x <= 0xfc:
+			buf[pos] = byte(x)
+			pos += 1
+		case x <= 0xffff:
+			buf[pos] = 0xfd
+			binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+			pos += 3
+		case x <= 0xffffffff:
+			buf[pos] = 0xfe
+			binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+			pos += 5
+		default:
+			buf[pos] = 0xff
+			binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+			pos += 9
+		}
+		copy(buf[pos:], *value.Action)
+		pos += uint(len(*value.Action))
+	}
+	if value.Direction != nil {
+		buf[pos] = byte(226)
+		pos += 1
+		switch x := len(*value.Direction); {
+		case x <= 0xfc:
+			buf[pos] = byte(x)
+			pos += 1
+		case x <= 0xffff:
+			buf[pos] = 0xfd
+			binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+			pos += 3
+		case x <= 0xffffffff:
+			buf[pos] = 0xfe
+			binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+			pos += 5
+		default:
+			buf[pos] = 0xff
+			binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+			pos += 9
+		}
+		copy(buf[pos:], *value.Direction)
+		pos += uint(len(*value.Direction))
+	}
+	if value.PacketType != nil {
+		buf[pos] = byte(227)
+		pos += 1
+		switch x := len(*value.PacketType); {
+		case x <= 0xfc:
+			buf[pos] = byte(x)
+			pos += 1
+		case x <= 0xffff:
+			buf[pos] = 0xfd
+			binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+			pos += 3
+		case x <= 0xffffffff:
+			buf[pos] = 0xfe
+			binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+			pos += 5
+		default:
+			buf[pos] = 0xff
+			binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+			pos += 9
+		}
+		copy(buf[pos:], *value.PacketType)
+		pos += uint(len(*value.PacketType))
+	}
+	if value.Scope != nil {
+		buf[pos] = byte(228)
+		pos += 1
+		switch x := len(*value.Scope); {
+		case x <= 0xfc:
+			buf[pos] = byte(x)
+			pos += 1
+		case x <= 0xffff:
+			buf[pos] = 0xfd
+			binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+			pos += 3
+		case x <= 0xffffffff:
+			buf[pos] = 0xfe
+			binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+			pos += 5
+		default:
+			buf[pos] = 0xff
+			binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+			pos += 9
+		}
+		copy(buf[pos:], *value.Scope)
+		pos += uint(len(*value.Scope))
+	}
 }
 
 func (encoder *ControlArgsEncoder) Encode(value *ControlArgs) enc.Wire {
@@ -860,6 +1043,11 @@ func (context *ControlArgsParsingContext) Parse(reader enc.ParseReader, ignoreCr
 	var handled_BaseCongestionMarkInterval bool = false
 	var handled_DefaultCongestionThreshold bool = false
 	var handled_Mtu bool = false
+	var handled_RuleId bool = false
+	var handled_Action bool = false
+	var handled_Direction bool = false
+	var handled_PacketType bool = false
+	var handled_Scope bool = false
 
 	progress := -1
 	_ = progress
@@ -1219,6 +1407,81 @@ func (context *ControlArgsParsingContext) Parse(reader enc.ParseReader, ignoreCr
 						value.Mtu = &tempVal
 					}
 				}
+			case 224:
+				if true {
+					handled = true
+					handled_RuleId = true
+					{
+						tempVal := uint64(0)
+						tempVal = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								tempVal = uint64(tempVal<<8) | uint64(x)
+							}
+						}
+						value.RuleId = &tempVal
+					}
+				}
+			case 225:
+				if true {
+					handled = true
+					handled_Action = true
+					{
+						var builder strings.Builder
+						_, err = io.CopyN(&builder, reader, int64(l))
+						if err == nil {
+							tempStr := builder.String()
+							value.Action = &tempStr
+						}
+					}
+				}
+			case 226:
+				if true {
+					handled = true
+					handled_Direction = true
+					{
+						var builder strings.Builder
+						_, err = io.CopyN(&builder, reader, int64(l))
+						if err == nil {
+							tempStr := builder.String()
+							value.Direction = &tempStr
+						}
+					}
+				}
+			case 227:
+				if true {
+					handled = true
+					handled_PacketType = true
+					{
+						var builder strings.Builder
+						_, err = io.CopyN(&builder, reader, int64(l))
+						if err == nil {
+							tempStr := builder.String()
+							value.PacketType = &tempStr
+						}
+					}
+				}
+			case 228:
+				if true {
+					handled = true
+					handled_Scope = true
+					{
+						var builder strings.Builder
+						_, err = io.CopyN(&builder, reader, int64(l))
+						if err == nil {
+							tempStr := builder.String()
+							value.Scope = &tempStr
+						}
+					}
+				}
 			default:
 				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
 					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
@@ -1285,6 +1548,21 @@ func (context *ControlArgsParsingContext) Parse(reader enc.ParseReader, ignoreCr
 	if !handled_Mtu && err == nil {
 		value.Mtu = nil
 	}
+	if !handled_RuleId && err == nil {
+		value.RuleId = nil
+	}
+	if !handled_Action && err == nil {
+		value.Action = nil
+	}
+	if !handled_Direction && err == nil {
+		value.Direction = nil
+	}
+	if !handled_PacketType && err == nil {
+		value.PacketType = nil
+	}
+	if !handled_Scope && err == nil {
+		value.Scope = nil
+	}
 
 	if err != nil {
 		return nil, err
@@ -1359,6 +1637,21 @@ func (value *ControlArgs) ToDict() map[string]any {
 	if value.Mtu != nil {
 		dict["Mtu"] = *value.Mtu
 	}
+	if value.RuleId != nil {
+		dict["RuleId"] = *value.RuleId
+	}
+	if value.Action != nil {
+		dict["Action"] = *value.Action
+	}
+	if value.Direction != nil {
+		dict["Direction"] = *value.Direction
+	}
+	if value.PacketType != nil {
+		dict["PacketType"] = *value.PacketType
+	}
+	if value.Scope != nil {
+		dict["Scope"] = *value.Scope
+	}
 	return dict
 }
 
@@ -1557,6 +1850,66 @@ func DictToControlArgs(dict map[string]any) (*ControlArgs, error) {
 	if err != nil {
 		return nil, err
 	}
+	if vv, ok := dict["RuleId"]; ok {
+		if v, ok := vv.(uint64); ok {
+			value.RuleId = &v
+		} else {
+			err = enc.ErrIncompatibleType{Name: "RuleId", TypeNum: 224, ValType: "uint64", Value: vv}
+		}
+	} else {
+		value.RuleId = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if vv, ok := dict["Action"]; ok {
+		if v, ok := vv.(string); ok {
+			value.Action = &v
+		} else {
+			err = enc.ErrIncompatibleType{Name: "Action", TypeNum: 225, ValType: "string", Value: vv}
+		}
+	} else {
+		value.Action = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if vv, ok := dict["Direction"]; ok {
+		if v, ok := vv.(string); ok {
+			value.Direction = &v
+		} else {
+			err = enc.ErrIncompatibleType{Name: "Direction", TypeNum: 226, ValType: "string", Value: vv}
+		}
+	} else {
+		value.Direction = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if vv, ok := dict["PacketType"]; ok {
+		if v, ok := vv.(string); ok {
+			value.PacketType = &v
+		} else {
+			err = enc.ErrIncompatibleType{Name: "PacketType", TypeNum: 227, ValType: "string", Value: vv}
+		}
+	} else {
+		value.PacketType = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if vv, ok := dict["Scope"]; ok {
+		if v, ok := vv.(string); ok {
+			value.Scope = &v
+		} else {
+			err = enc.ErrIncompatibleType{Name: "Scope", TypeNum: 228, ValType: "string", Value: vv}
+		}
+	} else {
+		value.Scope = nil
+	}
+	if err != nil {
+		return nil, err
+	}
 	return value, nil
 }
 
@@ -3198,6 +3551,32 @@ func (encoder *GeneralStatusEncoder) Init(value *GeneralStatus) {
 			l += 9
 		}
 	}
+	if value.NDroppedInterests != nil {
+		l += 1
+		switch x := *value.NDroppedInterests; {
+		case x <= 0xff:
+			l += 2
+		case x <= 0xffff:
+			l += 3
+		case x <= 0xffffffff:
+			l += 5
+		default:
+			l += 9
+		}
+	}
+	if value.NDroppedData != nil {
+		l += 1
+		switch x := *value.NDroppedData; {
+		case x <= 0xff:
+			l += 2
+		case x <= 0xffff:
+			l += 3
+		case x <= 0xffffffff:
+			l += 5
+		default:
+			l += 9
+		}
+	}
 	encoder.length = l
 
 }
@@ -3729,6 +4108,50 @@ func (encoder *GeneralStatusEncoder) EncodeInto(value *GeneralStatus, buf []byte
 			pos += 9
 		}
 	}
+	if value.NDroppedInterests != nil {
+		buf[pos] = byte(236)
+		pos += 1
+		switch x := *value.NDroppedInterests; {
+		case x <= 0xff:
+			buf[pos] = 1
+			buf[pos+1] = byte(x)
+			pos += 2
+		case x <= 0xffff:
+			buf[pos] = 2
+			binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+			pos += 3
+		case x <= 0xffffffff:
+			buf[pos] = 4
+			binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+			pos += 5
+		default:
+			buf[pos] = 8
+			binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+			pos += 9
+		}
+	}
+	if value.NDroppedData != nil {
+		buf[pos] = byte(238)
+		pos += 1
+		switch x := *value.NDroppedData; {
+		case x <= 0xff:
+			buf[pos] = 1
+			buf[pos+1] = byte(x)
+			pos += 2
+		case x <= 0xffff:
+			buf[pos] = 2
+			binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+			pos += 3
+		case x <= 0xffffffff:
+			buf[pos] = 4
+			binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+			pos += 5
+		default:
+			buf[pos] = 8
+			binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+			pos += 9
+		}
+	}
 }
 
 func (encoder *GeneralStatusEncoder) Encode(value *GeneralStatus) enc.Wire {
@@ -3771,6 +4194,8 @@ func (context *GeneralStatusParsingContext) Parse(reader enc.ParseReader, ignore
 	var handled_NRetransmitted bool = false
 	var handled_NRetxExhausted bool = false
 	var handled_NConngestionMarked bool = false
+	var handled_NDroppedInterests bool = false
+	var handled_NDroppedData bool = false
 
 	progress := -1
 	_ = progress
@@ -4301,6 +4726,52 @@ func (context *GeneralStatusParsingContext) Parse(reader enc.ParseReader, ignore
 						value.NConngestionMarked = &tempVal
 					}
 				}
+			case 236:
+				if true {
+					handled = true
+					handled_NDroppedInterests = true
+					{
+						tempVal := uint64(0)
+						tempVal = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								tempVal = uint64(tempVal<<8) | uint64(x)
+							}
+						}
+						value.NDroppedInterests = &tempVal
+					}
+				}
+			case 238:
+				if true {
+					handled = true
+					handled_NDroppedData = true
+					{
+						tempVal := uint64(0)
+						tempVal = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								tempVal = uint64(tempVal<<8) | uint64(x)
+							}
+						}
+						value.NDroppedData = &tempVal
+					}
+				}
 			default:
 				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
 					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
@@ -4394,6 +4865,12 @@ func (context *GeneralStatusParsingContext) Parse(reader enc.ParseReader, ignore
 	if !handled_NConngestionMarked && err == nil {
 		value.NConngestionMarked = nil
 	}
+	if !handled_NDroppedInterests && err == nil {
+		value.NDroppedInterests = nil
+	}
+	if !handled_NDroppedData && err == nil {
+		value.NDroppedData = nil
+	}
 
 	if err != nil {
 		return nil, err
@@ -4647,6 +5124,19 @@ func (encoder *FaceStatusEncoder) Init(value *FaceStatus) {
 	default:
 		l += 9
 	}
+	if value.FaceState != nil {
+		l += 1
+		switch x := *value.FaceState; {
+		case x <= 0xff:
+			l += 2
+		case x <= 0xffff:
+			l += 3
+		case x <= 0xffffffff:
+			l += 5
+		default:
+			l += 9
+		}
+	}
 	encoder.length = l
 
 }
@@ -5049,6 +5539,28 @@ func (encoder *FaceStatusEncoder) EncodeInto(value *FaceStatus, buf []byte) {
 		binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
 		pos += 9
 	}
+	if value.FaceState != nil {
+		buf[pos] = byte(208)
+		pos += 1
+		switch x := *value.FaceState; {
+		case x <= 0xff:
+			buf[pos] = 1
+			buf[pos+1] = byte(x)
+			pos += 2
+		case x <= 0xffff:
+			buf[pos] = 2
+			binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+			pos += 3
+		case x <= 0xffffffff:
+			buf[pos] = 4
+			binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+			pos += 5
+		default:
+			buf[pos] = 8
+			binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+			pos += 9
+		}
+	}
 }
 
 func (encoder *FaceStatusEncoder) Encode(value *FaceStatus) enc.Wire {
@@ -5085,6 +5597,7 @@ func (context *FaceStatusParsingContext) Parse(reader enc.ParseReader, ignoreCri
 	var handled_NInBytes bool = false
 	var handled_NOutBytes bool = false
 	var handled_Flags bool = false
+	var handled_FaceState bool = false
 
 	progress := -1
 	_ = progress
@@ -5474,6 +5987,29 @@ func (context *FaceStatusParsingContext) Parse(reader enc.ParseReader, ignoreCri
 						}
 					}
 				}
+			case 208:
+				if true {
+					handled = true
+					handled_FaceState = true
+					{
+						tempVal := uint64(0)
+						tempVal = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								tempVal = uint64(tempVal<<8) | uint64(x)
+							}
+						}
+						value.FaceState = &tempVal
+					}
+				}
 			default:
 				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
 					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
@@ -5549,6 +6085,9 @@ func (context *FaceStatusParsingContext) Parse(reader enc.ParseReader, ignoreCri
 	if !handled_Flags && err == nil {
 		err = enc.ErrSkipRequired{Name: "Flags", TypeNum: 108}
 	}
+	if !handled_FaceState && err == nil {
+		value.FaceState = nil
+	}
 
 	if err != nil {
 		return nil, err
@@ -9157,3 +9696,1864 @@ func ParseCsInfoMsg(reader enc.ParseReader, ignoreCritical bool) (*CsInfoMsg, er
 	context.Init()
 	return context.Parse(reader, ignoreCritical)
 }
+
+type FilterRuleEncoder struct {
+	length uint
+
+	Name_length uint
+}
+
+type FilterRuleParsingContext struct {
+}
+
+func (encoder *FilterRuleEncoder) Init(value *FilterRule) {
+
+	if value.Name != nil {
+		encoder.Name_length = 0
+		for _, c := range value.Name {
+			encoder.Name_length += uint(c.EncodingLength())
+		}
+	}
+
+	l := uint(0)
+	l += 1
+	switch x := value.RuleId; {
+	case x <= 0xff:
+		l += 2
+	case x <= 0xffff:
+		l += 3
+	case x <= 0xffffffff:
+		l += 5
+	default:
+		l += 9
+	}
+	l += 1
+	switch x := len(value.Action); {
+	case x <= 0xfc:
+		l += 1
+	case x <= 0xffff:
+		l += 3
+	case x <= 0xffffffff:
+		l += 5
+	default:
+		l += 9
+	}
+	l += uint(len(value.Action))
+	if value.Name != nil {
+		l += 1
+		switch x := encoder.Name_length; {
+		case x <= 0xfc:
+			l += 1
+		case x <= 0xffff:
+			l += 3
+		case x <= 0xffffffff:
+			l += 5
+		default:
+			l += 9
+		}
+		l += encoder.Name_length
+	}
+	if value.Direction != nil {
+		l += 1
+		switch x := len(*value.Direction); {
+		case x <= 0xfc:
+			l += 1
+		case x <= 0xffff:
+			l += 3
+		case x <= 0xffffffff:
+			l += 5
+		default:
+			l += 9
+		}
+		l += uint(len(*value.Direction))
+	}
+	if value.PacketType != nil {
+		l += 1
+		switch x := len(*value.PacketType); {
+		case x <= 0xfc:
+			l += 1
+		case x <= 0xffff:
+			l += 3
+		case x <= 0xffffffff:
+			l += 5
+		default:
+			l += 9
+		}
+		l += uint(len(*value.PacketType))
+	}
+	if value.Uri != nil {
+		l += 1
+		switch x := len(*value.Uri); {
+		case x <= 0xfc:
+			l += 1
+		case x <= 0xffff:
+			l += 3
+		case x <= 0xffffffff:
+			l += 5
+		default:
+			l += 9
+		}
+		l += uint(len(*value.Uri))
+	}
+	if value.Scope != nil {
+		l += 1
+		switch x := len(*value.Scope); {
+		case x <= 0xfc:
+			l += 1
+		case x <= 0xffff:
+			l += 3
+		case x <= 0xffffffff:
+			l += 5
+		default:
+			l += 9
+		}
+		l += uint(len(*value.Scope))
+	}
+	l += 1
+	switch x := value.NHits; {
+	case x <= 0xff:
+		l += 2
+	case x <= 0xffff:
+		l += 3
+	case x <= 0xffffffff:
+		l += 5
+	default:
+		l += 9
+	}
+	encoder.length = l
+
+}
+
+func (context *FilterRuleParsingContext) Init() {
+
+}
+
+func (encoder *FilterRuleEncoder) EncodeInto(value *FilterRule, buf []byte) {
+
+	pos := uint(0)
+
+	buf[pos] = byte(224)
+	pos += 1
+	switch x := value.RuleId; {
+	case x <= 0xff:
+		buf[pos] = 1
+		buf[pos+1] = byte(x)
+		pos += 2
+	case x <= 0xffff:
+		buf[pos] = 2
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+		pos += 3
+	case x <= 0xffffffff:
+		buf[pos] = 4
+		binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+		pos += 5
+	default:
+		buf[pos] = 8
+		binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+		pos += 9
+	}
+	buf[pos] = byte(225)
+	pos += 1
+	switch x := len(value.Action); {
+	case x <= 0xfc:
+		buf[pos] = byte(x)
+		pos += 1
+	case x <= 0xffff:
+		buf[pos] = 0xfd
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+		pos += 3
+	case x <= 0xffffffff:
+		buf[pos] = 0xfe
+		binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+		pos += 5
+	default:
+		buf[pos] = 0xff
+		binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+		pos += 9
+	}
+	copy(buf[pos:], value.Action)
+	pos += uint(len(value.Action))
+	if value.Name != nil {
+		buf[pos] = byte(7)
+		pos += 1
+		switch x := encoder.Name_length; {
+		case x <= 0xfc:
+			buf[pos] = byte(x)
+			pos += 1
+		case x <= 0xffff:
+			buf[pos] = 0xfd
+			binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+			pos += 3
+		case x <= 0xffffffff:
+			buf[pos] = 0xfe
+			binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+			pos += 5
+		default:
+			buf[pos] = 0xff
+			binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+			pos += 9
+		}
+		for _, c := range value.Name {
+			pos += uint(c.EncodeInto(buf[pos:]))
+		}
+	}
+	if value.Direction != nil {
+		buf[pos] = byte(226)
+		pos += 1
+		switch x := len(*value.Direction); {
+		case x <= 0xfc:
+			buf[pos] = byte(x)
+			pos += 1
+		case x <= 0xffff:
+			buf[pos] = 0xfd
+			binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+			pos += 3
+		case x <= 0xffffffff:
+			buf[pos] = 0xfe
+			binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+			pos += 5
+		default:
+			buf[pos] = 0xff
+			binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+			pos += 9
+		}
+		copy(buf[pos:], *value.Direction)
+		pos += uint(len(*value.Direction))
+	}
+	if value.PacketType != nil {
+		buf[pos] = byte(227)
+		pos += 1
+		switch x := len(*value.PacketType); {
+		case x <= 0xfc:
+			buf[pos] = byte(x)
+			pos += 1
+		case x <= 0xffff:
+			buf[pos] = 0xfd
+			binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+			pos += 3
+		case x <= 0xffffffff:
+			buf[pos] = 0xfe
+			binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+			pos += 5
+		default:
+			buf[pos] = 0xff
+			binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+			pos += 9
+		}
+		copy(buf[pos:], *value.PacketType)
+		pos += uint(len(*value.PacketType))
+	}
+	if value.Uri != nil {
+		buf[pos] = byte(114)
+		pos += 1
+		switch x := len(*value.Uri); {
+		case x <= 0xfc:
+			buf[pos] = byte(x)
+			pos += 1
+		case x <= 0xffff:
+			buf[pos] = 0xfd
+			binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+			pos += 3
+		case x <= 0xffffffff:
+			buf[pos] = 0xfe
+			binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+			pos += 5
+		default:
+			buf[pos] = 0xff
+			binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+			pos += 9
+		}
+		copy(buf[pos:], *value.Uri)
+		pos += uint(len(*value.Uri))
+	}
+	if value.Scope != nil {
+		buf[pos] = byte(228)
+		pos += 1
+		switch x := len(*value.Scope); {
+		case x <= 0xfc:
+			buf[pos] = byte(x)
+			pos += 1
+		case x <= 0xffff:
+			buf[pos] = 0xfd
+			binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+			pos += 3
+		case x <= 0xffffffff:
+			buf[pos] = 0xfe
+			binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+			pos += 5
+		default:
+			buf[pos] = 0xff
+			binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+			pos += 9
+		}
+		copy(buf[pos:], *value.Scope)
+		pos += uint(len(*value.Scope))
+	}
+	buf[pos] = byte(229)
+	pos += 1
+	switch x := value.NHits; {
+	case x <= 0xff:
+		buf[pos] = 1
+		buf[pos+1] = byte(x)
+		pos += 2
+	case x <= 0xffff:
+		buf[pos] = 2
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+		pos += 3
+	case x <= 0xffffffff:
+		buf[pos] = 4
+		binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+		pos += 5
+	default:
+		buf[pos] = 8
+		binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+		pos += 9
+	}
+}
+
+func (encoder *FilterRuleEncoder) Encode(value *FilterRule) enc.Wire {
+
+	wire := make(enc.Wire, 1)
+	wire[0] = make([]byte, encoder.length)
+	buf := wire[0]
+	encoder.EncodeInto(value, buf)
+
+	return wire
+}
+
+func (context *FilterRuleParsingContext) Parse(reader enc.ParseReader, ignoreCritical bool) (*FilterRule, error) {
+	if reader == nil {
+		return nil, enc.ErrBufferOverflow
+	}
+
+	var handled_RuleId bool = false
+	var handled_Action bool = false
+	var handled_Name bool = false
+	var handled_Direction bool = false
+	var handled_PacketType bool = false
+	var handled_Uri bool = false
+	var handled_Scope bool = false
+	var handled_NHits bool = false
+
+	progress := -1
+	_ = progress
+
+	value := &FilterRule{}
+	var err error
+	var startPos int
+	for {
+		startPos = reader.Pos()
+		if startPos >= reader.Length() {
+			break
+		}
+		typ := enc.TLNum(0)
+		l := enc.TLNum(0)
+		typ, err = enc.ReadTLNum(reader)
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+		l, err = enc.ReadTLNum(reader)
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+
+		err = nil
+		if handled := false; true {
+			switch typ {
+			case 224:
+				if true {
+					handled = true
+					handled_RuleId = true
+					value.RuleId = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.RuleId = uint64(value.RuleId<<8) | uint64(x)
+						}
+					}
+				}
+			case 225:
+				if true {
+					handled = true
+					handled_Action = true
+					{
+						var builder strings.Builder
+						_, err = io.CopyN(&builder, reader, int64(l))
+						if err == nil {
+							value.Action = builder.String()
+						}
+					}
+				}
+			case 7:
+				if true {
+					handled = true
+					handled_Name = true
+					value.Name = make(enc.Name, l/2+1)
+					startName := reader.Pos()
+					endName := startName + int(l)
+					for j := range value.Name {
+						if reader.Pos() >= endName {
+							value.Name = value.Name[:j]
+							break
+						}
+						var err1, err3 error
+						value.Name[j].Typ, err1 = enc.ReadTLNum(reader)
+						l, err2 := enc.ReadTLNum(reader)
+						value.Name[j].Val, err3 = reader.ReadBuf(int(l))
+						if err1 != nil || err2 != nil || err3 != nil {
+							err = io.ErrUnexpectedEOF
+							break
+						}
+					}
+					if err == nil && reader.Pos() != endName {
+						err = enc.ErrBufferOverflow
+					}
+				}
+			case 226:
+				if true {
+					handled = true
+					handled_Direction = true
+					{
+						var builder strings.Builder
+						_, err = io.CopyN(&builder, reader, int64(l))
+						if err == nil {
+							tempStr := builder.String()
+							value.Direction = &tempStr
+						}
+					}
+				}
+			case 227:
+				if true {
+					handled = true
+					handled_PacketType = true
+					{
+						var builder strings.Builder
+						_, err = io.CopyN(&builder, reader, int64(l))
+						if err == nil {
+							tempStr := builder.String()
+							value.PacketType = &tempStr
+						}
+					}
+				}
+			case 114:
+				if true {
+					handled = true
+					handled_Uri = true
+					{
+						var builder strings.Builder
+						_, err = io.CopyN(&builder, reader, int64(l))
+						if err == nil {
+							tempStr := builder.String()
+							value.Uri = &tempStr
+						}
+					}
+				}
+			case 228:
+				if true {
+					handled = true
+					handled_Scope = true
+					{
+						var builder strings.Builder
+						_, err = io.CopyN(&builder, reader, int64(l))
+						if err == nil {
+							tempStr := builder.String()
+							value.Scope = &tempStr
+						}
+					}
+				}
+			case 229:
+				if true {
+					handled = true
+					handled_NHits = true
+					value.NHits = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NHits = uint64(value.NHits<<8) | uint64(x)
+						}
+					}
+				}
+			default:
+				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
+					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
+				}
+				handled = true
+				err = reader.Skip(int(l))
+			}
+			if err == nil && !handled {
+			}
+			if err != nil {
+				return nil, enc.ErrFailToParse{TypeNum: typ, Err: err}
+			}
+		}
+	}
+
+	startPos = reader.Pos()
+	err = nil
+
+	if !handled_RuleId && err == nil {
+		err = enc.ErrSkipRequired{Name: "RuleId", TypeNum: 224}
+	}
+	if !handled_Action && err == nil {
+		err = enc.ErrSkipRequired{Name: "Action", TypeNum: 225}
+	}
+	if !handled_Name && err == nil {
+		value.Name = nil
+	}
+	if !handled_Direction && err == nil {
+		value.Direction = nil
+	}
+	if !handled_PacketType && err == nil {
+		value.PacketType = nil
+	}
+	if !handled_Uri && err == nil {
+		value.Uri = nil
+	}
+	if !handled_Scope && err == nil {
+		value.Scope = nil
+	}
+	if !handled_NHits && err == nil {
+		err = enc.ErrSkipRequired{Name: "NHits", TypeNum: 229}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (value *FilterRule) Encode() enc.Wire {
+	encoder := FilterRuleEncoder{}
+	encoder.Init(value)
+	return encoder.Encode(value)
+}
+
+func (value *FilterRule) Bytes() []byte {
+	return value.Encode().Join()
+}
+
+func ParseFilterRule(reader enc.ParseReader, ignoreCritical bool) (*FilterRule, error) {
+	context := FilterRuleParsingContext{}
+	context.Init()
+	return context.Parse(reader, ignoreCritical)
+}
+
+type FilterRuleMsgEncoder struct {
+	length uint
+
+	Rules_subencoder []struct {
+		Rules_encoder FilterRuleEncoder
+	}
+}
+
+type FilterRuleMsgParsingContext struct {
+	Rules_context FilterRuleParsingContext
+}
+
+func (encoder *FilterRuleMsgEncoder) Init(value *FilterRuleMsg) {
+	{
+		Rules_l := len(value.Rules)
+		encoder.Rules_subencoder = make([]struct {
+			Rules_encoder FilterRuleEncoder
+		}, Rules_l)
+		for i := 0; i < Rules_l; i++ {
+			pseudoEncoder := &encoder.Rules_subencoder[i]
+			pseudoValue := struct {
+				Rules *FilterRule
+			}{
+				Rules: value.Rules[i],
+			}
+			{
+				encoder := pseudoEncoder
+				value := &pseudoValue
+				if value.Rules != nil {
+					encoder.Rules_encoder.Init(value.Rules)
+				}
+				_ = encoder
+				_ = value
+			}
+		}
+	}
+
+	l := uint(0)
+	if value.Rules != nil {
+		for seq_i, seq_v := range value.Rules {
+			pseudoEncoder := &encoder.Rules_subencoder[seq_i]
+			pseudoValue := struct {
+				Rules *FilterRule
+			}{
+				Rules: seq_v,
+			}
+			{
+				encoder := pseudoEncoder
+				value := &pseudoValue
+				if value.Rules != nil {
+					l += 1
+					switch x := encoder.Rules_encoder.length; {
+					case x <= 0xfc:
+						l += 1
+					case x <= 0xffff:
+						l += 3
+					case x <= 0xffffffff:
+						l += 5
+					default:
+						l += 9
+					}
+					l += encoder.Rules_encoder.length
+				}
+				_ = encoder
+				_ = value
+			}
+		}
+	}
+	encoder.length = l
+
+}
+
+func (context *FilterRuleMsgParsingContext) Init() {
+	context.Rules_context.Init()
+}
+
+func (encoder *FilterRuleMsgEncoder) EncodeInto(value *FilterRuleMsg, buf []byte) {
+
+	pos := uint(0)
+
+	if value.Rules != nil {
+		for seq_i, seq_v := range value.Rules {
+			pseudoEncoder := &encoder.Rules_subencoder[seq_i]
+			pseudoValue := struct {
+				Rules *FilterRule
+			}{
+				Rules: seq_v,
+			}
+			{
+				encoder := pseudoEncoder
+				value := &pseudoValue
+				if value.Rules != nil {
+					buf[pos] = byte(128)
+					pos += 1
+					switch x := encoder.Rules_encoder.length; {
+					case x <= 0xfc:
+						buf[pos] = byte(x)
+						pos += 1
+					case x <= 0xffff:
+						buf[pos] = 0xfd
+						binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+						pos += 3
+					case x <= 0xffffffff:
+						buf[pos] = 0xfe
+						binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+						pos += 5
+					default:
+						buf[pos] = 0xff
+						binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+						pos += 9
+					}
+					if encoder.Rules_encoder.length > 0 {
+						encoder.Rules_encoder.EncodeInto(value.Rules, buf[pos:])
+						pos += encoder.Rules_encoder.length
+					}
+				}
+				_ = encoder
+				_ = value
+			}
+		}
+	}
+}
+
+func (encoder *FilterRuleMsgEncoder) Encode(value *FilterRuleMsg) enc.Wire {
+
+	wire := make(enc.Wire, 1)
+	wire[0] = make([]byte, encoder.length)
+	buf := wire[0]
+	encoder.EncodeInto(value, buf)
+
+	return wire
+}
+
+func (context *FilterRuleMsgParsingContext) Parse(reader enc.ParseReader, ignoreCritical bool) (*FilterRuleMsg, error) {
+	if reader == nil {
+		return nil, enc.ErrBufferOverflow
+	}
+
+	var handled_Rules bool = false
+
+	progress := -1
+	_ = progress
+
+	value := &FilterRuleMsg{}
+	var err error
+	var startPos int
+	for {
+		startPos = reader.Pos()
+		if startPos >= reader.Length() {
+			break
+		}
+		typ := enc.TLNum(0)
+		l := enc.TLNum(0)
+		typ, err = enc.ReadTLNum(reader)
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+		l, err = enc.ReadTLNum(reader)
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+
+		err = nil
+		if handled := false; true {
+			switch typ {
+			case 128:
+				if true {
+					handled = true
+					handled_Rules = true
+					if value.Rules == nil {
+						value.Rules = make([]*FilterRule, 0)
+					}
+					{
+						pseudoValue := struct {
+							Rules *FilterRule
+						}{}
+						{
+							value := &pseudoValue
+							value.Rules, err = context.Rules_context.Parse(reader.Delegate(int(l)), ignoreCritical)
+							_ = value
+						}
+						value.Rules = append(value.Rules, pseudoValue.Rules)
+					}
+					progress--
+				}
+			default:
+				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
+					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
+				}
+				handled = true
+				err = reader.Skip(int(l))
+			}
+			if err == nil && !handled {
+			}
+			if err != nil {
+				return nil, enc.ErrFailToParse{TypeNum: typ, Err: err}
+			}
+		}
+	}
+
+	startPos = reader.Pos()
+	err = nil
+
+	if !handled_Rules && err == nil {
+		// sequence - skip
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (value *FilterRuleMsg) Encode() enc.Wire {
+	encoder := FilterRuleMsgEncoder{}
+	encoder.Init(value)
+	return encoder.Encode(value)
+}
+
+func (value *FilterRuleMsg) Bytes() []byte {
+	return value.Encode().Join()
+}
+
+func ParseFilterRuleMsg(reader enc.ParseReader, ignoreCritical bool) (*FilterRuleMsg, error) {
+	context := FilterRuleMsgParsingContext{}
+	context.Init()
+	return context.Parse(reader, ignoreCritical)
+}
+
+type TraceEventEncoder struct {
+	length uint
+}
+
+type TraceEventParsingContext struct {
+}
+
+func (encoder *TraceEventEncoder) Init(value *TraceEvent) {
+
+	l := uint(0)
+	l += 1
+	switch x := value.Timestamp; {
+	case x <= 0xff:
+		l += 2
+	case x <= 0xffff:
+		l += 3
+	case x <= 0xffffffff:
+		l += 5
+	default:
+		l += 9
+	}
+	l += 1
+	switch x := len(value.Event); {
+	case x <= 0xfc:
+		l += 1
+	case x <= 0xffff:
+		l += 3
+	case x <= 0xffffffff:
+		l += 5
+	default:
+		l += 9
+	}
+	l += uint(len(value.Event))
+	l += 1
+	switch x := value.Thread; {
+	case x <= 0xff:
+		l += 2
+	case x <= 0xffff:
+		l += 3
+	case x <= 0xffffffff:
+		l += 5
+	default:
+		l += 9
+	}
+	if value.FaceId != nil {
+		l += 1
+		switch x := *value.FaceId; {
+		case x <= 0xff:
+			l += 2
+		case x <= 0xffff:
+			l += 3
+		case x <= 0xffffffff:
+			l += 5
+		default:
+			l += 9
+		}
+	}
+	if value.Detail != nil {
+		l += 1
+		switch x := len(*value.Detail); {
+		case x <= 0xfc:
+			l += 1
+		case x <= 0xffff:
+			l += 3
+		case x <= 0xffffffff:
+			l += 5
+		default:
+			l += 9
+		}
+		l += uint(len(*value.Detail))
+	}
+	encoder.length = l
+
+}
+
+func (context *TraceEventParsingContext) Init() {
+
+}
+
+func (encoder *TraceEventEncoder) EncodeInto(value *TraceEvent, buf []byte) {
+
+	pos := uint(0)
+
+	buf[pos] = byte(232)
+	pos += 1
+	switch x := value.Timestamp; {
+	case x <= 0xff:
+		buf[pos] = 1
+		buf[pos+1] = byte(x)
+		pos += 2
+	case x <= 0xffff:
+		buf[pos] = 2
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+		pos += 3
+	case x <= 0xffffffff:
+		buf[pos] = 4
+		binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+		pos += 5
+	default:
+		buf[pos] = 8
+		binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+		pos += 9
+	}
+	buf[pos] = byte(233)
+	pos += 1
+	switch x := len(value.Event); {
+	case x <= 0xfc:
+		buf[pos] = byte(x)
+		pos += 1
+	case x <= 0xffff:
+		buf[pos] = 0xfd
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+		pos += 3
+	case x <= 0xffffffff:
+		buf[pos] = 0xfe
+		binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+		pos += 5
+	default:
+		buf[pos] = 0xff
+		binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+		pos += 9
+	}
+	copy(buf[pos:], value.Event)
+	pos += uint(len(value.Event))
+	buf[pos] = byte(234)
+	pos += 1
+	switch x := value.Thread; {
+	case x <= 0xff:
+		buf[pos] = 1
+		buf[pos+1] = byte(x)
+		pos += 2
+	case x <= 0xffff:
+		buf[pos] = 2
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+		pos += 3
+	case x <= 0xffffffff:
+		buf[pos] = 4
+		binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+		pos += 5
+	default:
+		buf[pos] = 8
+		binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+		pos += 9
+	}
+	if value.FaceId != nil {
+		buf[pos] = byte(105)
+		pos += 1
+		switch x := *value.FaceId; {
+		case x <= 0xff:
+			buf[pos] = 1
+			buf[pos+1] = byte(x)
+			pos += 2
+		case x <= 0xffff:
+			buf[pos] = 2
+			binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+			pos += 3
+		case x <= 0xffffffff:
+			buf[pos] = 4
+			binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+			pos += 5
+		default:
+			buf[pos] = 8
+			binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+			pos += 9
+		}
+	}
+	if value.Detail != nil {
+		buf[pos] = byte(235)
+		pos += 1
+		switch x := len(*value.Detail); {
+		case x <= 0xfc:
+			buf[pos] = byte(x)
+			pos += 1
+		case x <= 0xffff:
+			buf[pos] = 0xfd
+			binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+			pos += 3
+		case x <= 0xffffffff:
+			buf[pos] = 0xfe
+			binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+			pos += 5
+		default:
+			buf[pos] = 0xff
+			binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+			pos += 9
+		}
+		copy(buf[pos:], *value.Detail)
+		pos += uint(len(*value.Detail))
+	}
+}
+
+func (encoder *TraceEventEncoder) Encode(value *TraceEvent) enc.Wire {
+
+	wire := make(enc.Wire, 1)
+	wire[0] = make([]byte, encoder.length)
+	buf := wire[0]
+	encoder.EncodeInto(value, buf)
+
+	return wire
+}
+
+func (context *TraceEventParsingContext) Parse(reader enc.ParseReader, ignoreCritical bool) (*TraceEvent, error) {
+	if reader == nil {
+		return nil, enc.ErrBufferOverflow
+	}
+
+	var handled_Timestamp bool = false
+	var handled_Event bool = false
+	var handled_Thread bool = false
+	var handled_FaceId bool = false
+	var handled_Detail bool = false
+
+	progress := -1
+	_ = progress
+
+	value := &TraceEvent{}
+	var err error
+	var startPos int
+	for {
+		startPos = reader.Pos()
+		if startPos >= reader.Length() {
+			break
+		}
+		typ := enc.TLNum(0)
+		l := enc.TLNum(0)
+		typ, err = enc.ReadTLNum(reader)
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+		l, err = enc.ReadTLNum(reader)
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+
+		err = nil
+		if handled := false; true {
+			switch typ {
+			case 232:
+				if true {
+					handled = true
+					handled_Timestamp = true
+					value.Timestamp = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.Timestamp = uint64(value.Timestamp<<8) | uint64(x)
+						}
+					}
+				}
+			case 233:
+				if true {
+					handled = true
+					handled_Event = true
+					{
+						var builder strings.Builder
+						_, err = io.CopyN(&builder, reader, int64(l))
+						if err == nil {
+							value.Event = builder.String()
+						}
+					}
+				}
+			case 234:
+				if true {
+					handled = true
+					handled_Thread = true
+					value.Thread = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.Thread = uint64(value.Thread<<8) | uint64(x)
+						}
+					}
+				}
+			case 105:
+				if true {
+					handled = true
+					handled_FaceId = true
+					{
+						tempVal := uint64(0)
+						tempVal = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								tempVal = uint64(tempVal<<8) | uint64(x)
+							}
+						}
+						value.FaceId = &tempVal
+					}
+				}
+			case 235:
+				if true {
+					handled = true
+					handled_Detail = true
+					{
+						var builder strings.Builder
+						_, err = io.CopyN(&builder, reader, int64(l))
+						if err == nil {
+							tempStr := builder.String()
+							value.Detail = &tempStr
+						}
+					}
+				}
+			default:
+				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
+					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
+				}
+				handled = true
+				err = reader.Skip(int(l))
+			}
+			if err == nil && !handled {
+			}
+			if err != nil {
+				return nil, enc.ErrFailToParse{TypeNum: typ, Err: err}
+			}
+		}
+	}
+
+	startPos = reader.Pos()
+	err = nil
+
+	if !handled_Timestamp && err == nil {
+		err = enc.ErrSkipRequired{Name: "Timestamp", TypeNum: 232}
+	}
+	if !handled_Event && err == nil {
+		err = enc.ErrSkipRequired{Name: "Event", TypeNum: 233}
+	}
+	if !handled_Thread && err == nil {
+		err = enc.ErrSkipRequired{Name: "Thread", TypeNum: 234}
+	}
+	if !handled_FaceId && err == nil {
+		value.FaceId = nil
+	}
+	if !handled_Detail && err == nil {
+		value.Detail = nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (value *TraceEvent) Encode() enc.Wire {
+	encoder := TraceEventEncoder{}
+	encoder.Init(value)
+	return encoder.Encode(value)
+}
+
+func (value *TraceEvent) Bytes() []byte {
+	return value.Encode().Join()
+}
+
+func ParseTraceEvent(reader enc.ParseReader, ignoreCritical bool) (*TraceEvent, error) {
+	context := TraceEventParsingContext{}
+	context.Init()
+	return context.Parse(reader, ignoreCritical)
+}
+
+type TraceEncoder struct {
+	length uint
+
+	Name_length uint
+
+	Events_subencoder []struct {
+		Events_encoder TraceEventEncoder
+	}
+}
+
+type TraceParsingContext struct {
+	Events_context TraceEventParsingContext
+}
+
+func (encoder *TraceEncoder) Init(value *Trace) {
+
+	if value.Name != nil {
+		encoder.Name_length = 0
+		for _, c := range value.Name {
+			encoder.Name_length += uint(c.EncodingLength())
+		}
+	}
+
+	{
+		Events_l := len(value.Events)
+		encoder.Events_subencoder = make([]struct {
+			Events_encoder TraceEventEncoder
+		}, Events_l)
+		for i := 0; i < Events_l; i++ {
+			pseudoEncoder := &encoder.Events_subencoder[i]
+			pseudoValue := struct {
+				Events *TraceEvent
+			}{
+				Events: value.Events[i],
+			}
+			{
+				encoder := pseudoEncoder
+				value := &pseudoValue
+				if value.Events != nil {
+					encoder.Events_encoder.Init(value.Events)
+				}
+				_ = encoder
+				_ = value
+			}
+		}
+	}
+
+	l := uint(0)
+	l += 1
+	switch x := value.TraceId; {
+	case x <= 0xff:
+		l += 2
+	case x <= 0xffff:
+		l += 3
+	case x <= 0xffffffff:
+		l += 5
+	default:
+		l += 9
+	}
+	if value.Name != nil {
+		l += 1
+		switch x := encoder.Name_length; {
+		case x <= 0xfc:
+			l += 1
+		case x <= 0xffff:
+			l += 3
+		case x <= 0xffffffff:
+			l += 5
+		default:
+			l += 9
+		}
+		l += encoder.Name_length
+	}
+	l += 1
+	switch x := len(value.PacketType); {
+	case x <= 0xfc:
+		l += 1
+	case x <= 0xffff:
+		l += 3
+	case x <= 0xffffffff:
+		l += 5
+	default:
+		l += 9
+	}
+	l += uint(len(value.PacketType))
+	l += 1
+	switch x := value.FaceId; {
+	case x <= 0xff:
+		l += 2
+	case x <= 0xffff:
+		l += 3
+	case x <= 0xffffffff:
+		l += 5
+	default:
+		l += 9
+	}
+	if value.Events != nil {
+		for seq_i, seq_v := range value.Events {
+			pseudoEncoder := &encoder.Events_subencoder[seq_i]
+			pseudoValue := struct {
+				Events *TraceEvent
+			}{
+				Events: seq_v,
+			}
+			{
+				encoder := pseudoEncoder
+				value := &pseudoValue
+				if value.Events != nil {
+					l += 1
+					switch x := encoder.Events_encoder.length; {
+					case x <= 0xfc:
+						l += 1
+					case x <= 0xffff:
+						l += 3
+					case x <= 0xffffffff:
+						l += 5
+					default:
+						l += 9
+					}
+					l += encoder.Events_encoder.length
+				}
+				_ = encoder
+				_ = value
+			}
+		}
+	}
+	encoder.length = l
+
+}
+
+func (context *TraceParsingContext) Init() {
+
+	context.Events_context.Init()
+}
+
+func (encoder *TraceEncoder) EncodeInto(value *Trace, buf []byte) {
+
+	pos := uint(0)
+
+	buf[pos] = byte(230)
+	pos += 1
+	switch x := value.TraceId; {
+	case x <= 0xff:
+		buf[pos] = 1
+		buf[pos+1] = byte(x)
+		pos += 2
+	case x <= 0xffff:
+		buf[pos] = 2
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+		pos += 3
+	case x <= 0xffffffff:
+		buf[pos] = 4
+		binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+		pos += 5
+	default:
+		buf[pos] = 8
+		binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+		pos += 9
+	}
+	if value.Name != nil {
+		buf[pos] = byte(7)
+		pos += 1
+		switch x := encoder.Name_length; {
+		case x <= 0xfc:
+			buf[pos] = byte(x)
+			pos += 1
+		case x <= 0xffff:
+			buf[pos] = 0xfd
+			binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+			pos += 3
+		case x <= 0xffffffff:
+			buf[pos] = 0xfe
+			binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+			pos += 5
+		default:
+			buf[pos] = 0xff
+			binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+			pos += 9
+		}
+		for _, c := range value.Name {
+			pos += uint(c.EncodeInto(buf[pos:]))
+		}
+	}
+	buf[pos] = byte(227)
+	pos += 1
+	switch x := len(value.PacketType); {
+	case x <= 0xfc:
+		buf[pos] = byte(x)
+		pos += 1
+	case x <= 0xffff:
+		buf[pos] = 0xfd
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+		pos += 3
+	case x <= 0xffffffff:
+		buf[pos] = 0xfe
+		binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+		pos += 5
+	default:
+		buf[pos] = 0xff
+		binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+		pos += 9
+	}
+	copy(buf[pos:], value.PacketType)
+	pos += uint(len(value.PacketType))
+	buf[pos] = byte(105)
+	pos += 1
+	switch x := value.FaceId; {
+	case x <= 0xff:
+		buf[pos] = 1
+		buf[pos+1] = byte(x)
+		pos += 2
+	case x <= 0xffff:
+		buf[pos] = 2
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+		pos += 3
+	case x <= 0xffffffff:
+		buf[pos] = 4
+		binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+		pos += 5
+	default:
+		buf[pos] = 8
+		binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+		pos += 9
+	}
+	if value.Events != nil {
+		for seq_i, seq_v := range value.Events {
+			pseudoEncoder := &encoder.Events_subencoder[seq_i]
+			pseudoValue := struct {
+				Events *TraceEvent
+			}{
+				Events: seq_v,
+			}
+			{
+				encoder := pseudoEncoder
+				value := &pseudoValue
+				if value.Events != nil {
+					buf[pos] = byte(231)
+					pos += 1
+					switch x := encoder.Events_encoder.length; {
+					case x <= 0xfc:
+						buf[pos] = byte(x)
+						pos += 1
+					case x <= 0xffff:
+						buf[pos] = 0xfd
+						binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+						pos += 3
+					case x <= 0xffffffff:
+						buf[pos] = 0xfe
+						binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+						pos += 5
+					default:
+						buf[pos] = 0xff
+						binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+						pos += 9
+					}
+					if encoder.Events_encoder.length > 0 {
+						encoder.Events_encoder.EncodeInto(value.Events, buf[pos:])
+						pos += encoder.Events_encoder.length
+					}
+				}
+				_ = encoder
+				_ = value
+			}
+		}
+	}
+}
+
+func (encoder *TraceEncoder) Encode(value *Trace) enc.Wire {
+
+	wire := make(enc.Wire, 1)
+	wire[0] = make([]byte, encoder.length)
+	buf := wire[0]
+	encoder.EncodeInto(value, buf)
+
+	return wire
+}
+
+func (context *TraceParsingContext) Parse(reader enc.ParseReader, ignoreCritical bool) (*Trace, error) {
+	if reader == nil {
+		return nil, enc.ErrBufferOverflow
+	}
+
+	var handled_TraceId bool = false
+	var handled_Name bool = false
+	var handled_PacketType bool = false
+	var handled_FaceId bool = false
+	var handled_Events bool = false
+
+	progress := -1
+	_ = progress
+
+	value := &Trace{}
+	var err error
+	var startPos int
+	for {
+		startPos = reader.Pos()
+		if startPos >= reader.Length() {
+			break
+		}
+		typ := enc.TLNum(0)
+		l := enc.TLNum(0)
+		typ, err = enc.ReadTLNum(reader)
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+		l, err = enc.ReadTLNum(reader)
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+
+		err = nil
+		if handled := false; true {
+			switch typ {
+			case 230:
+				if true {
+					handled = true
+					handled_TraceId = true
+					value.TraceId = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.TraceId = uint64(value.TraceId<<8) | uint64(x)
+						}
+					}
+				}
+			case 7:
+				if true {
+					handled = true
+					handled_Name = true
+					value.Name = make(enc.Name, l/2+1)
+					startName := reader.Pos()
+					endName := startName + int(l)
+					for j := range value.Name {
+						if reader.Pos() >= endName {
+							value.Name = value.Name[:j]
+							break
+						}
+						var err1, err3 error
+						value.Name[j].Typ, err1 = enc.ReadTLNum(reader)
+						l, err2 := enc.ReadTLNum(reader)
+						value.Name[j].Val, err3 = reader.ReadBuf(int(l))
+						if err1 != nil || err2 != nil || err3 != nil {
+							err = io.ErrUnexpectedEOF
+							break
+						}
+					}
+					if err == nil && reader.Pos() != endName {
+						err = enc.ErrBufferOverflow
+					}
+				}
+			case 227:
+				if true {
+					handled = true
+					handled_PacketType = true
+					{
+						var builder strings.Builder
+						_, err = io.CopyN(&builder, reader, int64(l))
+						if err == nil {
+							value.PacketType = builder.String()
+						}
+					}
+				}
+			case 105:
+				if true {
+					handled = true
+					handled_FaceId = true
+					value.FaceId = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.FaceId = uint64(value.FaceId<<8) | uint64(x)
+						}
+					}
+				}
+			case 231:
+				if true {
+					handled = true
+					handled_Events = true
+					if value.Events == nil {
+						value.Events = make([]*TraceEvent, 0)
+					}
+					{
+						pseudoValue := struct {
+							Events *TraceEvent
+						}{}
+						{
+							value := &pseudoValue
+							value.Events, err = context.Events_context.Parse(reader.Delegate(int(l)), ignoreCritical)
+							_ = value
+						}
+						value.Events = append(value.Events, pseudoValue.Events)
+					}
+					progress--
+				}
+			default:
+				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
+					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
+				}
+				handled = true
+				err = reader.Skip(int(l))
+			}
+			if err == nil && !handled {
+			}
+			if err != nil {
+				return nil, enc.ErrFailToParse{TypeNum: typ, Err: err}
+			}
+		}
+	}
+
+	startPos = reader.Pos()
+	err = nil
+
+	if !handled_TraceId && err == nil {
+		err = enc.ErrSkipRequired{Name: "TraceId", TypeNum: 230}
+	}
+	if !handled_Name && err == nil {
+		value.Name = nil
+	}
+	if !handled_PacketType && err == nil {
+		err = enc.ErrSkipRequired{Name: "PacketType", TypeNum: 227}
+	}
+	if !handled_FaceId && err == nil {
+		err = enc.ErrSkipRequired{Name: "FaceId", TypeNum: 105}
+	}
+	if !handled_Events && err == nil {
+		// sequence - skip
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (value *Trace) Encode() enc.Wire {
+	encoder := TraceEncoder{}
+	encoder.Init(value)
+	return encoder.Encode(value)
+}
+
+func (value *Trace) Bytes() []byte {
+	return value.Encode().Join()
+}
+
+func ParseTrace(reader enc.ParseReader, ignoreCritical bool) (*Trace, error) {
+	context := TraceParsingContext{}
+	context.Init()
+	return context.Parse(reader, ignoreCritical)
+}
+
+type TraceMsgEncoder struct {
+	length uint
+
+	Traces_subencoder []struct {
+		Traces_encoder TraceEncoder
+	}
+}
+
+type TraceMsgParsingContext struct {
+	Traces_context TraceParsingContext
+}
+
+func (encoder *TraceMsgEncoder) Init(value *TraceMsg) {
+	{
+		Traces_l := len(value.Traces)
+		encoder.Traces_subencoder = make([]struct {
+			Traces_encoder TraceEncoder
+		}, Traces_l)
+		for i := 0; i < Traces_l; i++ {
+			pseudoEncoder := &encoder.Traces_subencoder[i]
+			pseudoValue := struct {
+				Traces *Trace
+			}{
+				Traces: value.Traces[i],
+			}
+			{
+				encoder := pseudoEncoder
+				value := &pseudoValue
+				if value.Traces != nil {
+					encoder.Traces_encoder.Init(value.Traces)
+				}
+				_ = encoder
+				_ = value
+			}
+		}
+	}
+
+	l := uint(0)
+	if value.Traces != nil {
+		for seq_i, seq_v := range value.Traces {
+			pseudoEncoder := &encoder.Traces_subencoder[seq_i]
+			pseudoValue := struct {
+				Traces *Trace
+			}{
+				Traces: seq_v,
+			}
+			{
+				encoder := pseudoEncoder
+				value := &pseudoValue
+				if value.Traces != nil {
+					l += 1
+					switch x := encoder.Traces_encoder.length; {
+					case x <= 0xfc:
+						l += 1
+					case x <= 0xffff:
+						l += 3
+					case x <= 0xffffffff:
+						l += 5
+					default:
+						l += 9
+					}
+					l += encoder.Traces_encoder.length
+				}
+				_ = encoder
+				_ = value
+			}
+		}
+	}
+	encoder.length = l
+
+}
+
+func (context *TraceMsgParsingContext) Init() {
+	context.Traces_context.Init()
+}
+
+func (encoder *TraceMsgEncoder) EncodeInto(value *TraceMsg, buf []byte) {
+
+	pos := uint(0)
+
+	if value.Traces != nil {
+		for seq_i, seq_v := range value.Traces {
+			pseudoEncoder := &encoder.Traces_subencoder[seq_i]
+			pseudoValue := struct {
+				Traces *Trace
+			}{
+				Traces: seq_v,
+			}
+			{
+				encoder := pseudoEncoder
+				value := &pseudoValue
+				if value.Traces != nil {
+					buf[pos] = byte(128)
+					pos += 1
+					switch x := encoder.Traces_encoder.length; {
+					case x <= 0xfc:
+						buf[pos] = byte(x)
+						pos += 1
+					case x <= 0xffff:
+						buf[pos] = 0xfd
+						binary.BigEndian.PutUint16(buf[pos+1:], uint16(x))
+						pos += 3
+					case x <= 0xffffffff:
+						buf[pos] = 0xfe
+						binary.BigEndian.PutUint32(buf[pos+1:], uint32(x))
+						pos += 5
+					default:
+						buf[pos] = 0xff
+						binary.BigEndian.PutUint64(buf[pos+1:], uint64(x))
+						pos += 9
+					}
+					if encoder.Traces_encoder.length > 0 {
+						encoder.Traces_encoder.EncodeInto(value.Traces, buf[pos:])
+						pos += encoder.Traces_encoder.length
+					}
+				}
+				_ = encoder
+				_ = value
+			}
+		}
+	}
+}
+
+func (encoder *TraceMsgEncoder) Encode(value *TraceMsg) enc.Wire {
+
+	wire := make(enc.Wire, 1)
+	wire[0] = make([]byte, encoder.length)
+	buf := wire[0]
+	encoder.EncodeInto(value, buf)
+
+	return wire
+}
+
+func (context *TraceMsgParsingContext) Parse(reader enc.ParseReader, ignoreCritical bool) (*TraceMsg, error) {
+	if reader == nil {
+		return nil, enc.ErrBufferOverflow
+	}
+
+	var handled_Traces bool = false
+
+	progress := -1
+	_ = progress
+
+	value := &TraceMsg{}
+	var err error
+	var startPos int
+	for {
+		startPos = reader.Pos()
+		if startPos >= reader.Length() {
+			break
+		}
+		typ := enc.TLNum(0)
+		l := enc.TLNum(0)
+		typ, err = enc.ReadTLNum(reader)
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+		l, err = enc.ReadTLNum(reader)
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+
+		err = nil
+		if handled := false; true {
+			switch typ {
+			case 128:
+				if true {
+					handled = true
+					handled_Traces = true
+					if value.Traces == nil {
+						value.Traces = make([]*Trace, 0)
+					}
+					{
+						pseudoValue := struct {
+							Traces *Trace
+						}{}
+						{
+							value := &pseudoValue
+							value.Traces, err = context.Traces_context.Parse(reader.Delegate(int(l)), ignoreCritical)
+							_ = value
+						}
+						value.Traces = append(value.Traces, pseudoValue.Traces)
+					}
+					progress--
+				}
+			default:
+				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
+					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
+				}
+				handled = true
+				err = reader.Skip(int(l))
+			}
+			if err == nil && !handled {
+			}
+			if err != nil {
+				return nil, enc.ErrFailToParse{TypeNum: typ, Err: err}
+			}
+		}
+	}
+
+	startPos = reader.Pos()
+	err = nil
+
+	if !handled_Traces && err == nil {
+		// sequence - skip
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (value *TraceMsg) Encode() enc.Wire {
+	encoder := TraceMsgEncoder{}
+	encoder.Init(value)
+	return encoder.Encode(value)
+}
+
+func (value *TraceMsg) Bytes() []byte {
+	return value.Encode().Join()
+}
+
+func ParseTraceMsg(reader enc.ParseReader, ignoreCritical bool) (*TraceMsg, error) {
+	context := TraceMsgParsingContext{}
+	context.Init()
+	return context.Parse(reader, ignoreCritical)
+}
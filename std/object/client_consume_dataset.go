@@ -0,0 +1,79 @@
+package object
+
+import (
+	"fmt"
+	"time"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/log"
+	"github.com/named-data/ndnd/std/ndn"
+	"github.com/named-data/ndnd/std/utils"
+)
+
+// callback for the status dataset consume API
+type ConsumeDatasetCallback func(content enc.Wire, err error)
+
+// ConsumeStatusDataset fetches a status dataset of the NFD management protocol,
+// such as /localhost/nfd/faces/list, and reassembles its segments.
+// Status datasets have no metadata, so the latest version is discovered with
+// the first segment and the other segments are fetched from the same version.
+func (c *Client) ConsumeStatusDataset(name enc.Name, callback ConsumeDatasetCallback) {
+	log.Debugf("consume: fetching status dataset %s", name)
+	args := ExpressRArgs{
+		Name: name,
+		Config: &ndn.InterestConfig{
+			CanBePrefix: true,
+			MustBeFresh: true,
+			Lifetime:    utils.IdPtr(time.Millisecond * 1000),
+		},
+		Retries: 3,
+	}
+	c.ExpressR(args, func(args ndn.ExpressCallbackArgs) {
+		if args.Result == ndn.InterestResultError {
+			callback(nil, fmt.Errorf("consume: fetch failed with error %v", args.Error))
+			return
+		}
+
+		if args.Result != ndn.InterestResultData {
+			callback(nil, fmt.Errorf("consume: fetch failed with result %d", args.Result))
+			return
+		}
+
+		// segments are named <name>/<version>/<segment>, and any of them
+		// can be received when a previous fetch is still cached
+		dataName := args.Data.Name()
+		if len(dataName) < len(name)+2 ||
+			dataName[len(dataName)-2].Typ != enc.TypeVersionNameComponent ||
+			dataName[len(dataName)-1].Typ != enc.TypeSegmentNameComponent {
+			callback(nil, fmt.Errorf("consume: invalid status dataset name %s", dataName))
+			return
+		}
+
+		fbId := args.Data.FinalBlockID()
+		if fbId == nil {
+			callback(nil, fmt.Errorf("consume: no FinalBlockId in status dataset"))
+			return
+		}
+
+		// single segment, which can be empty
+		if fbId.Equal(dataName[len(dataName)-1]) && dataName[len(dataName)-1].NumberVal() == 0 {
+			callback(enc.Wire{args.Data.Content().Join()}, nil)
+			return
+		}
+
+		// fetch all segments of the discovered version
+		content := make(enc.Wire, 0)
+		c.Consume(dataName[:len(dataName)-1].Clone(), func(state *ConsumeState) bool {
+			if state.Error() != nil {
+				callback(nil, state.Error())
+				return false
+			}
+
+			content = append(content, state.Content())
+			if state.IsComplete() {
+				callback(content, nil)
+			}
+			return true
+		})
+	})
+}
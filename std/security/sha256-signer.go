@@ -2,6 +2,7 @@ package security
 
 import (
 	"crypto/sha256"
+	"sync/atomic"
 
 	enc "github.com/named-data/ndnd/std/encoding"
 	"github.com/named-data/ndnd/std/ndn"
@@ -41,17 +42,18 @@ func NewSha256Signer() ndn.Signer {
 // sha256Signer is an Interest signer that uses DigestSha256.
 type sha256IntSigner struct {
 	timer ndn.Timer
-	seq   uint64
+	// signers are shared by the goroutines of an engine
+	seq atomic.Uint64
 }
 
 func (s *sha256IntSigner) SigInfo() (*ndn.SigConfig, error) {
-	s.seq++
+	seq := s.seq.Add(1)
 	return &ndn.SigConfig{
 		Type:    ndn.SignatureDigestSha256,
 		KeyName: nil,
 		Nonce:   s.timer.Nonce(),
 		SigTime: utils.IdPtr(s.timer.Now()),
-		SeqNum:  utils.IdPtr(s.seq),
+		SeqNum:  utils.IdPtr(seq),
 	}, nil
 }
 
@@ -67,6 +69,5 @@ func (*sha256IntSigner) ComputeSigValue(covered enc.Wire) ([]byte, error) {
 func NewSha256IntSigner(timer ndn.Timer) ndn.Signer {
 	return &sha256IntSigner{
 		timer: timer,
-		seq:   0,
 	}
 }
@@ -0,0 +1,87 @@
+package nfdc
+
+import (
+	"fmt"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/utils"
+)
+
+func (t *Tool) csInfo(args []string) {
+	parseArgs(args, nil)
+
+	data := t.fetchStatusDataset("cs", "info")
+	status, err := mgmt.ParseCsInfoMsg(enc.NewWireReader(data), true)
+	if err != nil || status.CsInfo == nil {
+		fatalf("Error parsing CS information: %+v", err)
+	}
+
+	info := status.CsInfo
+	fmt.Println("CS information:")
+	printAligned([]keyValue{
+		{"capacity", info.Capacity},
+		{"admit", onOff(info.Flags&mgmt.CsEnableAdmit != 0)},
+		{"serve", onOff(info.Flags&mgmt.CsEnableServe != 0)},
+		{"nEntries", info.NCsEntries},
+		{"nHits", info.NHits},
+		{"nMisses", info.NMisses},
+	})
+}
+
+func (t *Tool) csConfig(args []string) {
+	opts := parseArgs(args, nil)
+
+	ctrl := &mgmt.ControlArgs{Capacity: optUint(opts, "capacity")}
+	var flags, mask uint64
+	for _, flag := range []struct {
+		key string
+		bit uint64
+	}{
+		{"admit", mgmt.CsEnableAdmit},
+		{"serve", mgmt.CsEnableServe},
+	} {
+		if val, ok := opts[flag.key]; ok {
+			mask |= flag.bit
+			if parseOnOff(flag.key, val) {
+				flags |= flag.bit
+			}
+		}
+	}
+	if mask != 0 {
+		ctrl.Flags, ctrl.Mask = utils.IdPtr(flags), utils.IdPtr(mask)
+	}
+
+	params := t.exec("cs", "config", ctrl)
+	fmt.Print("cs-config-updated")
+	if params.Capacity != nil {
+		fmt.Printf(" capacity=%d", *params.Capacity)
+	}
+	if params.Flags != nil {
+		fmt.Printf(" admit=%s serve=%s", onOff(*params.Flags&mgmt.CsEnableAdmit != 0),
+			onOff(*params.Flags&mgmt.CsEnableServe != 0))
+	}
+	fmt.Println()
+}
+
+func (t *Tool) csErase(args []string) {
+	opts := parseArgs(args, []string{"prefix"})
+
+	ctrl := &mgmt.ControlArgs{
+		Name:  parseName(require(opts, "prefix")),
+		Count: optUint(opts, "count"),
+	}
+	params := t.exec("cs", "erase", ctrl)
+	fmt.Printf("Erased %d Data packets matching %s\n", optValue(params.Count), ctrl.Name)
+	if params.Capacity != nil && ctrl.Count == nil {
+		// The forwarder erases a limited number of entries per command
+		fmt.Println("More Data packets may remain; run the command again to erase them")
+	}
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
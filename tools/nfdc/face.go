@@ -0,0 +1,254 @@
+package nfdc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/utils"
+)
+
+func (t *Tool) faceList(args []string) {
+	parseArgs(args, nil)
+	for _, face := range t.faces() {
+		fmt.Println(formatFace(face))
+	}
+}
+
+func (t *Tool) faceCreate(args []string) {
+	opts := parseArgs(args, []string{"remote"})
+
+	ctrl := &mgmt.ControlArgs{
+		Uri:                        utils.IdPtr(require(opts, "remote")),
+		Mtu:                        optUint(opts, "mtu"),
+		BaseCongestionMarkInterval: optUint(opts, "congestion-marking-interval"),
+		DefaultCongestionThreshold: optUint(opts, "default-congestion-threshold"),
+	}
+	if local, ok := opts["local"]; ok {
+		ctrl.LocalUri = utils.IdPtr(local)
+	}
+	if pers, ok := opts["persistency"]; ok {
+		ctrl.FacePersistency = utils.IdPtr(parsePersistency(pers))
+	}
+	if ctrl.BaseCongestionMarkInterval != nil {
+		*ctrl.BaseCongestionMarkInterval *= 1000000 // milliseconds to nanoseconds
+	}
+
+	// Face flags
+	var flags, mask uint64
+	for _, flag := range []struct {
+		key string
+		bit uint64
+	}{
+		{"reliability", mgmt.FaceFlagLpReliabilityEnabled},
+		{"congestion-marking", mgmt.FaceFlagCongestionMarkingEnabled},
+	} {
+		if val, ok := opts[flag.key]; ok {
+			mask |= flag.bit
+			if parseOnOff(flag.key, val) {
+				flags |= flag.bit
+			}
+		}
+	}
+	if mask != 0 {
+		ctrl.Flags, ctrl.Mask = utils.IdPtr(flags), utils.IdPtr(mask)
+	}
+
+	t.createFace(ctrl)
+}
+
+// createFace creates a face, or finds the existing face with the same remote URI,
+// and returns its parameters.
+func (t *Tool) createFace(ctrl *mgmt.ControlArgs) *mgmt.ControlArgs {
+	resp, err := t.engine.ExecMgmtCmdWithResponse("faces", "create", ctrl)
+	if resp != nil && resp.Val != nil && resp.Val.StatusCode == 409 && resp.Val.Params != nil {
+		fmt.Println("face-exists", formatFaceParams(resp.Val.Params))
+		return resp.Val.Params
+	}
+	if err != nil {
+		fatalf("Error faces create: %+v", err)
+	}
+	if resp == nil || resp.Val == nil || resp.Val.Params == nil || resp.Val.Params.FaceId == nil {
+		fatalf("Error faces create: response without parameters")
+	}
+	fmt.Println("face-created", formatFaceParams(resp.Val.Params))
+	return resp.Val.Params
+}
+
+func (t *Tool) faceDestroy(args []string) {
+	opts := parseArgs(args, []string{"face"})
+	face := t.findFace(require(opts, "face"))
+
+	t.exec("faces", "destroy", &mgmt.ControlArgs{FaceId: utils.IdPtr(face.FaceId)})
+	fmt.Printf("face-destroyed id=%d local=%s remote=%s persistency=%s\n",
+		face.FaceId, face.LocalUri, face.Uri, persistencyString(face.FacePersistency))
+}
+
+// faces fetches the face dataset.
+func (t *Tool) faces() []*mgmt.FaceStatus {
+	data := t.fetchStatusDataset("faces", "list")
+	status, err := mgmt.ParseFaceStatusMsg(enc.NewWireReader(data), true)
+	if err != nil {
+		fatalf("Error parsing face status: %+v", err)
+	}
+	return status.Vals
+}
+
+// lookupFace finds a face by FaceId or remote URI, or returns nil if there is none.
+func (t *Tool) lookupFace(face string) *mgmt.FaceStatus {
+	faceID, err := strconv.ParseUint(face, 10, 64)
+	for _, status := range t.faces() {
+		if (err == nil && status.FaceId == faceID) || (err != nil && status.Uri == face) {
+			return status
+		}
+	}
+	return nil
+}
+
+// findFace finds a face by FaceId or remote URI.
+func (t *Tool) findFace(face string) *mgmt.FaceStatus {
+	status := t.lookupFace(face)
+	if status == nil {
+		fatalf("Face not found: %s", face)
+	}
+	return status
+}
+
+// faceID resolves a face given by FaceId or remote URI.
+func (t *Tool) faceID(face string) uint64 {
+	if faceID, err := strconv.ParseUint(face, 10, 64); err == nil {
+		return faceID
+	}
+	return t.findFace(face).FaceId
+}
+
+func formatFace(face *mgmt.FaceStatus) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "faceid=%d remote=%s local=%s", face.FaceId, face.Uri, face.LocalUri)
+	if face.ExpirationPeriod != nil {
+		fmt.Fprintf(&b, " expires=%ds", *face.ExpirationPeriod/1000)
+	}
+	if face.BaseCongestionMarkInterval != nil || face.DefaultCongestionThreshold != nil {
+		congestion := []string{}
+		if face.BaseCongestionMarkInterval != nil {
+			congestion = append(congestion, fmt.Sprintf("base-marking-interval=%dms", *face.BaseCongestionMarkInterval/1000000))
+		}
+		if face.DefaultCongestionThreshold != nil {
+			congestion = append(congestion, fmt.Sprintf("default-threshold=%dB", *face.DefaultCongestionThreshold))
+		}
+		fmt.Fprintf(&b, " congestion={%s}", strings.Join(congestion, " "))
+	}
+	if face.Mtu != nil {
+		fmt.Fprintf(&b, " mtu=%dB", *face.Mtu)
+	}
+	fmt.Fprintf(&b, " counters={in={%di %dd %dn %dB} out={%di %dd %dn %dB}}",
+		face.NInInterests, face.NInData, face.NInNacks, face.NInBytes,
+		face.NOutInterests, face.NOutData, face.NOutNacks, face.NOutBytes)
+
+	flags := []string{
+		scopeString(face.FaceScope),
+		persistencyString(face.FacePersistency),
+		linkTypeString(face.LinkType),
+	}
+	flags = append(flags, faceFlagStrings(face.Flags)...)
+	fmt.Fprintf(&b, " flags={%s}", strings.Join(flags, " "))
+	return b.String()
+}
+
+func formatFaceParams(params *mgmt.ControlArgs) string {
+	var b strings.Builder
+	if params.FaceId != nil {
+		fmt.Fprintf(&b, "id=%d", *params.FaceId)
+	}
+	if params.LocalUri != nil {
+		fmt.Fprintf(&b, " local=%s", *params.LocalUri)
+	}
+	if params.Uri != nil {
+		fmt.Fprintf(&b, " remote=%s", *params.Uri)
+	}
+	if params.FacePersistency != nil {
+		fmt.Fprintf(&b, " persistency=%s", persistencyString(*params.FacePersistency))
+	}
+	if params.Flags != nil {
+		for _, flag := range faceFlagStrings(*params.Flags) {
+			fmt.Fprintf(&b, " %s=on", flag)
+		}
+	}
+	if params.Mtu != nil {
+		fmt.Fprintf(&b, " mtu=%d", *params.Mtu)
+	}
+	return b.String()
+}
+
+func faceFlagStrings(flags uint64) []string {
+	strs := []string{}
+	if flags&mgmt.FaceFlagLocalFieldsEnabled != 0 {
+		strs = append(strs, "local-fields")
+	}
+	if flags&mgmt.FaceFlagLpReliabilityEnabled != 0 {
+		strs = append(strs, "lp-reliability")
+	}
+	if flags&mgmt.FaceFlagCongestionMarkingEnabled != 0 {
+		strs = append(strs, "congestion-marking")
+	}
+	return strs
+}
+
+func scopeString(scope uint64) string {
+	if scope == mgmt.FaceScopeLocal {
+		return "local"
+	}
+	return "non-local"
+}
+
+func persistencyString(persistency uint64) string {
+	switch persistency {
+	case mgmt.FacePersPersistent:
+		return "persistent"
+	case mgmt.FacePersOnDemand:
+		return "on-demand"
+	case mgmt.FacePersPermanent:
+		return "permanent"
+	default:
+		return strconv.FormatUint(persistency, 10)
+	}
+}
+
+func parsePersistency(s string) uint64 {
+	switch s {
+	case "persistent":
+		return mgmt.FacePersPersistent
+	case "permanent":
+		return mgmt.FacePersPermanent
+	default:
+		fatalf("Invalid persistency: %s", s)
+		return 0
+	}
+}
+
+func linkTypeString(linkType uint64) string {
+	switch linkType {
+	case mgmt.FaceLinkPointToPoint:
+		return "point-to-point"
+	case mgmt.FaceLinkMultiAccess:
+		return "multi-access"
+	case mgmt.FaceLinkAdHoc:
+		return "adhoc"
+	default:
+		return strconv.FormatUint(linkType, 10)
+	}
+}
+
+func parseOnOff(key string, s string) bool {
+	switch s {
+	case "on", "true", "yes":
+		return true
+	case "off", "false", "no":
+		return false
+	default:
+		fatalf("Invalid %s: %s", key, s)
+		return false
+	}
+}
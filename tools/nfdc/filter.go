@@ -0,0 +1,76 @@
+package nfdc
+
+import (
+	"fmt"
+	"strings"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/utils"
+)
+
+func (t *Tool) filterList(args []string) {
+	parseArgs(args, nil)
+
+	data := t.fetchStatusDataset("filter", "list")
+	status, err := mgmt.ParseFilterRuleMsg(enc.NewWireReader(data), true)
+	if err != nil {
+		fatalf("Error parsing filter rules: %+v", err)
+	}
+
+	for _, rule := range status.Rules {
+		fmt.Printf("id=%d action=%s prefix=%s%s hits=%d\n",
+			rule.RuleId, rule.Action, rule.Name,
+			formatFilterMatch(rule.Direction, rule.PacketType, rule.Uri, rule.Scope), rule.NHits)
+	}
+}
+
+func (t *Tool) filterAdd(args []string) {
+	opts := parseArgs(args, []string{"action", "prefix"})
+
+	ctrl := &mgmt.ControlArgs{
+		Action: utils.IdPtr(require(opts, "action")),
+		Name:   parseName(require(opts, "prefix")),
+	}
+	for key, field := range map[string]**string{
+		"direction": &ctrl.Direction,
+		"type":      &ctrl.PacketType,
+		"face":      &ctrl.Uri,
+		"scope":     &ctrl.Scope,
+	} {
+		if val, ok := opts[key]; ok {
+			*field = utils.IdPtr(val)
+		}
+	}
+
+	params := t.exec("filter", "add", ctrl)
+	fmt.Printf("filter-added id=%d action=%s prefix=%s%s\n",
+		optValue(params.RuleId), *ctrl.Action, ctrl.Name,
+		formatFilterMatch(ctrl.Direction, ctrl.PacketType, ctrl.Uri, ctrl.Scope))
+}
+
+func (t *Tool) filterRemove(args []string) {
+	opts := parseArgs(args, []string{"id"})
+
+	id := parseUint("id", require(opts, "id"))
+	t.exec("filter", "remove", &mgmt.ControlArgs{RuleId: utils.IdPtr(id)})
+	fmt.Printf("filter-removed id=%d\n", id)
+}
+
+func formatFilterMatch(direction, packetType, face, scope *string) string {
+	var b strings.Builder
+	for _, field := range []struct {
+		key string
+		val *string
+	}{
+		{"direction", direction},
+		{"type", packetType},
+		{"face", face},
+		{"scope", scope},
+	} {
+		if field.val != nil {
+			fmt.Fprintf(&b, " %s=%s", field.key, *field.val)
+		}
+	}
+	return b.String()
+}
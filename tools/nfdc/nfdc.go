@@ -0,0 +1,315 @@
+package nfdc
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/named-data/ndnd/cmd"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/engine"
+	"github.com/named-data/ndnd/std/engine/basic"
+	"github.com/named-data/ndnd/std/engine/face"
+	"github.com/named-data/ndnd/std/log"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/object"
+	"github.com/named-data/ndnd/std/utils"
+)
+
+// defaultTransport is the forwarder socket used when NDN_CLIENT_TRANSPORT is not set.
+const defaultTransport = "unix:///var/run/nfd/nfd.sock"
+
+// defaultTCPPort is the port of TCP transports without one.
+const defaultTCPPort = "6363"
+
+// Tool is a management client of the local forwarder, with the commands of NFD's nfdc.
+type Tool struct {
+	engine *basic.Engine
+	client *object.Client
+}
+
+// Commands returns the subcommands of nfdc.
+func Commands() []*cmd.CmdTree {
+	t := &Tool{}
+	exec := func(fun func(args []string)) func([]string) {
+		return func(args []string) {
+			t.start()
+			defer t.stop()
+			fun(args)
+		}
+	}
+
+	return []*cmd.CmdTree{{
+		Name: "status",
+		Help: "Print forwarder status",
+		Sub: []*cmd.CmdTree{{
+			Name: "general",
+			Help: "Print general forwarder status",
+			Fun:  exec(t.statusGeneral),
+		}},
+	}, {
+		Name: "face",
+		Help: "Manage faces",
+		Sub: []*cmd.CmdTree{{
+			Name: "list",
+			Help: "Print face list",
+			Fun:  exec(t.faceList),
+		}, {
+			Name: "create",
+			Help: "Create a face",
+			Fun:  exec(t.faceCreate),
+		}, {
+			Name: "destroy",
+			Help: "Destroy a face",
+			Fun:  exec(t.faceDestroy),
+		}},
+	}, {
+		Name: "route",
+		Help: "Manage routes",
+		Sub: []*cmd.CmdTree{{
+			Name: "list",
+			Help: "Print RIB routes",
+			Fun:  exec(t.routeList),
+		}, {
+			Name: "add",
+			Help: "Add a route",
+			Fun:  exec(t.routeAdd),
+		}, {
+			Name: "remove",
+			Help: "Remove a route",
+			Fun:  exec(t.routeRemove),
+		}},
+	}, {
+		Name: "strategy",
+		Help: "Manage strategy choices",
+		Sub: []*cmd.CmdTree{{
+			Name: "list",
+			Help: "Print strategy choices",
+			Fun:  exec(t.strategyList),
+		}, {
+			Name: "set",
+			Help: "Set the strategy of a prefix",
+			Fun:  exec(t.strategySet),
+		}, {
+			Name: "unset",
+			Help: "Clear the strategy of a prefix",
+			Fun:  exec(t.strategyUnset),
+		}},
+	}, {
+		Name: "cs",
+		Help: "Manage the Content Store",
+		Sub: []*cmd.CmdTree{{
+			Name: "info",
+			Help: "Print Content Store information",
+			Fun:  exec(t.csInfo),
+		}, {
+			Name: "config",
+			Help: "Change Content Store settings",
+			Fun:  exec(t.csConfig),
+		}, {
+			Name: "erase",
+			Help: "Erase cached Data under a prefix",
+			Fun:  exec(t.csErase),
+		}},
+	}, {
+		Name: "filter",
+		Help: "Manage packet filter rules (YaNFD)",
+		Sub: []*cmd.CmdTree{{
+			Name: "list",
+			Help: "Print packet filter rules",
+			Fun:  exec(t.filterList),
+		}, {
+			Name: "add",
+			Help: "Add a packet filter rule",
+			Fun:  exec(t.filterAdd),
+		}, {
+			Name: "remove",
+			Help: "Remove a packet filter rule",
+			Fun:  exec(t.filterRemove),
+		}},
+	}}
+}
+
+// start connects to the forwarder of NDN_CLIENT_TRANSPORT, as in NFD's client configuration.
+func (t *Tool) start() {
+	log.SetLevel(log.WarnLevel)
+
+	transport := os.Getenv("NDN_CLIENT_TRANSPORT")
+	if transport == "" {
+		transport = defaultTransport
+	}
+	network, addr, err := parseTransport(transport)
+	if err != nil {
+		fatalf("Unsupported transport %s: %+v", transport, err)
+	}
+
+	t.engine = engine.NewBasicEngine(face.NewStreamFace(network, addr, true)).(*basic.Engine)
+	if err := t.engine.Start(); err != nil {
+		fatalf("Unable to connect to the forwarder: %+v", err)
+	}
+
+	t.client = object.NewClient(t.engine, object.NewMemoryStore())
+	if err := t.client.Start(); err != nil {
+		fatalf("Unable to start object client: %+v", err)
+	}
+}
+
+// parseTransport returns the network and address of a forwarder transport URI, which
+// is a Unix socket (unix:///path) or a TCP endpoint (tcp://host:port, port 6363 by default).
+// Management commands are only accepted on local faces, so TCP must be to a loopback address.
+func parseTransport(transport string) (network string, addr string, err error) {
+	u, err := url.Parse(transport)
+	if err != nil {
+		return "", "", err
+	}
+
+	switch u.Scheme {
+	case "unix":
+		if u.Path == "" {
+			return "", "", errors.New("missing socket path")
+		}
+		return "unix", u.Path, nil
+	case "tcp", "tcp4", "tcp6":
+		if u.Hostname() == "" {
+			return "", "", errors.New("missing host")
+		}
+		port := u.Port()
+		if port == "" {
+			port = defaultTCPPort
+		}
+		return u.Scheme, net.JoinHostPort(u.Hostname(), port), nil
+	default:
+		return "", "", fmt.Errorf("unknown scheme %s", u.Scheme)
+	}
+}
+
+func (t *Tool) stop() {
+	t.client.Stop()
+	t.engine.Stop()
+}
+
+// exec executes a management command and returns the parameters of the response.
+func (t *Tool) exec(module string, verb string, args *mgmt.ControlArgs) *mgmt.ControlArgs {
+	resp, err := t.engine.ExecMgmtCmdWithResponse(module, verb, args)
+	if err != nil {
+		fatalf("Error %s %s: %+v", module, verb, err)
+	}
+	if resp == nil || resp.Val == nil || resp.Val.Params == nil {
+		return &mgmt.ControlArgs{}
+	}
+	return resp.Val.Params
+}
+
+// fetchStatusDataset fetches all segments of a status dataset of the forwarder.
+func (t *Tool) fetchStatusDataset(module string, dataset string) enc.Wire {
+	name := enc.Name{
+		enc.NewStringComponent(enc.TypeGenericNameComponent, "localhost"),
+		enc.NewStringComponent(enc.TypeGenericNameComponent, "nfd"),
+		enc.NewStringComponent(enc.TypeGenericNameComponent, module),
+		enc.NewStringComponent(enc.TypeGenericNameComponent, dataset),
+	}
+
+	type result struct {
+		content enc.Wire
+		err     error
+	}
+	ch := make(chan result, 1)
+	t.client.ConsumeStatusDataset(name, func(content enc.Wire, err error) {
+		ch <- result{content, err}
+	})
+
+	res := <-ch
+	if res.err != nil {
+		fatalf("Unable to fetch %s: %+v", name, res.err)
+	}
+	return res.content
+}
+
+// parseArgs parses the arguments of a command, given as key-value pairs as in nfdc.
+// The values of the positional keys may be given alone and in order before other
+// arguments, and flags are keys without value.
+func parseArgs(args []string, positional []string, flags ...string) map[string]string {
+	parsed := make(map[string]string)
+	isFlag := func(key string) bool {
+		return slices.Contains(flags, key)
+	}
+
+	// A token is a positional value if it is not the name of a positional key, and
+	// either the remaining tokens cannot all be key-value pairs, or there are enough
+	// positional keys left for two values
+	remaining := 0
+	for _, arg := range args[1:] {
+		if !isFlag(arg) {
+			remaining++
+		}
+	}
+	isPositional := func(pos int, token string) bool {
+		return pos < len(positional) && !slices.Contains(positional, token) &&
+			(remaining%2 == 1 || len(positional)-pos >= 2)
+	}
+
+	pos := 0
+	for i := 1; i < len(args); i++ {
+		key := args[i]
+		switch {
+		case isFlag(key):
+			parsed[key] = "true"
+			continue
+		case isPositional(pos, key):
+			parsed[positional[pos]] = key
+			pos++
+			remaining--
+			continue
+		case i+1 < len(args):
+			parsed[key] = args[i+1]
+			i++
+			remaining -= 2
+		default:
+			fatalf("Missing value for %s", key)
+		}
+		pos = len(positional) // no positional values after a key
+	}
+	return parsed
+}
+
+// require returns the value of an argument, or exits if it is missing.
+func require(args map[string]string, key string) string {
+	val, ok := args[key]
+	if !ok {
+		fatalf("Missing argument: %s", key)
+	}
+	return val
+}
+
+func parseName(s string) enc.Name {
+	name, err := enc.NameFromStr(s)
+	if err != nil {
+		fatalf("Invalid name %s: %+v", s, err)
+	}
+	return name
+}
+
+func parseUint(key string, s string) uint64 {
+	val, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		fatalf("Invalid %s: %s", key, s)
+	}
+	return val
+}
+
+// optUint parses an optional numeric argument.
+func optUint(args map[string]string, key string) *uint64 {
+	if s, ok := args[key]; ok {
+		return utils.IdPtr(parseUint(key, s))
+	}
+	return nil
+}
+
+func fatalf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,103 @@
+package nfdc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseArgs(t *testing.T) {
+	routeAdd := []string{"prefix", "nexthop"}
+	flags := []string{"no-inherit", "capture"}
+
+	tests := []struct {
+		name       string
+		args       []string
+		positional []string
+		expected   map[string]string
+	}{{
+		name:     "no arguments",
+		args:     []string{"list"},
+		expected: map[string]string{},
+	}, {
+		name:       "positional",
+		args:       []string{"add", "/a", "300"},
+		positional: routeAdd,
+		expected:   map[string]string{"prefix": "/a", "nexthop": "300"},
+	}, {
+		name:       "key-value",
+		args:       []string{"add", "prefix", "/a", "nexthop", "300", "cost", "10"},
+		positional: routeAdd,
+		expected:   map[string]string{"prefix": "/a", "nexthop": "300", "cost": "10"},
+	}, {
+		name:       "key-value in any order",
+		args:       []string{"add", "nexthop", "300", "prefix", "/a"},
+		positional: routeAdd,
+		expected:   map[string]string{"prefix": "/a", "nexthop": "300"},
+	}, {
+		name:       "positional then key-value",
+		args:       []string{"add", "/a", "nexthop", "300", "cost", "10"},
+		positional: routeAdd,
+		expected:   map[string]string{"prefix": "/a", "nexthop": "300", "cost": "10"},
+	}, {
+		name:       "fewer positional values than keys",
+		args:       []string{"add", "/a", "cost", "10"},
+		positional: routeAdd,
+		expected:   map[string]string{"prefix": "/a", "cost": "10"},
+	}, {
+		name:       "all positional then key-value",
+		args:       []string{"add", "/a", "300", "cost", "10"},
+		positional: routeAdd,
+		expected:   map[string]string{"prefix": "/a", "nexthop": "300", "cost": "10"},
+	}, {
+		name:       "flags",
+		args:       []string{"add", "/a", "no-inherit", "300", "capture"},
+		positional: routeAdd,
+		expected:   map[string]string{"prefix": "/a", "nexthop": "300", "no-inherit": "true", "capture": "true"},
+	}, {
+		name:       "flags between key-value pairs",
+		args:       []string{"add", "prefix", "/a", "capture", "nexthop", "300"},
+		positional: routeAdd,
+		expected:   map[string]string{"prefix": "/a", "nexthop": "300", "capture": "true"},
+	}, {
+		name:       "key-value with a single positional key",
+		args:       []string{"create", "persistency", "permanent", "remote", "udp4://192.0.2.1:6363"},
+		positional: []string{"remote"},
+		expected:   map[string]string{"remote": "udp4://192.0.2.1:6363", "persistency": "permanent"},
+	}, {
+		name:       "single positional key",
+		args:       []string{"create", "udp4://192.0.2.1:6363", "persistency", "permanent"},
+		positional: []string{"remote"},
+		expected:   map[string]string{"remote": "udp4://192.0.2.1:6363", "persistency": "permanent"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseArgs(tt.args, tt.positional, flags...))
+		})
+	}
+}
+
+func TestParseTransport(t *testing.T) {
+	tests := []struct {
+		transport string
+		network   string
+		addr      string
+	}{
+		{"unix:///var/run/nfd/nfd.sock", "unix", "/var/run/nfd/nfd.sock"},
+		{"tcp://127.0.0.1:6364", "tcp", "127.0.0.1:6364"},
+		{"tcp4://localhost", "tcp4", "localhost:6363"},
+		{"tcp6://[::1]", "tcp6", "[::1]:6363"},
+	}
+	for _, tt := range tests {
+		network, addr, err := parseTransport(tt.transport)
+		assert.NoError(t, err, tt.transport)
+		assert.Equal(t, tt.network, network, tt.transport)
+		assert.Equal(t, tt.addr, addr, tt.transport)
+	}
+
+	for _, transport := range []string{"unix://", "tcp://", "udp4://127.0.0.1:6363", "ws://localhost:9696", "%"} {
+		_, _, err := parseTransport(transport)
+		assert.Error(t, err, transport)
+	}
+}
@@ -0,0 +1,151 @@
+package nfdc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/utils"
+)
+
+// routeOrigins are the names of route origins, as in nfdc.
+var routeOrigins = map[string]uint64{
+	"app":       0,
+	"autoreg":   64,
+	"client":    65,
+	"autoconf":  66,
+	"nlsr":      128,
+	"prefixann": 129,
+	"static":    255,
+}
+
+func (t *Tool) routeList(args []string) {
+	parseArgs(args, nil)
+
+	data := t.fetchStatusDataset("rib", "list")
+	status, err := mgmt.ParseRibStatus(enc.NewWireReader(data), true)
+	if err != nil {
+		fatalf("Error parsing RIB status: %+v", err)
+	}
+
+	for _, entry := range status.Entries {
+		for _, route := range entry.Routes {
+			expires := "never"
+			if route.ExpirationPeriod != nil {
+				expires = fmt.Sprintf("%ds", *route.ExpirationPeriod/1000)
+			}
+			fmt.Printf("prefix=%s nexthop=%d origin=%s cost=%d flags=%s expires=%s\n",
+				entry.Name, route.FaceId, originString(route.Origin), route.Cost, routeFlagsString(route.Flags), expires)
+		}
+	}
+}
+
+func (t *Tool) routeAdd(args []string) {
+	opts := parseArgs(args, []string{"prefix", "nexthop"}, "no-inherit", "capture")
+
+	flags := mgmt.RouteFlagChildInherit
+	if _, ok := opts["no-inherit"]; ok {
+		flags &^= mgmt.RouteFlagChildInherit
+	}
+	if _, ok := opts["capture"]; ok {
+		flags |= mgmt.RouteFlagCapture
+	}
+
+	ctrl := &mgmt.ControlArgs{
+		Name:             parseName(require(opts, "prefix")),
+		FaceId:           utils.IdPtr(t.nexthopFaceID(require(opts, "nexthop"))),
+		Origin:           utils.IdPtr(parseOrigin(opts)),
+		Cost:             optUint(opts, "cost"),
+		Flags:            utils.IdPtr(flags),
+		ExpirationPeriod: optUint(opts, "expires"),
+	}
+	if ctrl.Cost == nil {
+		ctrl.Cost = utils.IdPtr(uint64(0))
+	}
+
+	params := t.exec("rib", "register", ctrl)
+	if params.FaceId == nil {
+		params = ctrl
+	}
+	expires := "never"
+	if params.ExpirationPeriod != nil {
+		expires = fmt.Sprintf("%dms", *params.ExpirationPeriod)
+	}
+	fmt.Printf("route-add-accepted prefix=%s nexthop=%d origin=%s cost=%d flags=%s expires=%s\n",
+		params.Name, *params.FaceId, originString(optValue(params.Origin)), optValue(params.Cost),
+		routeFlagsString(optValue(params.Flags)), expires)
+}
+
+// nexthopFaceID resolves the nexthop of a new route, given by FaceId or remote URI.
+// As in nfdc, a persistent face is created if there is no face with the remote URI.
+func (t *Tool) nexthopFaceID(nexthop string) uint64 {
+	if faceID, err := strconv.ParseUint(nexthop, 10, 64); err == nil {
+		return faceID
+	}
+	if face := t.lookupFace(nexthop); face != nil {
+		return face.FaceId
+	}
+	params := t.createFace(&mgmt.ControlArgs{
+		Uri:             utils.IdPtr(nexthop),
+		FacePersistency: utils.IdPtr(mgmt.FacePersPersistent),
+	})
+	return *params.FaceId
+}
+
+func (t *Tool) routeRemove(args []string) {
+	opts := parseArgs(args, []string{"prefix", "nexthop"})
+
+	ctrl := &mgmt.ControlArgs{
+		Name:   parseName(require(opts, "prefix")),
+		FaceId: utils.IdPtr(t.faceID(require(opts, "nexthop"))),
+		Origin: utils.IdPtr(parseOrigin(opts)),
+	}
+	t.exec("rib", "unregister", ctrl)
+	fmt.Printf("route-removed prefix=%s nexthop=%d origin=%s\n",
+		ctrl.Name, *ctrl.FaceId, originString(*ctrl.Origin))
+}
+
+// parseOrigin parses the origin argument, by name or number, which defaults to static.
+func parseOrigin(opts map[string]string) uint64 {
+	origin, ok := opts["origin"]
+	if !ok {
+		return routeOrigins["static"]
+	}
+	if val, ok := routeOrigins[origin]; ok {
+		return val
+	}
+	return parseUint("origin", origin)
+}
+
+func originString(origin uint64) string {
+	for name, val := range routeOrigins {
+		if val == origin {
+			return name
+		}
+	}
+	return strconv.FormatUint(origin, 10)
+}
+
+func routeFlagsString(flags uint64) string {
+	strs := []string{}
+	if flags&mgmt.RouteFlagChildInherit != 0 {
+		strs = append(strs, "child-inherit")
+	}
+	if flags&mgmt.RouteFlagCapture != 0 {
+		strs = append(strs, "capture")
+	}
+	if len(strs) == 0 {
+		return "none"
+	}
+	return strings.Join(strs, "|")
+}
+
+// optValue returns the value of an optional number, or zero if absent.
+func optValue(val *uint64) uint64 {
+	if val == nil {
+		return 0
+	}
+	return *val
+}
@@ -0,0 +1,59 @@
+package nfdc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+)
+
+type keyValue struct {
+	key string
+	val any
+}
+
+func (t *Tool) statusGeneral(args []string) {
+	parseArgs(args, nil)
+
+	data := t.fetchStatusDataset("status", "general")
+	status, err := mgmt.ParseGeneralStatus(enc.NewWireReader(data), true)
+	if err != nil {
+		fatalf("Error parsing general status: %+v", err)
+	}
+
+	start := time.UnixMilli(int64(status.StartTimestamp))
+	current := time.UnixMilli(int64(status.CurrentTimestamp))
+	fmt.Println("General NFD status:")
+	printAligned([]keyValue{
+		{"version", status.NfdVersion},
+		{"startTime", start.Format(time.RFC3339)},
+		{"currentTime", current.Format(time.RFC3339)},
+		{"uptime", current.Sub(start).Round(time.Second)},
+		{"nNameTreeEntries", status.NNameTreeEntries},
+		{"nFibEntries", status.NFibEntries},
+		{"nPitEntries", status.NPitEntries},
+		{"nMeasurementsEntries", status.NMeasurementsEntries},
+		{"nCsEntries", status.NCsEntries},
+		{"nInInterests", status.NInInterests},
+		{"nOutInterests", status.NOutInterests},
+		{"nInData", status.NInData},
+		{"nOutData", status.NOutData},
+		{"nInNacks", status.NInNacks},
+		{"nOutNacks", status.NOutNacks},
+		{"nSatisfiedInterests", status.NSatisfiedInterests},
+		{"nUnsatisfiedInterests", status.NUnsatisfiedInterests},
+	})
+}
+
+// printAligned prints key-value pairs with the keys aligned to the right, as in nfdc.
+func printAligned(kvs []keyValue) {
+	width := 0
+	for _, kv := range kvs {
+		width = max(width, len(kv.key))
+	}
+	for _, kv := range kvs {
+		fmt.Printf("  %s%s=%v\n", strings.Repeat(" ", width-len(kv.key)), kv.key, kv.val)
+	}
+}
@@ -0,0 +1,60 @@
+package nfdc
+
+import (
+	"fmt"
+	"strings"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+)
+
+// strategyPrefix is the prefix of strategy names, which may be omitted on the command line.
+const strategyPrefix = "/localhost/nfd/strategy/"
+
+func (t *Tool) strategyList(args []string) {
+	parseArgs(args, nil)
+
+	data := t.fetchStatusDataset("strategy-choice", "list")
+	status, err := mgmt.ParseStrategyChoiceMsg(enc.NewWireReader(data), true)
+	if err != nil {
+		fatalf("Error parsing strategy choices: %+v", err)
+	}
+
+	for _, choice := range status.StrategyChoices {
+		strategy := enc.Name{}
+		if choice.Strategy != nil {
+			strategy = choice.Strategy.Name
+		}
+		fmt.Printf("prefix=%s strategy=%s\n", choice.Name, strategy)
+	}
+}
+
+func (t *Tool) strategySet(args []string) {
+	opts := parseArgs(args, []string{"prefix", "strategy"})
+
+	strategy := require(opts, "strategy")
+	if !strings.HasPrefix(strategy, "/") {
+		strategy = strategyPrefix + strategy
+	}
+
+	ctrl := &mgmt.ControlArgs{
+		Name:     parseName(require(opts, "prefix")),
+		Strategy: &mgmt.Strategy{Name: parseName(strategy)},
+	}
+	params := t.exec("strategy-choice", "set", ctrl)
+	if params.Strategy != nil {
+		ctrl.Strategy = params.Strategy
+	}
+	fmt.Printf("strategy-set prefix=%s strategy=%s\n", ctrl.Name, ctrl.Strategy.Name)
+}
+
+func (t *Tool) strategyUnset(args []string) {
+	opts := parseArgs(args, []string{"prefix"})
+
+	ctrl := &mgmt.ControlArgs{Name: parseName(require(opts, "prefix"))}
+	if len(ctrl.Name) == 0 {
+		fatalf("Unable to unset the default strategy")
+	}
+	t.exec("strategy-choice", "unset", ctrl)
+	fmt.Printf("strategy-unset prefix=%s\n", ctrl.Name)
+}